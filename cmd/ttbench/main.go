@@ -0,0 +1,139 @@
+// ttbench measures the candidate building, crossover and mutation of the genetic algorithm on a
+// synthetic university, large unless -preset says otherwise. the benchmarks of the computed package
+// measure the same cases against the bool grids the bitsets replaced and fail below a 5x speedup
+//
+//	ttbench -runs 5
+//	ttbench -preset medium -shared-courses 0.3 -population 40
+//	go test -run '^$' -bench . ./internal/timetable/computed
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"os"
+	"runtime"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	"github.com/Cxons/unischedulebackend/internal/timetable/synthetic"
+)
+
+// how long and how much memory one op of a case took
+type measurement struct {
+	perOp       time.Duration
+	allocsPerOp uint64
+	bytesPerOp  uint64
+}
+
+// runs op runs times after a warm up run
+func measure(runs int, op func()) measurement {
+	op()
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < runs; i++ {
+		op()
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return measurement{
+		perOp:       elapsed / time.Duration(runs),
+		allocsPerOp: (after.Mallocs - before.Mallocs) / uint64(runs),
+		bytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / uint64(runs),
+	}
+}
+
+// the university of the config the flags give, with the default constraints
+func university(config func() (synthetic.Config, error), seed int64) (*computed.PreComputed, error) {
+	c, err := config()
+	if err != nil {
+		return nil, err
+	}
+	uni, err := synthetic.Generate(c, seed)
+	if err != nil {
+		return nil, err
+	}
+	problem, err := uni.Problem()
+	if err != nil {
+		return nil, err
+	}
+	pre, _, err := problem.PreComputed()
+	return pre, err
+}
+
+type benchCase struct {
+	name string
+	run  func()
+}
+
+func main() {
+	config := synthetic.Flags(flag.CommandLine)
+	preset := flag.Lookup("preset")
+	preset.DefValue = "large"
+	preset.Value.Set(preset.DefValue)
+	seed := flag.Int64("seed", 1, "seed of the university and of every run")
+	runs := flag.Int("runs", 2, "timed runs of every case")
+	candidates := flag.Int("candidates", 4, "candidates built from scratch per op")
+	population := flag.Int("population", 20, "size of the population bred from")
+	flag.Parse()
+
+	// the solver logs every candidate it builds
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	pre, err := university(config, *seed)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	params := computed.DefaultGAParams()
+	courseSessions := computed.ComputeCourseSessions(pre)
+	buf := computed.NewSearchBuffers(pre)
+
+	// the parents every crossover case breeds from
+	parents := computed.BuildPopulation(pre, *seed, *population, params.SampleK, []*computed.SearchBuffers{buf})
+
+	cases := []benchCase{
+		{
+			name: "build candidates",
+			run: func() {
+				for i := 0; i < *candidates; i++ {
+					computed.BuildOneCandidate(rand.New(rand.NewSource(int64(i))), pre, params.SampleK, buf)
+				}
+			},
+		},
+		{
+			name: "crossover and mutation",
+			run: func() {
+				r := rand.New(rand.NewSource(*seed))
+				for i := 0; i < *population; i++ {
+					parent1, parent2 := computed.SelectParents(parents, params.TournamentSize, r)
+					child := computed.Crossover(pre, parent1, parent2, courseSessions, buf)
+					computed.Mutation(pre, child, r, params.MutationRate, buf)
+				}
+			},
+		},
+		{
+			// a whole generation on one worker, fitness and soft constraints included
+			name: "next generation",
+			run: func() {
+				previous := append([]*computed.Candidate(nil), parents...)
+				rngs := []*rand.Rand{rand.New(rand.NewSource(*seed))}
+				computed.BuildNextGeneration(pre, previous, courseSessions, rngs, []*computed.SearchBuffers{buf}, params)
+			},
+		},
+	}
+
+	fmt.Printf("%d sessions, %d venues, %d lecturers, %d cohorts, %d slots\n\n", len(pre.SessionAtoms), pre.NumVenues, pre.NumLecturers, pre.NumCohorts, pre.TotalSlots)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "case\ttime/op\tallocs/op\tMB/op\t")
+	for _, c := range cases {
+		m := measure(*runs, c.run)
+		fmt.Fprintf(w, "%s\t%v\t%d\t%.1f\t\n", c.name, m.perOp.Round(time.Microsecond), m.allocsPerOp, float64(m.bytesPerOp)/(1<<20))
+	}
+	w.Flush()
+}
@@ -0,0 +1,74 @@
+// ttgen generates a synthetic university, either as a problem file ttsolve and ttsolvebench read or
+// as sql that seeds an empty database. a preset gives its size and the other flags change it, the
+// same flags and seed always give the same university.
+//
+//	ttgen -preset small -seed 3 -out small.json
+//	ttgen -preset large -shared-courses 0.3 -lecturer-unavailability 0.2 -format sql -out large.sql
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	"github.com/Cxons/unischedulebackend/internal/timetable/synthetic"
+)
+
+func run() error {
+	config := synthetic.Flags(flag.CommandLine)
+	seed := flag.Int64("seed", 1, "seed of the university")
+	format := flag.String("format", "problem", "problem or sql")
+	outPath := flag.String("out", "-", "where the university goes, - for stdout")
+	flag.Parse()
+
+	if *format != "problem" && *format != "sql" {
+		return fmt.Errorf("unknown format %q, use problem or sql", *format)
+	}
+	// building the problem logs what it maps at info
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	c, err := config()
+	if err != nil {
+		return err
+	}
+	uni, err := synthetic.Generate(c, *seed)
+	if err != nil {
+		return err
+	}
+
+	var out io.WriteCloser = os.Stdout
+	if *outPath != "-" {
+		if out, err = os.Create(*outPath); err != nil {
+			return err
+		}
+	}
+	if *format == "sql" {
+		err = uni.WriteSQL(out)
+	} else {
+		var problem *computed.Problem
+		if problem, err = uni.Problem(); err == nil {
+			err = computed.WriteProblem(out, problem)
+		}
+	}
+	if *outPath != "-" {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d faculties, %d departments, %d lecturers, %d cohorts, %d courses, %d venues\n",
+		uni.Name, len(uni.Faculties), len(uni.Departments), len(uni.Lecturers), len(uni.Cohorts), len(uni.Courses), len(uni.Venues))
+	return nil
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "ttgen:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,211 @@
+// ttsolve solves a timetable problem file without the server or the database. the file is the
+// format of computed.Problem, e.g what POST /timetable/export returns (the response as is or just
+// its Data). the solver and params saved in the file are used unless a flag overrides them, and the
+// placements are written with a report of every hard and soft violation as JSON or CSV.
+//
+//	ttsolve -problem uni.json -seed 7 -generations 300 -out result.json
+//	ttsolve -problem uni.json -solver ANNEALING -format csv -out placements.csv -violations violations.csv
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+)
+
+type options struct {
+	problemPath    string
+	solver         string
+	format         string
+	outPath        string
+	violationsPath string
+	verbose        bool
+	// only applied when set on the command line
+	seed             int64
+	populationSize   int
+	generations      int
+	mutationRate     float64
+	tournamentSize   int
+	elitismFraction  float64
+	sampleK          int
+	workers          int
+	stallGenerations int
+	timeBudget       time.Duration
+	stopWhenFeasible bool
+}
+
+func parseFlags() (options, map[string]bool) {
+	var opts options
+	flag.StringVar(&opts.problemPath, "problem", "-", "problem file to solve, - for stdin")
+	flag.StringVar(&opts.solver, "solver", "", "GENETIC, ANNEALING or GENETIC_TABU, defaults to the solver of the file")
+	flag.StringVar(&opts.format, "format", "json", "json or csv")
+	flag.StringVar(&opts.outPath, "out", "-", "where the placements go, - for stdout")
+	flag.StringVar(&opts.violationsPath, "violations", "", "csv only, where the violation report goes, after the placements when empty")
+	flag.BoolVar(&opts.verbose, "v", false, "log what the solver does to stderr")
+	flag.Int64Var(&opts.seed, "seed", 0, "seed of the run")
+	flag.IntVar(&opts.populationSize, "population", 0, "population size")
+	flag.IntVar(&opts.generations, "generations", 0, "generations")
+	flag.Float64Var(&opts.mutationRate, "mutation", 0, "mutation rate")
+	flag.IntVar(&opts.tournamentSize, "tournament", 0, "tournament size")
+	flag.Float64Var(&opts.elitismFraction, "elitism", 0, "elitism fraction")
+	flag.IntVar(&opts.sampleK, "sample-k", 0, "pick from the top k feasible pairs when building a candidate")
+	flag.IntVar(&opts.workers, "workers", 0, "children built at the same time, 0 for one per core")
+	flag.IntVar(&opts.stallGenerations, "stall", 0, "stop after this many generations without a better best fitness")
+	flag.DurationVar(&opts.timeBudget, "time-budget", 0, "stop the genetic algorithm after this long")
+	flag.BoolVar(&opts.stopWhenFeasible, "stop-when-feasible", false, "stop once the best candidate breaks no hard constraint")
+	flag.Parse()
+
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return opts, set
+}
+
+// the params of the file with the flags that were set applied over them
+func resolveParams(problem *computed.Problem, opts options, set map[string]bool) computed.GAParams {
+	params := problem.Params.GAParams()
+	if set["seed"] {
+		params.Seed = opts.seed
+	}
+	if set["population"] {
+		params.PopulationSize = opts.populationSize
+	}
+	if set["generations"] {
+		params.Generations = opts.generations
+	}
+	if set["mutation"] {
+		params.MutationRate = opts.mutationRate
+	}
+	if set["tournament"] {
+		params.TournamentSize = opts.tournamentSize
+	}
+	if set["elitism"] {
+		params.ElitismFraction = opts.elitismFraction
+	}
+	if set["sample-k"] {
+		params.SampleK = opts.sampleK
+	}
+	if set["workers"] {
+		params.Workers = opts.workers
+	}
+	if set["stall"] {
+		params.StallGenerations = opts.stallGenerations
+	}
+	if set["time-budget"] {
+		params.TimeBudget = opts.timeBudget
+	}
+	if set["stop-when-feasible"] {
+		params.StopWhenFeasible = opts.stopWhenFeasible
+	}
+	return params
+}
+
+// reads a problem file or the response of the export endpoint that wraps one
+func readProblem(path string) (*computed.Problem, error) {
+	var raw []byte
+	var err error
+	if path == "-" {
+		raw, err = io.ReadAll(os.Stdin)
+	} else {
+		raw, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var envelope struct {
+		Data json.RawMessage
+	}
+	if json.Unmarshal(raw, &envelope) == nil && len(envelope.Data) > 0 {
+		raw = envelope.Data
+	}
+	return computed.ReadProblem(bytes.NewReader(raw))
+}
+
+func create(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(path)
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func run() error {
+	opts, set := parseFlags()
+	if opts.format != "json" && opts.format != "csv" {
+		return fmt.Errorf("unknown format %q, use json or csv", opts.format)
+	}
+	level := slog.LevelWarn
+	if opts.verbose {
+		level = slog.LevelInfo
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	problem, err := readProblem(opts.problemPath)
+	if err != nil {
+		return err
+	}
+	pre, week, err := problem.PreComputed()
+	if err != nil {
+		return err
+	}
+	params := resolveParams(problem, opts, set)
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	name := problem.Solver
+	if opts.solver != "" {
+		name = opts.solver
+	}
+	solver, err := computed.NewSolver(name, params)
+	if err != nil {
+		return err
+	}
+
+	// ctrl-c stops the run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	started := time.Now()
+	cand, err := solver.Solve(ctx, pre, nil)
+	if err != nil {
+		return err
+	}
+	report := buildReport(problem, pre, week, cand, solver.Name(), params.Seed, time.Since(started))
+	fmt.Fprintf(os.Stderr, "%s: %d sessions, fitness %.6g, %d hard and %d soft violations in %v\n",
+		report.Solver, len(report.Placements), report.Fitness, report.HardViolations, report.SoftViolations, report.Duration)
+
+	out, err := create(opts.outPath)
+	if err != nil {
+		return err
+	}
+	if opts.format == "json" {
+		err = writeJSON(out, report)
+	} else {
+		err = writeCSV(out, opts.violationsPath, report)
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "ttsolve:", err)
+		os.Exit(1)
+	}
+}
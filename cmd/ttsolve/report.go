@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+)
+
+// what a run produced, placements refer to the ids of the problem file
+type report struct {
+	Solver         string      `json:"solver"`
+	Seed           int64       `json:"seed"`
+	Fitness        float64     `json:"fitness"`
+	HardPenalty    float64     `json:"hardPenalty"`
+	SoftPenalty    float64     `json:"softPenalty"`
+	HardViolations int         `json:"hardViolations"`
+	SoftViolations int         `json:"softViolations"`
+	StopReason     string      `json:"stopReason,omitempty"`
+	GenerationsRun *int        `json:"generationsRun,omitempty"`
+	Duration       string      `json:"duration"`
+	Placements     []placement `json:"placements"`
+	Violations     []violation `json:"violations"`
+}
+
+type placement struct {
+	Session    int      `json:"session"` // position in the sessions of the problem file
+	Course     string   `json:"course"`
+	CourseCode string   `json:"courseCode"`
+	Slot       int      `json:"slot"`
+	Day        string   `json:"day"`
+	Start      string   `json:"start"`
+	End        string   `json:"end"`
+	Venue      string   `json:"venue"`
+	VenueName  string   `json:"venueName"`
+	Lecturers  []string `json:"lecturers"`
+	Cohorts    []string `json:"cohorts"`
+	Pinned     bool     `json:"pinned"`
+	Conflict   bool     `json:"conflict"` // part of a hard violation
+}
+
+type violation struct {
+	Type      string   `json:"type"`
+	Hard      bool     `json:"hard"`
+	Penalty   float64  `json:"penalty"`
+	Day       string   `json:"day"`
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	Courses   []string `json:"courses"`
+	Lecturers []string `json:"lecturers"`
+	Venues    []string `json:"venues"`
+	Cohorts   []string `json:"cohorts"`
+	Message   string   `json:"message"`
+}
+
+func buildReport(problem *computed.Problem, pre *computed.PreComputed, week computed.TeachingWeek, cand *computed.Candidate, solver string, seed int64, took time.Duration) report {
+	computed.MarkConflicts(pre, cand)
+	hard := computed.FindHardViolations(pre, cand)
+	soft := computed.FindSoftViolations(pre, cand)
+	scored := computed.RescoreCandidate(pre, cand)
+
+	r := report{
+		Solver:         solver,
+		Seed:           seed,
+		Fitness:        scored.Fitness,
+		HardPenalty:    scored.HardPenalty,
+		SoftPenalty:    scored.SoftPenalty,
+		HardViolations: len(hard),
+		SoftViolations: len(soft),
+		StopReason:     cand.StopReason,
+		Duration:       took.Round(time.Millisecond).String(),
+		Placements:     make([]placement, 0, len(cand.Placements)),
+	}
+	if len(cand.History) > 0 {
+		generations := len(cand.History) - 1
+		r.GenerationsRun = &generations
+	}
+
+	dayNames := week.DayNames()
+	slotsPerDay := week.SlotsPerDay()
+	for _, p := range cand.Placements {
+		session := problem.Sessions[p.SessionIdx]
+		atom := pre.SessionAtoms[p.SessionIdx]
+		row := placement{
+			Session:    p.SessionIdx,
+			Course:     session.Course,
+			CourseCode: problem.Courses[p.CourseIdx].Code,
+			Slot:       p.SlotIdx,
+			Lecturers:  session.Lecturers,
+			Cohorts:    session.Cohorts,
+			Pinned:     atom.Pinned,
+			Conflict:   p.Conflict,
+		}
+		if p.VenueIdx >= 0 && p.VenueIdx < len(problem.Venues) {
+			row.Venue = problem.Venues[p.VenueIdx].Id
+			row.VenueName = problem.Venues[p.VenueIdx].Name
+		}
+		if p.SlotIdx >= 0 && slotsPerDay > 0 {
+			row.Day = dayNames[p.SlotIdx/slotsPerDay]
+			start := week.SlotStartMinutes(p.SlotIdx % slotsPerDay)
+			row.Start = computed.FormatMinutes(start)
+			row.End = computed.FormatMinutes(start + atom.SessionDuration*week.SlotMinutes)
+		}
+		r.Placements = append(r.Placements, row)
+	}
+	sort.SliceStable(r.Placements, func(i, j int) bool {
+		return r.Placements[i].Session < r.Placements[j].Session
+	})
+
+	details := computed.DescribeViolations(pre, week, append(hard, soft...))
+	r.Violations = make([]violation, 0, len(details))
+	for _, d := range details {
+		r.Violations = append(r.Violations, violation{
+			Type:      d.Type,
+			Hard:      d.Hard,
+			Penalty:   d.Penalty,
+			Day:       d.Day,
+			Start:     d.StartTime,
+			End:       d.EndTime,
+			Courses:   d.Courses,
+			Lecturers: d.Lecturers,
+			Venues:    d.Venues,
+			Cohorts:   d.Cohorts,
+			Message:   d.Message,
+		})
+	}
+	return r
+}
+
+func writeJSON(w io.Writer, r report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// the placements as csv, then the violations in violationsPath or after a blank line when it is empty.
+// lists are joined with ;
+func writeCSV(w io.Writer, violationsPath string, r report) error {
+	placements := csv.NewWriter(w)
+	placements.Write([]string{"session", "course", "course_code", "slot", "day", "start", "end", "venue", "venue_name", "lecturers", "cohorts", "pinned", "conflict"})
+	for _, p := range r.Placements {
+		placements.Write([]string{
+			strconv.Itoa(p.Session), p.Course, p.CourseCode, strconv.Itoa(p.Slot), p.Day, p.Start, p.End,
+			p.Venue, p.VenueName, strings.Join(p.Lecturers, ";"), strings.Join(p.Cohorts, ";"),
+			strconv.FormatBool(p.Pinned), strconv.FormatBool(p.Conflict),
+		})
+	}
+	placements.Flush()
+	if err := placements.Error(); err != nil {
+		return err
+	}
+
+	out := w
+	if violationsPath != "" {
+		file, err := os.Create(violationsPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	} else if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+	violations := csv.NewWriter(out)
+	violations.Write([]string{"type", "hard", "penalty", "day", "start", "end", "courses", "lecturers", "venues", "cohorts", "message"})
+	for _, v := range r.Violations {
+		violations.Write([]string{
+			v.Type, strconv.FormatBool(v.Hard), formatFloat(v.Penalty), v.Day, v.Start, v.End,
+			strings.Join(v.Courses, ";"), strings.Join(v.Lecturers, ";"), strings.Join(v.Venues, ";"), strings.Join(v.Cohorts, ";"),
+			v.Message,
+		})
+	}
+	violations.Flush()
+	return violations.Error()
+}
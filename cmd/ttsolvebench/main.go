@@ -0,0 +1,289 @@
+// ttsolvebench runs the solvers on synthetic universities and problem files and reports, for every
+// instance and solver, the hard violations left, the soft penalty, the fitness and how long the run
+// took, with the mean of every solver across the instances at the end. every solver gets the same
+// params and seed so the runs can be compared.
+//
+//	ttsolvebench -presets small,medium -instances 3 -generations 200
+//	ttsolvebench -presets "" -problems a.json,b.json -solvers GENETIC,GENETIC_TABU -format csv
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	"github.com/Cxons/unischedulebackend/internal/timetable/synthetic"
+)
+
+// a problem the solvers are run on
+type instance struct {
+	name string
+	pre  *computed.PreComputed
+}
+
+type row struct {
+	Instance       string  `json:"instance"`
+	Sessions       int     `json:"sessions"`
+	Solver         string  `json:"solver"`
+	HardViolations int     `json:"hardViolations"`
+	HardPenalty    float64 `json:"hardPenalty"`
+	SoftPenalty    float64 `json:"softPenalty"`
+	Fitness        float64 `json:"fitness"`
+	Seconds        float64 `json:"seconds"`
+	Error          string  `json:"error,omitempty"`
+}
+
+// the mean of a solver across the instances it finished
+type summary struct {
+	Solver         string  `json:"solver"`
+	Runs           int     `json:"runs"`
+	Feasible       int     `json:"feasible"`
+	HardViolations float64 `json:"hardViolations"`
+	SoftPenalty    float64 `json:"softPenalty"`
+	Fitness        float64 `json:"fitness"`
+	Seconds        float64 `json:"seconds"`
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func loadInstances(presets []string, count int, firstSeed int64, problemPaths []string) ([]instance, error) {
+	var instances []instance
+	for _, preset := range presets {
+		build, ok := synthetic.Presets[preset]
+		if !ok {
+			return nil, fmt.Errorf("unknown preset %q, use one of %s", preset, strings.Join(synthetic.PresetNames(), ", "))
+		}
+		for i := range count {
+			seed := firstSeed + int64(i)
+			uni, err := synthetic.Generate(build(), seed)
+			if err != nil {
+				return nil, err
+			}
+			problem, err := uni.Problem()
+			if err != nil {
+				return nil, err
+			}
+			pre, _, err := problem.PreComputed()
+			if err != nil {
+				return nil, err
+			}
+			instances = append(instances, instance{name: fmt.Sprintf("%s-%d", preset, seed), pre: pre})
+		}
+	}
+	for _, path := range problemPaths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		problem, err := computed.ReadProblem(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		pre, _, err := problem.PreComputed()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		instances = append(instances, instance{name: path, pre: pre})
+	}
+	return instances, nil
+}
+
+func summarize(rows []row, solverNames []string) []summary {
+	summaries := make([]summary, len(solverNames))
+	for i, name := range solverNames {
+		s := summary{Solver: name}
+		for _, r := range rows {
+			if r.Solver != name || r.Error != "" {
+				continue
+			}
+			s.Runs++
+			if r.HardViolations == 0 {
+				s.Feasible++
+			}
+			s.HardViolations += float64(r.HardViolations)
+			s.SoftPenalty += r.SoftPenalty
+			s.Fitness += r.Fitness
+			s.Seconds += r.Seconds
+		}
+		if s.Runs > 0 {
+			n := float64(s.Runs)
+			s.HardViolations /= n
+			s.SoftPenalty /= n
+			s.Fitness /= n
+			s.Seconds /= n
+		}
+		summaries[i] = s
+	}
+	return summaries
+}
+
+func writeTable(w io.Writer, rows []row, summaries []summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "instance\tsessions\tsolver\thard\thard penalty\tsoft penalty\tfitness\ttime")
+	for _, r := range rows {
+		if r.Error != "" {
+			fmt.Fprintf(tw, "%s\t%d\t%s\tfailed: %s\n", r.Instance, r.Sessions, r.Solver, r.Error)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%.4g\t%.4g\t%.4g\t%.2fs\n",
+			r.Instance, r.Sessions, r.Solver, r.HardViolations, r.HardPenalty, r.SoftPenalty, r.Fitness, r.Seconds)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(tw, "solver\truns\tfeasible\tmean hard\tmean soft penalty\tmean fitness\tmean time")
+	for _, s := range summaries {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\t%.4g\t%.4g\t%.2fs\n",
+			s.Solver, s.Runs, s.Feasible, s.HardViolations, s.SoftPenalty, s.Fitness, s.Seconds)
+	}
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, rows []row) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"instance", "sessions", "solver", "hard_violations", "hard_penalty", "soft_penalty", "fitness", "seconds", "error"})
+	for _, r := range rows {
+		cw.Write([]string{
+			r.Instance,
+			strconv.Itoa(r.Sessions),
+			r.Solver,
+			strconv.Itoa(r.HardViolations),
+			strconv.FormatFloat(r.HardPenalty, 'g', -1, 64),
+			strconv.FormatFloat(r.SoftPenalty, 'g', -1, 64),
+			strconv.FormatFloat(r.Fitness, 'g', -1, 64),
+			strconv.FormatFloat(r.Seconds, 'f', 3, 64),
+			r.Error,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, rows []row, summaries []summary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Runs    []row     `json:"runs"`
+		Solvers []summary `json:"solvers"`
+	}{rows, summaries})
+}
+
+func run() error {
+	presets := flag.String("presets", "small,medium", "synthetic presets to generate, comma separated, one of "+strings.Join(synthetic.PresetNames(), ", "))
+	count := flag.Int("instances", 3, "universities generated per preset")
+	firstSeed := flag.Int64("instance-seed", 1, "seed of the first university of a preset, the next ones count up from it")
+	problems := flag.String("problems", "", "problem files to run as well, comma separated")
+	solverList := flag.String("solvers", strings.Join([]string{computed.SolverGenetic, computed.SolverAnnealing, computed.SolverGeneticTabu}, ","), "solvers to run, comma separated")
+	format := flag.String("format", "table", "table, csv or json")
+	verbose := flag.Bool("v", false, "log what the solvers do to stderr")
+	params := computed.DefaultGAParams()
+	flag.Int64Var(&params.Seed, "seed", 1, "seed of every solver run")
+	flag.IntVar(&params.PopulationSize, "population", params.PopulationSize, "population size")
+	flag.IntVar(&params.Generations, "generations", params.Generations, "generations")
+	flag.IntVar(&params.Workers, "workers", params.Workers, "children built at the same time, 0 for one per core")
+	flag.IntVar(&params.StallGenerations, "stall", params.StallGenerations, "stop after this many generations without a better best fitness")
+	flag.DurationVar(&params.TimeBudget, "time-budget", params.TimeBudget, "stop the genetic algorithm after this long")
+	flag.Parse()
+
+	if *format != "table" && *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown format %q, use table, csv or json", *format)
+	}
+	level := slog.LevelWarn
+	if *verbose {
+		level = slog.LevelInfo
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	solverNames := splitList(*solverList)
+	solvers := make([]computed.Solver, 0, len(solverNames))
+	for _, name := range solverNames {
+		solver, err := computed.NewSolver(name, params)
+		if err != nil {
+			return err
+		}
+		solvers = append(solvers, solver)
+	}
+	if len(solvers) == 0 {
+		return fmt.Errorf("no solver to run")
+	}
+	instances, err := loadInstances(splitList(*presets), *count, *firstSeed, splitList(*problems))
+	if err != nil {
+		return err
+	}
+	if len(instances) == 0 {
+		return fmt.Errorf("no instance to run, give -presets or -problems")
+	}
+
+	// ctrl-c stops the benchmark, the runs that finished are still reported
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var rows []row
+	for _, inst := range instances {
+		if ctx.Err() != nil {
+			break
+		}
+		for _, result := range computed.BenchmarkSolvers(ctx, inst.pre, solvers) {
+			r := row{
+				Instance:       inst.name,
+				Sessions:       len(inst.pre.SessionAtoms),
+				Solver:         result.Solver,
+				HardViolations: result.HardViolations,
+				HardPenalty:    result.HardPenalty,
+				SoftPenalty:    result.SoftPenalty,
+				Fitness:        result.Fitness,
+				Seconds:        result.Duration.Seconds(),
+			}
+			if result.Err != nil {
+				r.Error = result.Err.Error()
+			}
+			rows = append(rows, r)
+			fmt.Fprintf(os.Stderr, "%s %s: %d hard violations, soft penalty %.4g in %v\n",
+				r.Instance, r.Solver, r.HardViolations, r.SoftPenalty, result.Duration.Round(time.Millisecond))
+		}
+	}
+
+	names := make([]string, len(solvers))
+	for i, solver := range solvers {
+		names[i] = solver.Name()
+	}
+	summaries := summarize(rows, names)
+	switch *format {
+	case "csv":
+		return writeCSV(os.Stdout, rows)
+	case "json":
+		return writeJSON(os.Stdout, rows, summaries)
+	}
+	return writeTable(os.Stdout, rows, summaries)
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "ttsolvebench:", err)
+		os.Exit(1)
+	}
+}
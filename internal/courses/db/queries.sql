@@ -90,13 +90,20 @@ SELECT
     c.sessions_per_week,
     c.level,
     c.semester,
+    c.lecturer_mode,
+    c.max_group_size,
+    d.faculty_id,
     cpv.venue_id
 FROM courses c
-INNER JOIN 
+INNER JOIN
+    departments d
+ON
+    d.department_id = c.department_id
+LEFT JOIN 
     courses_possible_venues cpv 
 ON 
     cpv.course_id = c.course_id
-WHERE c.university_id = $1;
+WHERE c.university_id = $1 AND (c.semester = $2 OR $2 = '');
 
 
 
@@ -157,6 +164,22 @@ SET
 WHERE course_id = $2 AND lecturer_id = $3
 RETURNING *;
 
+-- name: DeleteCourseLecturer :exec
+DELETE FROM courses_lecturers
+WHERE course_id = $1 AND lecturer_id = $2;
+
+-- name: SetCourseLecturerMode :exec
+UPDATE courses
+SET lecturer_mode = $1,
+    updated_at = NOW()
+WHERE course_id = $2;
+
+-- name: SetCourseMaxGroupSize :exec
+UPDATE courses
+SET max_group_size = $1,
+    updated_at = NOW()
+WHERE course_id = $2;
+
 -- name: CreateCohortCourse :one
 INSERT INTO cohort_courses_offered(
     cohort_id,course_id,university_id
@@ -185,3 +208,75 @@ SELECT
     semester
 FROM courses
 WHERE university_id = $1;
+
+-- name: UpsertElectiveGroup :one
+INSERT INTO elective_groups(
+    university_id,cohort_id,group_name
+)VALUES($1,$2,$3)
+ON CONFLICT (cohort_id,group_name) DO UPDATE
+SET updated_at = NOW()
+RETURNING elective_group_id;
+
+-- name: ClearElectiveGroupCourses :exec
+DELETE FROM elective_group_courses
+WHERE elective_group_id = $1;
+
+-- name: AddElectiveGroupCourse :exec
+INSERT INTO elective_group_courses(
+    elective_group_id,course_id
+)VALUES($1,$2);
+
+-- name: FetchElectiveGroupsForACohort :many
+SELECT
+    eg.elective_group_id,
+    eg.group_name,
+    c.course_id,
+    c.course_code,
+    c.course_title
+FROM elective_groups eg
+JOIN elective_group_courses egc ON egc.elective_group_id = eg.elective_group_id
+JOIN courses c ON c.course_id = egc.course_id
+WHERE eg.cohort_id = $1
+ORDER BY eg.group_name, c.course_code;
+
+-- name: DeleteElectiveGroup :exec
+DELETE FROM elective_groups
+WHERE elective_group_id = $1 AND university_id = $2;
+
+-- name: ClearCourseComponents :exec
+DELETE FROM course_components
+WHERE course_id = $1;
+
+-- name: CreateCourseComponent :exec
+INSERT INTO course_components(
+    course_id,university_id,session_type,duration,sessions_per_week,venue_kind,max_group_size
+)VALUES($1,$2,$3,$4,$5,$6,$7);
+
+-- name: FetchCourseComponents :many
+SELECT
+    component_id,
+    session_type,
+    duration,
+    sessions_per_week,
+    venue_kind,
+    max_group_size
+FROM course_components
+WHERE course_id = $1
+ORDER BY session_type;
+
+-- name: ClearCourseRequiredFeatures :exec
+DELETE FROM course_required_features
+WHERE course_id = $1 AND session_type IS NOT DISTINCT FROM $2;
+
+-- name: AddCourseRequiredFeature :exec
+INSERT INTO course_required_features(
+    course_id,university_id,session_type,feature
+)VALUES($1,$2,$3,$4);
+
+-- name: FetchCourseRequiredFeatures :many
+SELECT
+    session_type,
+    feature
+FROM course_required_features
+WHERE course_id = $1
+ORDER BY session_type NULLS FIRST, feature;
@@ -15,7 +15,11 @@ CREATE TABLE courses(
     level INT NOT NULL,
     semester TEXT NOT NULL CHECK (semester IN ('First','Second')),
     created_at TIMESTAMPTZ DEFAULT NOW(),
-    updated_at TIMESTAMPTZ DEFAULT NOW()
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    -- TEAM: every lecturer of the course teaches each session, ROTATE: sessions take turns between them
+    lecturer_mode TEXT NOT NULL DEFAULT 'TEAM' CHECK (lecturer_mode IN ('TEAM','ROTATE')),
+    -- a bigger class is split into parallel groups of at most this many, null for one session
+    max_group_size INT DEFAULT NULL CHECK (max_group_size IS NULL OR max_group_size > 0)
 );
 
 CREATE TABLE courses_possible_venues(
@@ -34,4 +38,52 @@ CREATE TABLE courses_lecturers(
     PRIMARY KEY(course_id,lecturer_id),
     created_at TIMESTAMPTZ DEFAULT NOW(),
     updated_at TIMESTAMPTZ DEFAULT NOW()
-);
\ No newline at end of file
+);
+-- a cohort takes exactly one course of every elective group it has, the courses of a group may
+-- run at the same time for the cohort. a course of the cohort in no group is taken by all of it
+CREATE TABLE elective_groups(
+    elective_group_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    cohort_id UUID NOT NULL REFERENCES cohorts(cohort_id) ON DELETE CASCADE,
+    group_name TEXT NOT NULL,
+    UNIQUE(cohort_id,group_name),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE elective_group_courses(
+    elective_group_id UUID NOT NULL REFERENCES elective_groups(elective_group_id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    PRIMARY KEY(elective_group_id,course_id),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+
+-- the kinds of session a course has every week. a course without components has one LECTURE
+-- component made of its course_duration and sessions_per_week
+CREATE TABLE course_components(
+    component_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    session_type TEXT NOT NULL CHECK (session_type IN ('LECTURE','LAB','TUTORIAL')),
+    duration INT NOT NULL CHECK (duration > 0),
+    sessions_per_week INT NOT NULL CHECK (sessions_per_week > 0),
+    venue_kind TEXT DEFAULT NULL, -- the kind of venue the sessions need, null for any of the course's venues
+    max_group_size INT DEFAULT NULL CHECK (max_group_size IS NULL OR max_group_size > 0), -- null for the course's
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (course_id, session_type)
+);
+
+CREATE TABLE course_required_features(
+    requirement_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    session_type TEXT DEFAULT NULL CHECK (session_type IN ('LECTURE','LAB','TUTORIAL')), -- null for every session of the course
+    feature TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX course_required_features_unique
+ON course_required_features(course_id,COALESCE(session_type,''),feature);
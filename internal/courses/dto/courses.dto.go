@@ -53,6 +53,19 @@ type UpdateCourseLecturersDto struct {
 	LecturerId2 string `json:"lecturerId2" validate:"required"`
 }
 
+// TEAM or ROTATE
+type SetCourseLecturerModeDto struct {
+	CourseId string `json:"courseId" validate:"required"`
+	LecturerMode string `json:"lecturerMode" validate:"required"`
+}
+
+// a class bigger than the max group size is split into parallel groups of at most that many,
+// a nil size never splits it
+type SetCourseMaxGroupSizeDto struct {
+	CourseId string `json:"courseId" validate:"required"`
+	MaxGroupSize *int32 `json:"maxGroupSize" validate:"omitempty,min=1"`
+}
+
 
 type SetCoursePossibleVenuesDto struct {
 	CourseId string `json:"courseId" validate:"required"`
@@ -65,3 +78,48 @@ type SetCohortCoursesDto struct {
 	CohortId string `json:"cohortId" validate:"required"`
 	Courses []uuid.UUID
 }
+
+// the courses of a cohort it takes exactly one of, setting a group again replaces its courses
+type SetElectiveGroupDto struct {
+	UniversityId string `json:"universityId" validate:"required"`
+	CohortId string `json:"cohortId" validate:"required"`
+	GroupName string `json:"groupName" validate:"required"`
+	Courses []uuid.UUID `json:"courses" validate:"required,min=2"`
+}
+
+type ElectiveGroupCourse struct {
+	CourseId uuid.UUID `json:"courseId"`
+	CourseCode string `json:"courseCode"`
+	CourseTitle string `json:"courseTitle"`
+}
+
+type ElectiveGroupResponse struct {
+	ElectiveGroupId uuid.UUID `json:"electiveGroupId"`
+	GroupName string `json:"groupName"`
+	Courses []ElectiveGroupCourse `json:"courses"`
+}
+
+// a kind of session the course has every week, duration is in hours like course_duration
+type CourseComponentDto struct {
+	SessionType string `json:"sessionType" validate:"required"`
+	Duration int32 `json:"duration" validate:"required,min=1"`
+	SessionsPerWeek int32 `json:"sessionsPerWeek" validate:"required,min=1"`
+	VenueKind string `json:"venueKind" validate:"omitempty"`
+	MaxGroupSize *int32 `json:"maxGroupSize" validate:"omitempty,min=1"` // the course's max group size when nil
+}
+
+// replaces the components of the course, an empty list goes back to course_duration and sessions_per_week
+type SetCourseComponentsDto struct {
+	CourseId string `json:"courseId" validate:"required"`
+	UniversityId string `json:"universityId" validate:"required"`
+	Components []CourseComponentDto `json:"components" validate:"dive"`
+}
+
+// replaces the features every venue of the course needs, or only the venues of one session type
+// when it is set. an empty list removes them
+type SetCourseRequiredFeaturesDto struct {
+	CourseId string `json:"courseId" validate:"required"`
+	UniversityId string `json:"universityId" validate:"required"`
+	SessionType string `json:"sessionType" validate:"omitempty"`
+	Features []string `json:"features" validate:"omitempty"`
+}
@@ -101,6 +101,45 @@ func (ch *CourseHandler) SetCoursePossibleVenues(res http.ResponseWriter,req *ht
 	utils.HandleAuthResponse(resp,err,errMsg,res)
 }
 
+func (ch *CourseHandler) SetCourseLecturers(res http.ResponseWriter,req *http.Request){
+	var body dto.SetCourseLecturersDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := ch.CourseService.SetCourseLecturers(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) UpdateCourseLecturers(res http.ResponseWriter,req *http.Request){
+	var body dto.UpdateCourseLecturersDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := ch.CourseService.UpdateCourseLecturers(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) DeleteCourseLecturer(res http.ResponseWriter,req *http.Request){
+	queryParams := req.URL.Query()
+	courseId := queryParams.Get("courseId")
+	lecturerId := queryParams.Get("lecturerId")
+	resp,errMsg,err := ch.CourseService.DeleteCourseLecturer(ctx,sqlc.DeleteCourseLecturerParams{
+		CourseID: utils.StringToUUID(courseId),
+		LecturerID: utils.StringToUUID(lecturerId),
+	})
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) SetCourseLecturerMode(res http.ResponseWriter,req *http.Request){
+	var body dto.SetCourseLecturerModeDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := ch.CourseService.SetCourseLecturerMode(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) SetCourseMaxGroupSize(res http.ResponseWriter,req *http.Request){
+	var body dto.SetCourseMaxGroupSizeDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := ch.CourseService.SetCourseMaxGroupSize(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
 func (ch *CourseHandler) DeleteCoursePossibleVenue(res http.ResponseWriter,req *http.Request){
 	queryParams := req.URL.Query()
 	courseId := queryParams.Get("courseId")
@@ -141,4 +180,54 @@ func (ch *CourseHandler) FetchAllCourses(res http.ResponseWriter, req *http.Requ
 }
 // func (ch *CourseHandler) DeleteCourse(req *http.Request, res http.ResponseWriter){
 	
-// }
\ No newline at end of file
+// }
+
+func (ch *CourseHandler) SetElectiveGroup(res http.ResponseWriter, req *http.Request){
+	var body dto.SetElectiveGroupDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := ch.CourseService.SetElectiveGroup(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) FetchElectiveGroupsForACohort(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	cohortId := queryParams.Get("cohortId")
+	resp,errMsg,err := ch.CourseService.FetchElectiveGroupsForACohort(ctx,utils.StringToUUID(cohortId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) DeleteElectiveGroup(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	universityId := queryParams.Get("uniId")
+	electiveGroupId := queryParams.Get("electiveGroupId")
+	resp,errMsg,err := ch.CourseService.DeleteElectiveGroup(ctx,utils.StringToUUID(universityId),utils.StringToUUID(electiveGroupId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) SetCourseComponents(res http.ResponseWriter, req *http.Request){
+	var body dto.SetCourseComponentsDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := ch.CourseService.SetCourseComponents(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) FetchCourseComponents(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	courseId := queryParams.Get("courseId")
+	resp,errMsg,err := ch.CourseService.FetchCourseComponents(ctx,utils.StringToUUID(courseId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) SetCourseRequiredFeatures(res http.ResponseWriter, req *http.Request){
+	var body dto.SetCourseRequiredFeaturesDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := ch.CourseService.SetCourseRequiredFeatures(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (ch *CourseHandler) FetchCourseRequiredFeatures(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	courseId := queryParams.Get("courseId")
+	resp,errMsg,err := ch.CourseService.FetchCourseRequiredFeatures(ctx,utils.StringToUUID(courseId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
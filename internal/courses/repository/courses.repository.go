@@ -19,12 +19,22 @@ type CourseRepository interface{
 	DeleteCourse(ctx context.Context,courseId uuid.UUID)error
 	SetCourseLecturers(ctx context.Context,param sqlc.SetCourseLecturersParams)(sqlc.CoursesLecturer,error)
 	UpdateCourseLecturers(ctx context.Context,param sqlc.UpdateCourseLecturersParams)(sqlc.CoursesLecturer,error)
+	DeleteCourseLecturer(ctx context.Context,param sqlc.DeleteCourseLecturerParams)error
+	SetCourseLecturerMode(ctx context.Context,param sqlc.SetCourseLecturerModeParams)error
+	SetCourseMaxGroupSize(ctx context.Context,param sqlc.SetCourseMaxGroupSizeParams)error
 	SetCoursePossibleVenues(ctx context.Context,courseVenueData []sqlc.SetCoursePossibleVenueParams)error
 	SetCoursesForACohort(ctx context.Context,uniId uuid.UUID,cohortId uuid.UUID, courses[]uuid.UUID)error
 	FetchCoursePossibleVenues(ctx context.Context,courseId uuid.UUID)([]sqlc.FetchCoursePossibleVenuesRow,error)
 	DeleteCoursePossibleVenue(ctx context.Context,params sqlc.DeleteCoursePossibleVenueParams)error
 	RetrieveCoursesForACohort(ctx context.Context,cohortId uuid.UUID)([]sqlc.RetrieveCoursesForACohortRow,error)
 	RetrieveAllCourses(ctx context.Context, uniId uuid.UUID)([]sqlc.FetchAllCoursesRow,error)
+	SetElectiveGroup(ctx context.Context,params sqlc.UpsertElectiveGroupParams,courses []uuid.UUID)(uuid.UUID,error)
+	FetchElectiveGroupsForACohort(ctx context.Context,cohortId uuid.UUID)([]sqlc.FetchElectiveGroupsForACohortRow,error)
+	DeleteElectiveGroup(ctx context.Context,params sqlc.DeleteElectiveGroupParams)error
+	SetCourseComponents(ctx context.Context,courseId uuid.UUID,components []sqlc.CreateCourseComponentParams)error
+	FetchCourseComponents(ctx context.Context,courseId uuid.UUID)([]sqlc.FetchCourseComponentsRow,error)
+	SetCourseRequiredFeatures(ctx context.Context,params sqlc.ClearCourseRequiredFeaturesParams,features []sqlc.AddCourseRequiredFeatureParams)error
+	FetchCourseRequiredFeatures(ctx context.Context,courseId uuid.UUID)([]sqlc.FetchCourseRequiredFeaturesRow,error)
 }
 type courseRepository struct {
 	store sqlc.Store
@@ -102,6 +112,18 @@ func (cq *courseRepository) UpdateCourseLecturers(ctx context.Context,param sqlc
 	return cq.cq.UpdateCourseLecturers(ctx,param)
 }
 
+func (cq *courseRepository) DeleteCourseLecturer(ctx context.Context,param sqlc.DeleteCourseLecturerParams)error{
+	return cq.cq.DeleteCourseLecturer(ctx,param)
+}
+
+func (cq *courseRepository) SetCourseLecturerMode(ctx context.Context,param sqlc.SetCourseLecturerModeParams)error{
+	return cq.cq.SetCourseLecturerMode(ctx,param)
+}
+
+func (cq *courseRepository) SetCourseMaxGroupSize(ctx context.Context,param sqlc.SetCourseMaxGroupSizeParams)error{
+	return cq.cq.SetCourseMaxGroupSize(ctx,param)
+}
+
 
 func (cq *courseRepository) SetCoursesForACohort(ctx context.Context,uniId uuid.UUID,cohortId uuid.UUID, courses[]uuid.UUID)error{
 	return cq.store.ExecTx(ctx,func(q *sqlc.Queries)error{
@@ -148,4 +170,74 @@ func (cq *courseRepository) RetrieveAllCourses(ctx context.Context, uniId uuid.U
 	return cq.cq.FetchAllCourses(ctx,uniId)
 }
 
-// func (cq *courseRepository) Retrieve
\ No newline at end of file
+// creates the group or replaces the courses of the one with the same name
+func (cq *courseRepository) SetElectiveGroup(ctx context.Context,params sqlc.UpsertElectiveGroupParams,courses []uuid.UUID)(uuid.UUID,error){
+	var groupId uuid.UUID
+	err := cq.store.ExecTx(ctx,func(q *sqlc.Queries)error{
+		var err error
+		groupId,err = q.UpsertElectiveGroup(ctx,params)
+		if err != nil{
+			return err
+		}
+		if err = q.ClearElectiveGroupCourses(ctx,groupId); err != nil{
+			return err
+		}
+		for _,courseId := range courses{
+			err = q.AddElectiveGroupCourse(ctx,sqlc.AddElectiveGroupCourseParams{
+				ElectiveGroupID: groupId,
+				CourseID: courseId,
+			})
+			if err != nil{
+				return err
+			}
+		}
+		return nil
+	})
+	return groupId,err
+}
+
+func (cq *courseRepository) FetchElectiveGroupsForACohort(ctx context.Context,cohortId uuid.UUID)([]sqlc.FetchElectiveGroupsForACohortRow,error){
+	return cq.cq.FetchElectiveGroupsForACohort(ctx,cohortId)
+}
+
+func (cq *courseRepository) DeleteElectiveGroup(ctx context.Context,params sqlc.DeleteElectiveGroupParams)error{
+	return cq.cq.DeleteElectiveGroup(ctx,params)
+}
+
+// func (cq *courseRepository) Retrieve
+
+func (cq *courseRepository) SetCourseComponents(ctx context.Context,courseId uuid.UUID,components []sqlc.CreateCourseComponentParams)error{
+	return cq.store.ExecTx(ctx,func(q *sqlc.Queries)error{
+		if err := q.ClearCourseComponents(ctx,courseId); err != nil{
+			return err
+		}
+		for _,component := range components{
+			if err := q.CreateCourseComponent(ctx,component); err != nil{
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (cq *courseRepository) FetchCourseComponents(ctx context.Context,courseId uuid.UUID)([]sqlc.FetchCourseComponentsRow,error){
+	return cq.cq.FetchCourseComponents(ctx,courseId)
+}
+
+func (cq *courseRepository) SetCourseRequiredFeatures(ctx context.Context,params sqlc.ClearCourseRequiredFeaturesParams,features []sqlc.AddCourseRequiredFeatureParams)error{
+	return cq.store.ExecTx(ctx,func(q *sqlc.Queries)error{
+		if err := q.ClearCourseRequiredFeatures(ctx,params); err != nil{
+			return err
+		}
+		for _,feature := range features{
+			if err := q.AddCourseRequiredFeature(ctx,feature); err != nil{
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (cq *courseRepository) FetchCourseRequiredFeatures(ctx context.Context,courseId uuid.UUID)([]sqlc.FetchCourseRequiredFeaturesRow,error){
+	return cq.cq.FetchCourseRequiredFeatures(ctx,courseId)
+}
@@ -22,12 +22,24 @@ func Routes(courseHandler courseHandler.CourseHandler,regHandler regHandler.RegH
 		r.Use(hodMiddleware)
 		r.Post("/",courseHandler.CreateCourse)
 		r.Post("/possiblevenues",courseHandler.SetCoursePossibleVenues)
+		r.Post("/lecturers",courseHandler.SetCourseLecturers)
+		r.Put("/lecturers",courseHandler.UpdateCourseLecturers)
+		r.Delete("/lecturer",courseHandler.DeleteCourseLecturer)
+		r.Post("/lecturermode",courseHandler.SetCourseLecturerMode)
+		r.Post("/groupsize",courseHandler.SetCourseMaxGroupSize)
+		r.Post("/components",courseHandler.SetCourseComponents)
+		r.Post("/features",courseHandler.SetCourseRequiredFeatures)
 	})
 	r.Delete("/possiblevenue",courseHandler.DeleteCoursePossibleVenue)
 	r.Get("/possiblevenues",courseHandler.FetchCoursePossibleVenues)
+	r.Get("/components",courseHandler.FetchCourseComponents)
+	r.Get("/features",courseHandler.FetchCourseRequiredFeatures)
 	r.Post("/department/all",courseHandler.RetrieveCoursesForADepartment)
 	r.Get("/cohort",courseHandler.RetrieveCoursesForACohort)
 	r.Post("/cohort",courseHandler.SetCoursesForACohort)
+	r.Get("/cohort/electives",courseHandler.FetchElectiveGroupsForACohort)
+	r.Post("/cohort/electives",courseHandler.SetElectiveGroup)
+	r.Delete("/cohort/electives",courseHandler.DeleteElectiveGroup)
 	r.Get("/all",courseHandler.FetchAllCourses)
 
 
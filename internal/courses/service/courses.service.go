@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/Cxons/unischedulebackend/internal/courses/dto"
@@ -36,12 +37,22 @@ type CourseService interface{
 	DeleteCourse(ctx context.Context,courseId string)(CourseResponse,string,error)
 	SetCourseLecturers(ctx context.Context,param SetCourseLecturersDto)(CourseResponse,string,error)
 	UpdateCourseLecturers(ctx context.Context, param UpdateCourseLecturersDto)(CourseResponse,string,error)
+	DeleteCourseLecturer(ctx context.Context, param sqlc.DeleteCourseLecturerParams)(CourseResponse,string,error)
+	SetCourseLecturerMode(ctx context.Context, param dto.SetCourseLecturerModeDto)(CourseResponse,string,error)
+	SetCourseMaxGroupSize(ctx context.Context, param dto.SetCourseMaxGroupSizeDto)(CourseResponse,string,error)
 	SetCoursePossibleVenues(ctx context.Context,courseVenueData dto.SetCoursePossibleVenuesDto)(CourseResponse,string,error)
 	DeleteCoursePossibleVenue(ctx context.Context, courseVenueParam sqlc.DeleteCoursePossibleVenueParams)(CourseResponse,string,error)
 	FetchCoursePossibleVenues(ctx context.Context,courseId uuid.UUID)(CourseResponse,string,error)
 	RetrieveCoursesForACohort(ctx context.Context,cohortId uuid.UUID)(CourseResponse,string,error)
 	SetCoursesForACohort(ctx context.Context,params dto.SetCohortCoursesDto)(CourseResponse,string,error)
 	RetrieveAllCourses(ctx context.Context, uniId uuid.UUID)(CourseResponse,string,error)
+	SetElectiveGroup(ctx context.Context,params dto.SetElectiveGroupDto)(CourseResponse,string,error)
+	FetchElectiveGroupsForACohort(ctx context.Context,cohortId uuid.UUID)(CourseResponse,string,error)
+	DeleteElectiveGroup(ctx context.Context,uniId uuid.UUID,electiveGroupId uuid.UUID)(CourseResponse,string,error)
+	SetCourseComponents(ctx context.Context,params dto.SetCourseComponentsDto)(CourseResponse,string,error)
+	FetchCourseComponents(ctx context.Context,courseId uuid.UUID)(CourseResponse,string,error)
+	SetCourseRequiredFeatures(ctx context.Context,params dto.SetCourseRequiredFeaturesDto)(CourseResponse,string,error)
+	FetchCourseRequiredFeatures(ctx context.Context,courseId uuid.UUID)(CourseResponse,string,error)
 }
 
 type courseService struct {
@@ -205,6 +216,7 @@ func (cs *courseService) UpdateCourseLecturers(ctx context.Context, param Update
 	courseLecturer := sqlc.UpdateCourseLecturersParams{
 		LecturerID: utils.StringToUUID(param.LecturerId),
 		LecturerID_2: utils.StringToUUID(param.LecturerId2),
+		CourseID: utils.StringToUUID(param.CourseId),
 	}
 	_,err := cs.repo.UpdateCourseLecturers(ctx,courseLecturer)
 
@@ -220,6 +232,59 @@ func (cs *courseService) UpdateCourseLecturers(ctx context.Context, param Update
 	},status.OK.Message,nil
 }
 
+func (cs *courseService) DeleteCourseLecturer(ctx context.Context, param sqlc.DeleteCourseLecturerParams)(CourseResponse,string,error){
+	err := cs.repo.DeleteCourseLecturer(ctx,param)
+	if err != nil{
+		cs.logger.Error("error removing course lecturer","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "Lecturer removed from course successfully",
+		Data: nil,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+// TEAM needs every lecturer of the course at each session, ROTATE gives each session to one of them in turn
+func (cs *courseService) SetCourseLecturerMode(ctx context.Context, param dto.SetCourseLecturerModeDto)(CourseResponse,string,error){
+	if param.LecturerMode != "TEAM" && param.LecturerMode != "ROTATE"{
+		return CourseResponse{},status.BadRequest.Message,errors.New("lecturer mode must be TEAM or ROTATE")
+	}
+	err := cs.repo.SetCourseLecturerMode(ctx,sqlc.SetCourseLecturerModeParams{
+		LecturerMode: param.LecturerMode,
+		CourseID: utils.StringToUUID(param.CourseId),
+	})
+	if err != nil{
+		cs.logger.Error("error setting course lecturer mode","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "Course lecturer mode set successfully",
+		Data: nil,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+// every session of the course is split into groups unless its component has a max group size of its own
+func (cs *courseService) SetCourseMaxGroupSize(ctx context.Context, param dto.SetCourseMaxGroupSizeDto)(CourseResponse,string,error){
+	err := cs.repo.SetCourseMaxGroupSize(ctx,sqlc.SetCourseMaxGroupSizeParams{
+		MaxGroupSize: utils.Int32PtrToNullInt32(param.MaxGroupSize),
+		CourseID: utils.StringToUUID(param.CourseId),
+	})
+	if err != nil{
+		cs.logger.Error("error setting course max group size","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "Course max group size set successfully",
+		Data: nil,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
 func (cs *courseService) SetCoursePossibleVenues(ctx context.Context,courseVenueData dto.SetCoursePossibleVenuesDto)(CourseResponse,string,error){
 	actualCourseVenueData := make([]sqlc.SetCoursePossibleVenueParams,0)
 	for _,val := range courseVenueData.Venues{
@@ -308,3 +373,199 @@ func (cs *courseService) RetrieveAllCourses(ctx context.Context, uniId uuid.UUID
 		StatusCodeMessage: status.OK.Message,
 	},status.OK.Message,nil
 }
+
+// the courses of the group must be offered to the cohort and in no other group of it, the
+// timetable lets them share a slot of the cohort
+func (cs *courseService) SetElectiveGroup(ctx context.Context,params dto.SetElectiveGroupDto)(CourseResponse,string,error){
+	cohortId := utils.StringToUUID(params.CohortId)
+	offered,err := cs.repo.RetrieveCoursesForACohort(ctx,cohortId)
+	if err != nil{
+		cs.logger.Error("error retrieving courses for the cohort","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	offeredIds := make(map[uuid.UUID]bool,len(offered))
+	for _,course := range offered{
+		offeredIds[course.CourseID] = true
+	}
+	groups,err := cs.repo.FetchElectiveGroupsForACohort(ctx,cohortId)
+	if err != nil{
+		cs.logger.Error("error fetching elective groups for the cohort","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	otherGroup := make(map[uuid.UUID]string)
+	for _,group := range groups{
+		if group.GroupName != params.GroupName{
+			otherGroup[group.CourseID] = group.GroupName
+		}
+	}
+	seen := make(map[uuid.UUID]bool,len(params.Courses))
+	for _,courseId := range params.Courses{
+		if seen[courseId]{
+			return CourseResponse{},status.BadRequest.Message,fmt.Errorf("course %s is listed more than once",courseId)
+		}
+		seen[courseId] = true
+		if !offeredIds[courseId]{
+			return CourseResponse{},status.BadRequest.Message,fmt.Errorf("course %s is not offered to the cohort",courseId)
+		}
+		if name,ok := otherGroup[courseId]; ok{
+			return CourseResponse{},status.BadRequest.Message,fmt.Errorf("course %s is already in the elective group %s of the cohort",courseId,name)
+		}
+	}
+
+	groupId,err := cs.repo.SetElectiveGroup(ctx,sqlc.UpsertElectiveGroupParams{
+		UniversityID: utils.StringToUUID(params.UniversityId),
+		CohortID: cohortId,
+		GroupName: params.GroupName,
+	},params.Courses)
+	if err != nil{
+		cs.logger.Error("error setting elective group","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "Elective group set successfully",
+		Data: groupId,
+		StatusCode: status.Created.Code,
+		StatusCodeMessage: status.Created.Message,
+	},status.Created.Message,nil
+}
+
+func (cs *courseService) FetchElectiveGroupsForACohort(ctx context.Context,cohortId uuid.UUID)(CourseResponse,string,error){
+	rows,err := cs.repo.FetchElectiveGroupsForACohort(ctx,cohortId)
+	if err != nil{
+		cs.logger.Error("error fetching elective groups for the cohort","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	// the rows come ordered by group, one per course
+	groups := make([]dto.ElectiveGroupResponse,0)
+	for _,row := range rows{
+		if len(groups) == 0 || groups[len(groups)-1].ElectiveGroupId != row.ElectiveGroupID{
+			groups = append(groups,dto.ElectiveGroupResponse{
+				ElectiveGroupId: row.ElectiveGroupID,
+				GroupName: row.GroupName,
+				Courses: make([]dto.ElectiveGroupCourse,0),
+			})
+		}
+		last := &groups[len(groups)-1]
+		last.Courses = append(last.Courses,dto.ElectiveGroupCourse{
+			CourseId: row.CourseID,
+			CourseCode: row.CourseCode,
+			CourseTitle: row.CourseTitle,
+		})
+	}
+	return CourseResponse{
+		Message: "Elective groups of the cohort",
+		Data: groups,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+func (cs *courseService) DeleteElectiveGroup(ctx context.Context,uniId uuid.UUID,electiveGroupId uuid.UUID)(CourseResponse,string,error){
+	err := cs.repo.DeleteElectiveGroup(ctx,sqlc.DeleteElectiveGroupParams{
+		ElectiveGroupID: electiveGroupId,
+		UniversityID: uniId,
+	})
+	if err != nil{
+		cs.logger.Error("error deleting elective group","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "Elective group deleted successfully",
+		StatusCode: status.NoContent.Code,
+		StatusCodeMessage: status.NoContent.Message,
+	},status.NoContent.Message,nil
+}
+
+// a course has at most one component of each session type, LECTURE, LAB or TUTORIAL
+func (cs *courseService) SetCourseComponents(ctx context.Context,params dto.SetCourseComponentsDto)(CourseResponse,string,error){
+	courseId := utils.StringToUUID(params.CourseId)
+	components := make([]sqlc.CreateCourseComponentParams,0,len(params.Components))
+	seen := make(map[string]bool)
+	for _,component := range params.Components{
+		if component.SessionType != "LECTURE" && component.SessionType != "LAB" && component.SessionType != "TUTORIAL"{
+			return CourseResponse{},status.BadRequest.Message,errors.New("session type must be LECTURE, LAB or TUTORIAL")
+		}
+		if seen[component.SessionType]{
+			return CourseResponse{},status.BadRequest.Message,fmt.Errorf("the course has more than one %s component",component.SessionType)
+		}
+		seen[component.SessionType] = true
+		components = append(components,sqlc.CreateCourseComponentParams{
+			CourseID: courseId,
+			UniversityID: utils.StringToUUID(params.UniversityId),
+			SessionType: component.SessionType,
+			Duration: component.Duration,
+			SessionsPerWeek: component.SessionsPerWeek,
+			VenueKind: utils.StringToNullString(component.VenueKind),
+			MaxGroupSize: utils.Int32PtrToNullInt32(component.MaxGroupSize),
+		})
+	}
+	err := cs.repo.SetCourseComponents(ctx,courseId,components)
+	if err != nil{
+		cs.logger.Error("error setting course components","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "Course components set successfully",
+		StatusCode: status.Created.Code,
+		StatusCodeMessage: status.Created.Message,
+	},status.Created.Message,nil
+}
+
+func (cs *courseService) FetchCourseComponents(ctx context.Context,courseId uuid.UUID)(CourseResponse,string,error){
+	data,err := cs.repo.FetchCourseComponents(ctx,courseId)
+	if err != nil{
+		cs.logger.Error("error fetching course components","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "The course components",
+		Data: data,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+// the features are matched against venue_features when the venues of the course are worked out
+func (cs *courseService) SetCourseRequiredFeatures(ctx context.Context,params dto.SetCourseRequiredFeaturesDto)(CourseResponse,string,error){
+	if params.SessionType != "" && params.SessionType != "LECTURE" && params.SessionType != "LAB" && params.SessionType != "TUTORIAL"{
+		return CourseResponse{},status.BadRequest.Message,errors.New("session type must be LECTURE, LAB or TUTORIAL")
+	}
+	courseId := utils.StringToUUID(params.CourseId)
+	sessionType := utils.StringToNullString(params.SessionType)
+	features := make([]sqlc.AddCourseRequiredFeatureParams,0,len(params.Features))
+	for _,feature := range utils.NormalizeTags(params.Features){
+		features = append(features,sqlc.AddCourseRequiredFeatureParams{
+			CourseID: courseId,
+			UniversityID: utils.StringToUUID(params.UniversityId),
+			SessionType: sessionType,
+			Feature: feature,
+		})
+	}
+	err := cs.repo.SetCourseRequiredFeatures(ctx,sqlc.ClearCourseRequiredFeaturesParams{
+		CourseID: courseId,
+		SessionType: sessionType,
+	},features)
+	if err != nil{
+		cs.logger.Error("error setting course required features","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "Course required features set successfully",
+		StatusCode: status.Created.Code,
+		StatusCodeMessage: status.Created.Message,
+	},status.Created.Message,nil
+}
+
+func (cs *courseService) FetchCourseRequiredFeatures(ctx context.Context,courseId uuid.UUID)(CourseResponse,string,error){
+	data,err := cs.repo.FetchCourseRequiredFeatures(ctx,courseId)
+	if err != nil{
+		cs.logger.Error("error fetching course required features","err:",err)
+		return CourseResponse{},status.InternalServerError.Message,err
+	}
+	return CourseResponse{
+		Message: "The course required features",
+		Data: data,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
@@ -130,7 +130,7 @@ CREATE TABLE lecturer_waiting_list(
 CREATE TABLE lecturer_unavailability (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     lecturer_id UUID NOT NULL REFERENCES lecturers(lecturer_id) ON DELETE CASCADE,
-    day TEXT NOT NULL CHECK (day IN ('Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday')),
+    day TEXT NOT NULL CHECK (day IN ('Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday', 'Sunday')),
     start_time TIME NOT NULL,
     end_time TIME NOT NULL,
     reason TEXT DEFAULT NULL,
@@ -21,7 +21,7 @@ func (s *Server) mountRoutes(){
 		r.Mount("/supabase",supRoutes.Routes(*s.Supabase))
 		r.Mount("/university",uniRoutes.Routes(*s.Uni))
 		r.Mount("/course",courseRoutes.Routes(*s.Course,*s.Reg))
-		r.Mount("/timetable",timetableRoutes.Routes(*s.Timetable))
+		r.Mount("/timetable",timetableRoutes.Routes(*s.Timetable,*s.Reg))
 		// r.Mount("/dean",dean.Routes())
 		// r.Mount("/hod",hod.Routes())
 		// r.Mount("/lecturer", lecturer.Routes())
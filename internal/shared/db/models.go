@@ -20,6 +20,57 @@ type Candidate struct {
 	EndOfDay        time.Time
 	CreatedAt       sql.NullTime
 	UpdatedAt       sql.NullTime
+	Seed            sql.NullInt64
+	PopulationSize  sql.NullInt32
+	Generations     sql.NullInt32
+	MutationRate    sql.NullFloat64
+	TournamentSize  sql.NullInt32
+	ElitismFraction sql.NullFloat64
+	RepairedFrom    uuid.NullUUID
+	PublishedAt     sql.NullTime
+	PublishedBy     uuid.NullUUID
+	Solver          sql.NullString
+	Workers         sql.NullInt32
+	GenerationsRun  sql.NullInt32
+	StopReason      sql.NullString
+	AcademicSession sql.NullString
+	Semester        sql.NullString
+}
+
+type CandidateFitnessHistory struct {
+	CandidateID uuid.UUID
+	Generation  int32
+	BestFitness float64
+	MeanFitness float64
+}
+
+type CandidateMovedSession struct {
+	ID              uuid.UUID
+	CandidateID     uuid.UUID
+	SessionIdx      int32
+	CourseID        uuid.UUID
+	FromVenueID     uuid.NullUUID
+	FromDay         sql.NullString
+	FromSessionTime sql.NullTime
+	ToVenueID       uuid.UUID
+	ToDay           string
+	ToSessionTime   time.Time
+	Reason          string
+	CreatedAt       sql.NullTime
+}
+
+type CandidateReview struct {
+	ReviewID     uuid.UUID
+	CandidateID  uuid.UUID
+	UniversityID uuid.UUID
+	ReviewerID   uuid.UUID
+	ReviewerRole string
+	FacultyID    uuid.NullUUID
+	DepartmentID uuid.NullUUID
+	Decision     string
+	Comment      string
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
 }
 
 type Cohort struct {
@@ -31,6 +82,7 @@ type Cohort struct {
 	CohortUniversityID uuid.UUID
 	CreatedAt          sql.NullTime
 	UpdatedAt          sql.NullTime
+	CohortSize         sql.NullInt32
 }
 
 type CohortCoursesOffered struct {
@@ -55,6 +107,30 @@ type Course struct {
 	Semester         string
 	CreatedAt        sql.NullTime
 	UpdatedAt        sql.NullTime
+	LecturerMode     string
+	MaxGroupSize     sql.NullInt32
+}
+
+type CourseComponent struct {
+	ComponentID     uuid.UUID
+	CourseID        uuid.UUID
+	UniversityID    uuid.UUID
+	SessionType     string
+	Duration        int32
+	SessionsPerWeek int32
+	VenueKind       sql.NullString
+	CreatedAt       sql.NullTime
+	UpdatedAt       sql.NullTime
+	MaxGroupSize    sql.NullInt32
+}
+
+type CourseRequiredFeature struct {
+	RequirementID uuid.UUID
+	CourseID      uuid.UUID
+	UniversityID  uuid.UUID
+	SessionType   sql.NullString
+	Feature       string
+	CreatedAt     sql.NullTime
 }
 
 type CoursesLecturer struct {
@@ -122,6 +198,22 @@ type DeptVenue struct {
 	UpdatedAt    sql.NullTime
 }
 
+type ElectiveGroup struct {
+	ElectiveGroupID uuid.UUID
+	UniversityID    uuid.UUID
+	CohortID        uuid.UUID
+	GroupName       string
+	CreatedAt       sql.NullTime
+	UpdatedAt       sql.NullTime
+}
+
+type ElectiveGroupCourse struct {
+	ElectiveGroupID uuid.UUID
+	CourseID        uuid.UUID
+	CreatedAt       sql.NullTime
+	UpdatedAt       sql.NullTime
+}
+
 type Faculty struct {
 	FacultyID    uuid.UUID
 	FacultyName  string
@@ -218,6 +310,10 @@ type SessionPlacement struct {
 	UniversityID uuid.UUID
 	CreatedAt    sql.NullTime
 	UpdatedAt    sql.NullTime
+	Conflict     bool
+	SessionType  string
+	GroupIdx     int32
+	GroupCount   int32
 }
 
 type Student struct {
@@ -245,6 +341,55 @@ type StudentCoursesOffered struct {
 	UpdatedAt sql.NullTime
 }
 
+type TimetableConstraint struct {
+	UniversityID   uuid.UUID
+	ConstraintName string
+	Enabled        bool
+	Weight         float64
+	LimitValue     float64
+	CreatedAt      sql.NullTime
+	UpdatedAt      sql.NullTime
+	Hard           bool
+}
+
+type TimetableSessionPin struct {
+	PinID         uuid.UUID
+	UniversityID  uuid.UUID
+	CourseID      uuid.UUID
+	SessionNumber int32
+	Day           string
+	StartTime     time.Time
+	VenueID       uuid.UUID
+	CreatedAt     sql.NullTime
+	UpdatedAt     sql.NullTime
+}
+
+type TimetableSetting struct {
+	UniversityID      uuid.UUID
+	PopulationSize    int32
+	Generations       int32
+	MutationRate      float64
+	TournamentSize    int32
+	ElitismFraction   float64
+	Seed              sql.NullInt64
+	CreatedAt         sql.NullTime
+	UpdatedAt         sql.NullTime
+	SlotMinutes       int32
+	Solver            string
+	StallGenerations  int32
+	TimeBudgetSeconds int32
+	StopWhenFeasible  bool
+}
+
+type TimetableTeachingDay struct {
+	UniversityID uuid.UUID
+	Day          string
+	StartTime    time.Time
+	EndTime      time.Time
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
+}
+
 type University struct {
 	UniversityID   uuid.UUID
 	UniversityName string
@@ -286,6 +431,24 @@ type Venue struct {
 	IsActive       sql.NullBool
 	CreatedAt      sql.NullTime
 	UpdatedAt      sql.NullTime
+	VenueKind      sql.NullString
+	IsRestricted   bool
+}
+
+type VenueFeature struct {
+	VenueID      uuid.UUID
+	Feature      string
+	UniversityID uuid.UUID
+	CreatedAt    sql.NullTime
+}
+
+type VenueTravelTime struct {
+	UniversityID uuid.UUID
+	FromVenueID  uuid.UUID
+	ToVenueID    uuid.UUID
+	Minutes      int32
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
 }
 
 type VenueUnavailability struct {
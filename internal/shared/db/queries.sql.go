@@ -13,6 +13,45 @@ import (
 	"github.com/google/uuid"
 )
 
+const addCourseRequiredFeature = `-- name: AddCourseRequiredFeature :exec
+INSERT INTO course_required_features(
+    course_id,university_id,session_type,feature
+)VALUES($1,$2,$3,$4)
+`
+
+type AddCourseRequiredFeatureParams struct {
+	CourseID     uuid.UUID
+	UniversityID uuid.UUID
+	SessionType  sql.NullString
+	Feature      string
+}
+
+func (q *Queries) AddCourseRequiredFeature(ctx context.Context, arg AddCourseRequiredFeatureParams) error {
+	_, err := q.db.ExecContext(ctx, addCourseRequiredFeature,
+		arg.CourseID,
+		arg.UniversityID,
+		arg.SessionType,
+		arg.Feature,
+	)
+	return err
+}
+
+const addElectiveGroupCourse = `-- name: AddElectiveGroupCourse :exec
+INSERT INTO elective_group_courses(
+    elective_group_id,course_id
+)VALUES($1,$2)
+`
+
+type AddElectiveGroupCourseParams struct {
+	ElectiveGroupID uuid.UUID
+	CourseID        uuid.UUID
+}
+
+func (q *Queries) AddElectiveGroupCourse(ctx context.Context, arg AddElectiveGroupCourseParams) error {
+	_, err := q.db.ExecContext(ctx, addElectiveGroupCourse, arg.ElectiveGroupID, arg.CourseID)
+	return err
+}
+
 const addRefreshToken = `-- name: AddRefreshToken :one
 INSERT INTO refresh_tokens(
     refresh_token, expires_at, user_id
@@ -43,6 +82,23 @@ func (q *Queries) AddRefreshToken(ctx context.Context, arg AddRefreshTokenParams
 	return i, err
 }
 
+const addVenueFeature = `-- name: AddVenueFeature :exec
+INSERT INTO venue_features(
+    venue_id,feature,university_id
+)VALUES($1,$2,$3)
+`
+
+type AddVenueFeatureParams struct {
+	VenueID      uuid.UUID
+	Feature      string
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) AddVenueFeature(ctx context.Context, arg AddVenueFeatureParams) error {
+	_, err := q.db.ExecContext(ctx, addVenueFeature, arg.VenueID, arg.Feature, arg.UniversityID)
+	return err
+}
+
 const approveDean = `-- name: ApproveDean :one
 UPDATE dean_waiting_list
 SET approved = TRUE
@@ -108,6 +164,46 @@ func (q *Queries) ApproveLecturer(ctx context.Context, waitID uuid.UUID) (Lectur
 	return i, err
 }
 
+const archiveDraftCandidates = `-- name: ArchiveDraftCandidates :exec
+UPDATE candidates
+SET candidate_status = 'ARCHIVED',
+    updated_at = NOW()
+WHERE university_id = $1 AND candidate_status = 'DRAFT'
+    AND academic_session IS NOT DISTINCT FROM $2
+    AND semester IS NOT DISTINCT FROM $3
+`
+
+type ArchiveDraftCandidatesParams struct {
+	UniversityID    uuid.UUID
+	AcademicSession sql.NullString
+	Semester        sql.NullString
+}
+
+func (q *Queries) ArchiveDraftCandidates(ctx context.Context, arg ArchiveDraftCandidatesParams) error {
+	_, err := q.db.ExecContext(ctx, archiveDraftCandidates, arg.UniversityID, arg.AcademicSession, arg.Semester)
+	return err
+}
+
+const archivePublishedCandidate = `-- name: ArchivePublishedCandidate :exec
+UPDATE candidates
+SET candidate_status = 'ARCHIVED',
+    updated_at = NOW()
+WHERE university_id = $1 AND candidate_status = 'PUBLISHED'
+    AND academic_session IS NOT DISTINCT FROM $2
+    AND semester IS NOT DISTINCT FROM $3
+`
+
+type ArchivePublishedCandidateParams struct {
+	UniversityID    uuid.UUID
+	AcademicSession sql.NullString
+	Semester        sql.NullString
+}
+
+func (q *Queries) ArchivePublishedCandidate(ctx context.Context, arg ArchivePublishedCandidateParams) error {
+	_, err := q.db.ExecContext(ctx, archivePublishedCandidate, arg.UniversityID, arg.AcademicSession, arg.Semester)
+	return err
+}
+
 const checkAndReturnToken = `-- name: CheckAndReturnToken :one
 SELECT refresh_token,expires_at,user_id,is_revoked
 FROM refresh_tokens
@@ -343,6 +439,56 @@ func (q *Queries) CheckLecturerConfirmation(ctx context.Context, waitID uuid.UUI
 	return i, err
 }
 
+const clearCourseComponents = `-- name: ClearCourseComponents :exec
+DELETE FROM course_components
+WHERE course_id = $1
+`
+
+func (q *Queries) ClearCourseComponents(ctx context.Context, courseID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, clearCourseComponents, courseID)
+	return err
+}
+
+const clearCourseRequiredFeatures = `-- name: ClearCourseRequiredFeatures :exec
+DELETE FROM course_required_features
+WHERE course_id = $1 AND session_type IS NOT DISTINCT FROM $2
+`
+
+type ClearCourseRequiredFeaturesParams struct {
+	CourseID    uuid.UUID
+	SessionType sql.NullString
+}
+
+func (q *Queries) ClearCourseRequiredFeatures(ctx context.Context, arg ClearCourseRequiredFeaturesParams) error {
+	_, err := q.db.ExecContext(ctx, clearCourseRequiredFeatures, arg.CourseID, arg.SessionType)
+	return err
+}
+
+const clearElectiveGroupCourses = `-- name: ClearElectiveGroupCourses :exec
+DELETE FROM elective_group_courses
+WHERE elective_group_id = $1
+`
+
+func (q *Queries) ClearElectiveGroupCourses(ctx context.Context, electiveGroupID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, clearElectiveGroupCourses, electiveGroupID)
+	return err
+}
+
+const clearVenueFeatures = `-- name: ClearVenueFeatures :exec
+DELETE FROM venue_features
+WHERE venue_id = $1 AND university_id = $2
+`
+
+type ClearVenueFeaturesParams struct {
+	VenueID      uuid.UUID
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) ClearVenueFeatures(ctx context.Context, arg ClearVenueFeaturesParams) error {
+	_, err := q.db.ExecContext(ctx, clearVenueFeatures, arg.VenueID, arg.UniversityID)
+	return err
+}
+
 const countCohortsForOneUni = `-- name: CountCohortsForOneUni :one
 SELECT COUNT(*) FROM cohorts
 WHERE cohort_university_id = $1
@@ -473,9 +619,11 @@ func (q *Queries) CountVenuesforFaculty(ctx context.Context, arg CountVenuesforF
 
 const createCandidate = `-- name: CreateCandidate :one
 INSERT INTO candidates(
-    fitness,university_id,candidate_status,start_of_day,end_of_day
-)VALUES($1,$2,$3,$4,$5)
-RETURNING id, fitness, university_id, candidate_status, start_of_day, end_of_day, created_at, updated_at
+    fitness,university_id,candidate_status,start_of_day,end_of_day,
+    seed,population_size,generations,mutation_rate,tournament_size,elitism_fraction,
+    repaired_from,solver,workers,generations_run,stop_reason,academic_session,semester
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18)
+RETURNING id, fitness, university_id, candidate_status, start_of_day, end_of_day, created_at, updated_at, seed, population_size, generations, mutation_rate, tournament_size, elitism_fraction, repaired_from, published_at, published_by, solver, workers, generations_run, stop_reason, academic_session, semester
 `
 
 type CreateCandidateParams struct {
@@ -484,6 +632,19 @@ type CreateCandidateParams struct {
 	CandidateStatus string
 	StartOfDay      time.Time
 	EndOfDay        time.Time
+	Seed            sql.NullInt64
+	PopulationSize  sql.NullInt32
+	Generations     sql.NullInt32
+	MutationRate    sql.NullFloat64
+	TournamentSize  sql.NullInt32
+	ElitismFraction sql.NullFloat64
+	RepairedFrom    uuid.NullUUID
+	Solver          sql.NullString
+	Workers         sql.NullInt32
+	GenerationsRun  sql.NullInt32
+	StopReason      sql.NullString
+	AcademicSession sql.NullString
+	Semester        sql.NullString
 }
 
 func (q *Queries) CreateCandidate(ctx context.Context, arg CreateCandidateParams) (Candidate, error) {
@@ -493,6 +654,19 @@ func (q *Queries) CreateCandidate(ctx context.Context, arg CreateCandidateParams
 		arg.CandidateStatus,
 		arg.StartOfDay,
 		arg.EndOfDay,
+		arg.Seed,
+		arg.PopulationSize,
+		arg.Generations,
+		arg.MutationRate,
+		arg.TournamentSize,
+		arg.ElitismFraction,
+		arg.RepairedFrom,
+		arg.Solver,
+		arg.Workers,
+		arg.GenerationsRun,
+		arg.StopReason,
+		arg.AcademicSession,
+		arg.Semester,
 	)
 	var i Candidate
 	err := row.Scan(
@@ -504,10 +678,48 @@ func (q *Queries) CreateCandidate(ctx context.Context, arg CreateCandidateParams
 		&i.EndOfDay,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Seed,
+		&i.PopulationSize,
+		&i.Generations,
+		&i.MutationRate,
+		&i.TournamentSize,
+		&i.ElitismFraction,
+		&i.RepairedFrom,
+		&i.PublishedAt,
+		&i.PublishedBy,
+		&i.Solver,
+		&i.Workers,
+		&i.GenerationsRun,
+		&i.StopReason,
+		&i.AcademicSession,
+		&i.Semester,
 	)
 	return i, err
 }
 
+const createCandidateFitness = `-- name: CreateCandidateFitness :exec
+INSERT INTO candidate_fitness_history(
+    candidate_id,generation,best_fitness,mean_fitness
+)VALUES($1,$2,$3,$4)
+`
+
+type CreateCandidateFitnessParams struct {
+	CandidateID uuid.UUID
+	Generation  int32
+	BestFitness float64
+	MeanFitness float64
+}
+
+func (q *Queries) CreateCandidateFitness(ctx context.Context, arg CreateCandidateFitnessParams) error {
+	_, err := q.db.ExecContext(ctx, createCandidateFitness,
+		arg.CandidateID,
+		arg.Generation,
+		arg.BestFitness,
+		arg.MeanFitness,
+	)
+	return err
+}
+
 const createCohort = `-- name: CreateCohort :one
 INSERT INTO cohorts(cohort_name,
     cohort_level,
@@ -517,7 +729,7 @@ INSERT INTO cohorts(cohort_name,
 )VALUES(
     $1,$2,$3,$4,$5
 )
-RETURNING cohort_id, cohort_name, cohort_level, cohort_department_id, cohort_faculty_id, cohort_university_id, created_at, updated_at
+RETURNING cohort_id, cohort_name, cohort_level, cohort_department_id, cohort_faculty_id, cohort_university_id, created_at, updated_at, cohort_size
 `
 
 type CreateCohortParams struct {
@@ -546,6 +758,7 @@ func (q *Queries) CreateCohort(ctx context.Context, arg CreateCohortParams) (Coh
 		&i.CohortUniversityID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CohortSize,
 	)
 	return i, err
 }
@@ -622,7 +835,7 @@ INSERT INTO courses(
 VALUES(
     $1,$2,$3,$4,$5,$6,$7,$8,$9,$10
 )
-RETURNING course_id, course_code, course_title, course_credit_unit, course_duration, department_id, university_id, lecturer_id, sessions_per_week, level, semester, created_at, updated_at
+RETURNING course_id, course_code, course_title, course_credit_unit, course_duration, department_id, university_id, lecturer_id, sessions_per_week, level, semester, created_at, updated_at, lecturer_mode
 `
 
 type CreateCourseParams struct {
@@ -666,10 +879,41 @@ func (q *Queries) CreateCourse(ctx context.Context, arg CreateCourseParams) (Cou
 		&i.Semester,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LecturerMode,
+		&i.MaxGroupSize,
 	)
 	return i, err
 }
 
+const createCourseComponent = `-- name: CreateCourseComponent :exec
+INSERT INTO course_components(
+    course_id,university_id,session_type,duration,sessions_per_week,venue_kind,max_group_size
+)VALUES($1,$2,$3,$4,$5,$6,$7)
+`
+
+type CreateCourseComponentParams struct {
+	CourseID        uuid.UUID
+	UniversityID    uuid.UUID
+	SessionType     string
+	Duration        int32
+	SessionsPerWeek int32
+	VenueKind       sql.NullString
+	MaxGroupSize    sql.NullInt32
+}
+
+func (q *Queries) CreateCourseComponent(ctx context.Context, arg CreateCourseComponentParams) error {
+	_, err := q.db.ExecContext(ctx, createCourseComponent,
+		arg.CourseID,
+		arg.UniversityID,
+		arg.SessionType,
+		arg.Duration,
+		arg.SessionsPerWeek,
+		arg.VenueKind,
+		arg.MaxGroupSize,
+	)
+	return err
+}
+
 const createDean = `-- name: CreateDean :one
 INSERT INTO current_dean(
     lecturer_id,faculty_id,university_id,start_date,end_date
@@ -841,10 +1085,46 @@ func (q *Queries) CreateLecturerUnavailability(ctx context.Context, arg CreateLe
 	return err
 }
 
+const createMovedSession = `-- name: CreateMovedSession :exec
+INSERT INTO candidate_moved_sessions(
+    candidate_id,session_idx,course_id,from_venue_id,from_day,from_session_time,
+    to_venue_id,to_day,to_session_time,reason
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+`
+
+type CreateMovedSessionParams struct {
+	CandidateID     uuid.UUID
+	SessionIdx      int32
+	CourseID        uuid.UUID
+	FromVenueID     uuid.NullUUID
+	FromDay         sql.NullString
+	FromSessionTime sql.NullTime
+	ToVenueID       uuid.UUID
+	ToDay           string
+	ToSessionTime   time.Time
+	Reason          string
+}
+
+func (q *Queries) CreateMovedSession(ctx context.Context, arg CreateMovedSessionParams) error {
+	_, err := q.db.ExecContext(ctx, createMovedSession,
+		arg.CandidateID,
+		arg.SessionIdx,
+		arg.CourseID,
+		arg.FromVenueID,
+		arg.FromDay,
+		arg.FromSessionTime,
+		arg.ToVenueID,
+		arg.ToDay,
+		arg.ToSessionTime,
+		arg.Reason,
+	)
+	return err
+}
+
 const createSessionPlacements = `-- name: CreateSessionPlacements :exec
 INSERT INTO session_placements(
-    candidate_id,session_idx,course_id,venue_id,day,session_time,university_id
-)VALUES($1,$2,$3,$4,$5,$6,$7)
+    candidate_id,session_idx,course_id,venue_id,day,session_time,university_id,conflict,session_type,group_idx,group_count
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
 `
 
 type CreateSessionPlacementsParams struct {
@@ -855,6 +1135,10 @@ type CreateSessionPlacementsParams struct {
 	Day          string
 	SessionTime  time.Time
 	UniversityID uuid.UUID
+	Conflict     bool
+	SessionType  string
+	GroupIdx     int32
+	GroupCount   int32
 }
 
 func (q *Queries) CreateSessionPlacements(ctx context.Context, arg CreateSessionPlacementsParams) error {
@@ -866,6 +1150,33 @@ func (q *Queries) CreateSessionPlacements(ctx context.Context, arg CreateSession
 		arg.Day,
 		arg.SessionTime,
 		arg.UniversityID,
+		arg.Conflict,
+		arg.SessionType,
+		arg.GroupIdx,
+		arg.GroupCount,
+	)
+	return err
+}
+
+const createTeachingDay = `-- name: CreateTeachingDay :exec
+INSERT INTO timetable_teaching_days(
+    university_id,day,start_time,end_time
+)VALUES($1,$2,$3::text::time,$4::text::time)
+`
+
+type CreateTeachingDayParams struct {
+	UniversityID uuid.UUID
+	Day          string
+	StartTime    string
+	EndTime      string
+}
+
+func (q *Queries) CreateTeachingDay(ctx context.Context, arg CreateTeachingDayParams) error {
+	_, err := q.db.ExecContext(ctx, createTeachingDay,
+		arg.UniversityID,
+		arg.Day,
+		arg.StartTime,
+		arg.EndTime,
 	)
 	return err
 }
@@ -925,11 +1236,12 @@ INSERT INTO venues(
     location,
     venue_image,
     capacity,
-    university_id
+    university_id,
+    venue_kind
 )VALUES(
-    $1,$2,$3,$4,$5,$6,$7
+    $1,$2,$3,$4,$5,$6,$7,$8
 )
-RETURNING venue_id, venue_name, venue_longitude, venue_latitude, location, venue_image, capacity, university_id, is_active, created_at, updated_at
+RETURNING venue_id, venue_name, venue_longitude, venue_latitude, location, venue_image, capacity, university_id, is_active, created_at, updated_at, venue_kind, is_restricted
 `
 
 type CreateVenueParams struct {
@@ -940,6 +1252,7 @@ type CreateVenueParams struct {
 	VenueImage     sql.NullString
 	Capacity       int32
 	UniversityID   uuid.UUID
+	VenueKind      sql.NullString
 }
 
 func (q *Queries) CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue, error) {
@@ -951,6 +1264,7 @@ func (q *Queries) CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue
 		arg.VenueImage,
 		arg.Capacity,
 		arg.UniversityID,
+		arg.VenueKind,
 	)
 	var i Venue
 	err := row.Scan(
@@ -965,6 +1279,8 @@ func (q *Queries) CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.VenueKind,
+		&i.IsRestricted,
 	)
 	return i, err
 }
@@ -1006,6 +1322,21 @@ func (q *Queries) DeleteCourse(ctx context.Context, courseID uuid.UUID) error {
 	return err
 }
 
+const deleteCourseLecturer = `-- name: DeleteCourseLecturer :exec
+DELETE FROM courses_lecturers
+WHERE course_id = $1 AND lecturer_id = $2
+`
+
+type DeleteCourseLecturerParams struct {
+	CourseID   uuid.UUID
+	LecturerID uuid.UUID
+}
+
+func (q *Queries) DeleteCourseLecturer(ctx context.Context, arg DeleteCourseLecturerParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCourseLecturer, arg.CourseID, arg.LecturerID)
+	return err
+}
+
 const deleteCoursePossibleVenue = `-- name: DeleteCoursePossibleVenue :exec
 DELETE FROM courses_possible_venues
 WHERE course_id = $1
@@ -1022,6 +1353,21 @@ func (q *Queries) DeleteCoursePossibleVenue(ctx context.Context, arg DeleteCours
 	return err
 }
 
+const deleteElectiveGroup = `-- name: DeleteElectiveGroup :exec
+DELETE FROM elective_groups
+WHERE elective_group_id = $1 AND university_id = $2
+`
+
+type DeleteElectiveGroupParams struct {
+	ElectiveGroupID uuid.UUID
+	UniversityID    uuid.UUID
+}
+
+func (q *Queries) DeleteElectiveGroup(ctx context.Context, arg DeleteElectiveGroupParams) error {
+	_, err := q.db.ExecContext(ctx, deleteElectiveGroup, arg.ElectiveGroupID, arg.UniversityID)
+	return err
+}
+
 const deleteRefreshToken = `-- name: DeleteRefreshToken :one
 DELETE FROM refresh_tokens
 WHERE user_id = $1
@@ -1043,21 +1389,44 @@ func (q *Queries) DeleteRefreshToken(ctx context.Context, userID uuid.UUID) (Ref
 	return i, err
 }
 
-const deprecateLatestCandidate = `-- name: DeprecateLatestCandidate :exec
-UPDATE candidates AS c
-SET candidate_status = 'DEPRECATED',
-    updated_at = NOW()
-WHERE c.id = (
-  SELECT id
-  FROM candidates
-  WHERE candidates.university_id = $1
-  ORDER BY created_at DESC
-  LIMIT 1
-)
+const deleteSessionPin = `-- name: DeleteSessionPin :exec
+DELETE FROM timetable_session_pins
+WHERE pin_id = $1 AND university_id = $2
+`
+
+type DeleteSessionPinParams struct {
+	PinID        uuid.UUID
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) DeleteSessionPin(ctx context.Context, arg DeleteSessionPinParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionPin, arg.PinID, arg.UniversityID)
+	return err
+}
+
+const deleteTeachingDays = `-- name: DeleteTeachingDays :exec
+DELETE FROM timetable_teaching_days
+WHERE university_id = $1
+`
+
+func (q *Queries) DeleteTeachingDays(ctx context.Context, universityID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTeachingDays, universityID)
+	return err
+}
+
+const deleteVenueTravelTime = `-- name: DeleteVenueTravelTime :exec
+DELETE FROM venue_travel_times
+WHERE university_id = $1 AND from_venue_id = $2 AND to_venue_id = $3
 `
 
-func (q *Queries) DeprecateLatestCandidate(ctx context.Context, universityID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deprecateLatestCandidate, universityID)
+type DeleteVenueTravelTimeParams struct {
+	UniversityID uuid.UUID
+	FromVenueID  uuid.UUID
+	ToVenueID    uuid.UUID
+}
+
+func (q *Queries) DeleteVenueTravelTime(ctx context.Context, arg DeleteVenueTravelTimeParams) error {
+	_, err := q.db.ExecContext(ctx, deleteVenueTravelTime, arg.UniversityID, arg.FromVenueID, arg.ToVenueID)
 	return err
 }
 
@@ -1283,7 +1652,8 @@ SELECT
     cohort_level,
     cohort_department_id,
     cohort_faculty_id,
-    cohort_university_id
+    cohort_university_id,
+    cohort_size
 FROM cohorts
 WHERE 
     cohort_department_id = $1
@@ -1296,6 +1666,7 @@ type FetchCohortsForADepartmentRow struct {
 	CohortDepartmentID uuid.UUID
 	CohortFacultyID    uuid.UUID
 	CohortUniversityID uuid.UUID
+	CohortSize         sql.NullInt32
 }
 
 func (q *Queries) FetchCohortsForADepartment(ctx context.Context, cohortDepartmentID uuid.UUID) ([]FetchCohortsForADepartmentRow, error) {
@@ -1314,6 +1685,59 @@ func (q *Queries) FetchCohortsForADepartment(ctx context.Context, cohortDepartme
 			&i.CohortDepartmentID,
 			&i.CohortFacultyID,
 			&i.CohortUniversityID,
+			&i.CohortSize,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const fetchCourseComponents = `-- name: FetchCourseComponents :many
+SELECT
+    component_id,
+    session_type,
+    duration,
+    sessions_per_week,
+    venue_kind,
+    max_group_size
+FROM course_components
+WHERE course_id = $1
+ORDER BY session_type
+`
+
+type FetchCourseComponentsRow struct {
+	ComponentID     uuid.UUID
+	SessionType     string
+	Duration        int32
+	SessionsPerWeek int32
+	VenueKind       sql.NullString
+	MaxGroupSize    sql.NullInt32
+}
+
+func (q *Queries) FetchCourseComponents(ctx context.Context, courseID uuid.UUID) ([]FetchCourseComponentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, fetchCourseComponents, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FetchCourseComponentsRow
+	for rows.Next() {
+		var i FetchCourseComponentsRow
+		if err := rows.Scan(
+			&i.ComponentID,
+			&i.SessionType,
+			&i.Duration,
+			&i.SessionsPerWeek,
+			&i.VenueKind,
+			&i.MaxGroupSize,
 		); err != nil {
 			return nil, err
 		}
@@ -1371,6 +1795,97 @@ func (q *Queries) FetchCoursePossibleVenues(ctx context.Context, courseID uuid.U
 	return items, nil
 }
 
+const fetchCourseRequiredFeatures = `-- name: FetchCourseRequiredFeatures :many
+SELECT
+    session_type,
+    feature
+FROM course_required_features
+WHERE course_id = $1
+ORDER BY session_type NULLS FIRST, feature
+`
+
+type FetchCourseRequiredFeaturesRow struct {
+	SessionType sql.NullString
+	Feature     string
+}
+
+func (q *Queries) FetchCourseRequiredFeatures(ctx context.Context, courseID uuid.UUID) ([]FetchCourseRequiredFeaturesRow, error) {
+	rows, err := q.db.QueryContext(ctx, fetchCourseRequiredFeatures, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FetchCourseRequiredFeaturesRow
+	for rows.Next() {
+		var i FetchCourseRequiredFeaturesRow
+		if err := rows.Scan(
+			&i.SessionType,
+			&i.Feature,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const fetchElectiveGroupsForACohort = `-- name: FetchElectiveGroupsForACohort :many
+SELECT
+    eg.elective_group_id,
+    eg.group_name,
+    c.course_id,
+    c.course_code,
+    c.course_title
+FROM elective_groups eg
+JOIN elective_group_courses egc ON egc.elective_group_id = eg.elective_group_id
+JOIN courses c ON c.course_id = egc.course_id
+WHERE eg.cohort_id = $1
+ORDER BY eg.group_name, c.course_code
+`
+
+type FetchElectiveGroupsForACohortRow struct {
+	ElectiveGroupID uuid.UUID
+	GroupName       string
+	CourseID        uuid.UUID
+	CourseCode      string
+	CourseTitle     string
+}
+
+func (q *Queries) FetchElectiveGroupsForACohort(ctx context.Context, cohortID uuid.UUID) ([]FetchElectiveGroupsForACohortRow, error) {
+	rows, err := q.db.QueryContext(ctx, fetchElectiveGroupsForACohort, cohortID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FetchElectiveGroupsForACohortRow
+	for rows.Next() {
+		var i FetchElectiveGroupsForACohortRow
+		if err := rows.Scan(
+			&i.ElectiveGroupID,
+			&i.GroupName,
+			&i.CourseID,
+			&i.CourseCode,
+			&i.CourseTitle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const fetchLecturerUnavailability = `-- name: FetchLecturerUnavailability :many
 SELECT
     lecturer_id,
@@ -1487,7 +2002,980 @@ func (q *Queries) FetchStudentCourses(ctx context.Context, studentID uuid.UUID)
 	return items, nil
 }
 
-const getCohortSessionsInCurrentTimetable = `-- name: GetCohortSessionsInCurrentTimetable :many
+const fetchVenueFeatures = `-- name: FetchVenueFeatures :many
+SELECT feature
+FROM venue_features
+WHERE venue_id = $1
+ORDER BY feature
+`
+
+func (q *Queries) FetchVenueFeatures(ctx context.Context, venueID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, fetchVenueFeatures, venueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var feature string
+		if err := rows.Scan(&feature); err != nil {
+			return nil, err
+		}
+		items = append(items, feature)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidateById = `-- name: GetCandidateById :one
+SELECT id, fitness, university_id, candidate_status, start_of_day, end_of_day, created_at, updated_at, seed, population_size, generations, mutation_rate, tournament_size, elitism_fraction, repaired_from, published_at, published_by, solver, workers, generations_run, stop_reason, academic_session, semester FROM candidates
+WHERE id = $1 AND university_id = $2
+`
+
+type GetCandidateByIdParams struct {
+	ID           uuid.UUID
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) GetCandidateById(ctx context.Context, arg GetCandidateByIdParams) (Candidate, error) {
+	row := q.db.QueryRowContext(ctx, getCandidateById, arg.ID, arg.UniversityID)
+	var i Candidate
+	err := row.Scan(
+		&i.ID,
+		&i.Fitness,
+		&i.UniversityID,
+		&i.CandidateStatus,
+		&i.StartOfDay,
+		&i.EndOfDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Seed,
+		&i.PopulationSize,
+		&i.Generations,
+		&i.MutationRate,
+		&i.TournamentSize,
+		&i.ElitismFraction,
+		&i.RepairedFrom,
+		&i.PublishedAt,
+		&i.PublishedBy,
+		&i.Solver,
+		&i.Workers,
+		&i.GenerationsRun,
+		&i.StopReason,
+		&i.AcademicSession,
+		&i.Semester,
+	)
+	return i, err
+}
+
+const getCandidateCohortSessions = `-- name: GetCandidateCohortSessions :many
+SELECT 
+    cco.cohort_id,
+    ch.cohort_name,
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.session_type
+FROM session_placements sp
+JOIN cohort_courses_offered cco ON cco.course_id = sp.course_id
+JOIN cohorts ch ON ch.cohort_id = cco.cohort_id
+JOIN courses co ON co.course_id = sp.course_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1
+ORDER BY ch.cohort_name, co.course_code, sp.session_idx
+`
+
+type GetCandidateCohortSessionsRow struct {
+	CohortID    uuid.UUID
+	CohortName  string
+	SessionIdx  int32
+	CourseID    uuid.UUID
+	CourseCode  string
+	VenueID     uuid.UUID
+	VenueName   string
+	Day         string
+	SessionTime time.Time
+	SessionType string
+}
+
+func (q *Queries) GetCandidateCohortSessions(ctx context.Context, candidateID uuid.UUID) ([]GetCandidateCohortSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCandidateCohortSessions, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCandidateCohortSessionsRow
+	for rows.Next() {
+		var i GetCandidateCohortSessionsRow
+		if err := rows.Scan(
+			&i.CohortID,
+			&i.CohortName,
+			&i.SessionIdx,
+			&i.CourseID,
+			&i.CourseCode,
+			&i.VenueID,
+			&i.VenueName,
+			&i.Day,
+			&i.SessionTime,
+			&i.SessionType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidateFitnessHistory = `-- name: GetCandidateFitnessHistory :many
+SELECT generation,best_fitness,mean_fitness FROM candidate_fitness_history
+WHERE candidate_id = $1
+ORDER BY generation
+`
+
+type GetCandidateFitnessHistoryRow struct {
+	Generation  int32
+	BestFitness float64
+	MeanFitness float64
+}
+
+func (q *Queries) GetCandidateFitnessHistory(ctx context.Context, candidateID uuid.UUID) ([]GetCandidateFitnessHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCandidateFitnessHistory, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCandidateFitnessHistoryRow
+	for rows.Next() {
+		var i GetCandidateFitnessHistoryRow
+		if err := rows.Scan(&i.Generation, &i.BestFitness, &i.MeanFitness); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidateReviews = `-- name: GetCandidateReviews :many
+SELECT 
+    r.review_id,
+    r.candidate_id,
+    r.reviewer_id,
+    l.lecturer_first_name,
+    l.lecturer_last_name,
+    r.reviewer_role,
+    r.faculty_id,
+    f.faculty_name,
+    r.department_id,
+    d.department_name,
+    r.decision,
+    r.comment,
+    r.created_at,
+    r.updated_at
+FROM candidate_reviews r
+JOIN lecturers l ON l.lecturer_id = r.reviewer_id
+LEFT JOIN faculties f ON f.faculty_id = r.faculty_id
+LEFT JOIN departments d ON d.department_id = r.department_id
+WHERE r.candidate_id = $1
+ORDER BY r.updated_at DESC
+`
+
+type GetCandidateReviewsRow struct {
+	ReviewID          uuid.UUID
+	CandidateID       uuid.UUID
+	ReviewerID        uuid.UUID
+	LecturerFirstName string
+	LecturerLastName  string
+	ReviewerRole      string
+	FacultyID         uuid.NullUUID
+	FacultyName       sql.NullString
+	DepartmentID      uuid.NullUUID
+	DepartmentName    sql.NullString
+	Decision          string
+	Comment           string
+	CreatedAt         sql.NullTime
+	UpdatedAt         sql.NullTime
+}
+
+func (q *Queries) GetCandidateReviews(ctx context.Context, candidateID uuid.UUID) ([]GetCandidateReviewsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCandidateReviews, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCandidateReviewsRow
+	for rows.Next() {
+		var i GetCandidateReviewsRow
+		if err := rows.Scan(
+			&i.ReviewID,
+			&i.CandidateID,
+			&i.ReviewerID,
+			&i.LecturerFirstName,
+			&i.LecturerLastName,
+			&i.ReviewerRole,
+			&i.FacultyID,
+			&i.FacultyName,
+			&i.DepartmentID,
+			&i.DepartmentName,
+			&i.Decision,
+			&i.Comment,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidateSessionPlacements = `-- name: GetCandidateSessionPlacements :many
+SELECT 
+    session_idx,
+    course_id,
+    venue_id,
+    day,
+    session_time,
+    conflict
+FROM session_placements
+WHERE candidate_id = $1
+ORDER BY session_idx
+`
+
+type GetCandidateSessionPlacementsRow struct {
+	SessionIdx  int32
+	CourseID    uuid.UUID
+	VenueID     uuid.UUID
+	Day         string
+	SessionTime time.Time
+	Conflict    bool
+}
+
+func (q *Queries) GetCandidateSessionPlacements(ctx context.Context, candidateID uuid.UUID) ([]GetCandidateSessionPlacementsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCandidateSessionPlacements, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCandidateSessionPlacementsRow
+	for rows.Next() {
+		var i GetCandidateSessionPlacementsRow
+		if err := rows.Scan(
+			&i.SessionIdx,
+			&i.CourseID,
+			&i.VenueID,
+			&i.Day,
+			&i.SessionTime,
+			&i.Conflict,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidateSessions = `-- name: GetCandidateSessions :many
+SELECT 
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    co.course_title,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.conflict,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN courses co ON co.course_id = sp.course_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1
+ORDER BY sp.session_idx
+`
+
+type GetCandidateSessionsRow struct {
+	SessionIdx  int32
+	CourseID    uuid.UUID
+	CourseCode  string
+	CourseTitle string
+	VenueID     uuid.UUID
+	VenueName   string
+	Day         string
+	SessionTime time.Time
+	Conflict    bool
+	SessionType string
+	GroupIdx    int32
+	GroupCount  int32
+}
+
+func (q *Queries) GetCandidateSessions(ctx context.Context, candidateID uuid.UUID) ([]GetCandidateSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCandidateSessions, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCandidateSessionsRow
+	for rows.Next() {
+		var i GetCandidateSessionsRow
+		if err := rows.Scan(
+			&i.SessionIdx,
+			&i.CourseID,
+			&i.CourseCode,
+			&i.CourseTitle,
+			&i.VenueID,
+			&i.VenueName,
+			&i.Day,
+			&i.SessionTime,
+			&i.Conflict,
+			&i.SessionType,
+			&i.GroupIdx,
+			&i.GroupCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidateSessionsForDepartment = `-- name: GetCandidateSessionsForDepartment :many
+SELECT 
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    co.course_title,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.conflict,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN courses co ON co.course_id = sp.course_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1 AND co.department_id = $2
+ORDER BY sp.session_idx
+`
+
+type GetCandidateSessionsForDepartmentParams struct {
+	CandidateID  uuid.UUID
+	DepartmentID uuid.UUID
+}
+
+type GetCandidateSessionsForDepartmentRow struct {
+	SessionIdx  int32
+	CourseID    uuid.UUID
+	CourseCode  string
+	CourseTitle string
+	VenueID     uuid.UUID
+	VenueName   string
+	Day         string
+	SessionTime time.Time
+	Conflict    bool
+	SessionType string
+	GroupIdx    int32
+	GroupCount  int32
+}
+
+func (q *Queries) GetCandidateSessionsForDepartment(ctx context.Context, arg GetCandidateSessionsForDepartmentParams) ([]GetCandidateSessionsForDepartmentRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCandidateSessionsForDepartment, arg.CandidateID, arg.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCandidateSessionsForDepartmentRow
+	for rows.Next() {
+		var i GetCandidateSessionsForDepartmentRow
+		if err := rows.Scan(
+			&i.SessionIdx,
+			&i.CourseID,
+			&i.CourseCode,
+			&i.CourseTitle,
+			&i.VenueID,
+			&i.VenueName,
+			&i.Day,
+			&i.SessionTime,
+			&i.Conflict,
+			&i.SessionType,
+			&i.GroupIdx,
+			&i.GroupCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCandidateSessionsForFaculty = `-- name: GetCandidateSessionsForFaculty :many
+SELECT 
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    co.course_title,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.conflict,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN courses co ON co.course_id = sp.course_id
+JOIN departments d ON d.department_id = co.department_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1 AND d.faculty_id = $2
+ORDER BY sp.session_idx
+`
+
+type GetCandidateSessionsForFacultyParams struct {
+	CandidateID uuid.UUID
+	FacultyID   uuid.UUID
+}
+
+type GetCandidateSessionsForFacultyRow struct {
+	SessionIdx  int32
+	CourseID    uuid.UUID
+	CourseCode  string
+	CourseTitle string
+	VenueID     uuid.UUID
+	VenueName   string
+	Day         string
+	SessionTime time.Time
+	Conflict    bool
+	SessionType string
+	GroupIdx    int32
+	GroupCount  int32
+}
+
+func (q *Queries) GetCandidateSessionsForFaculty(ctx context.Context, arg GetCandidateSessionsForFacultyParams) ([]GetCandidateSessionsForFacultyRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCandidateSessionsForFaculty, arg.CandidateID, arg.FacultyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCandidateSessionsForFacultyRow
+	for rows.Next() {
+		var i GetCandidateSessionsForFacultyRow
+		if err := rows.Scan(
+			&i.SessionIdx,
+			&i.CourseID,
+			&i.CourseCode,
+			&i.CourseTitle,
+			&i.VenueID,
+			&i.VenueName,
+			&i.Day,
+			&i.SessionTime,
+			&i.Conflict,
+			&i.SessionType,
+			&i.GroupIdx,
+			&i.GroupCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCohortSessionsInCurrentTimetable = `-- name: GetCohortSessionsInCurrentTimetable :many
+SELECT 
+    sp.id AS session_id,
+    sp.session_idx,
+    sp.course_id,
+    sp.venue_id,
+    sp.day,
+    sp.session_time,
+    sp.university_id,
+    c.fitness,
+    c.candidate_status,
+    c.start_of_day,
+    c.end_of_day,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN candidates c 
+    ON sp.candidate_id = c.id
+JOIN cohort_courses_offered cco
+    ON sp.course_id = cco.course_id
+WHERE 
+    cco.cohort_id = $1
+    AND cco.university_id = $2
+    AND c.university_id = $2
+    AND c.id = $3
+    AND c.candidate_status = 'PUBLISHED'
+`
+
+type GetCohortSessionsInCurrentTimetableParams struct {
+	CohortID     uuid.UUID
+	UniversityID uuid.UUID
+	ID           uuid.UUID
+}
+
+type GetCohortSessionsInCurrentTimetableRow struct {
+	SessionID       uuid.UUID
+	SessionIdx      int32
+	CourseID        uuid.UUID
+	VenueID         uuid.UUID
+	Day             string
+	SessionTime     time.Time
+	UniversityID    uuid.UUID
+	Fitness         float64
+	CandidateStatus string
+	StartOfDay      time.Time
+	EndOfDay        time.Time
+	SessionType     string
+	GroupIdx        int32
+	GroupCount      int32
+}
+
+func (q *Queries) GetCohortSessionsInCurrentTimetable(ctx context.Context, arg GetCohortSessionsInCurrentTimetableParams) ([]GetCohortSessionsInCurrentTimetableRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCohortSessionsInCurrentTimetable, arg.CohortID, arg.UniversityID, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCohortSessionsInCurrentTimetableRow
+	for rows.Next() {
+		var i GetCohortSessionsInCurrentTimetableRow
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.SessionIdx,
+			&i.CourseID,
+			&i.VenueID,
+			&i.Day,
+			&i.SessionTime,
+			&i.UniversityID,
+			&i.Fitness,
+			&i.CandidateStatus,
+			&i.StartOfDay,
+			&i.EndOfDay,
+			&i.SessionType,
+			&i.GroupIdx,
+			&i.GroupCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCourseDepartments = `-- name: GetCourseDepartments :many
+SELECT
+    co.course_id,
+    co.department_id,
+    d.faculty_id
+FROM courses co
+JOIN departments d ON d.department_id = co.department_id
+WHERE co.university_id = $1
+`
+
+type GetCourseDepartmentsRow struct {
+	CourseID     uuid.UUID
+	DepartmentID uuid.UUID
+	FacultyID    uuid.UUID
+}
+
+func (q *Queries) GetCourseDepartments(ctx context.Context, universityID uuid.UUID) ([]GetCourseDepartmentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCourseDepartments, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCourseDepartmentsRow
+	for rows.Next() {
+		var i GetCourseDepartmentsRow
+		if err := rows.Scan(
+			&i.CourseID,
+			&i.DepartmentID,
+			&i.FacultyID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDepartmentVenueUsage = `-- name: GetDepartmentVenueUsage :many
+SELECT
+    d.department_id,
+    d.department_name,
+    COUNT(*)::int AS sessions,
+    COUNT(*) FILTER (WHERE EXISTS (
+        SELECT 1 FROM dept_venues dv WHERE dv.venue_id = sp.venue_id AND dv.department_id = d.department_id
+    ))::int AS own_department,
+    COUNT(*) FILTER (WHERE NOT EXISTS (
+        SELECT 1 FROM dept_venues dv WHERE dv.venue_id = sp.venue_id AND dv.department_id = d.department_id
+    ) AND EXISTS (
+        SELECT 1 FROM faculty_venues fv WHERE fv.venue_id = sp.venue_id AND fv.faculty_id = d.faculty_id
+    ))::int AS own_faculty,
+    COUNT(*) FILTER (WHERE NOT EXISTS (
+        SELECT 1 FROM dept_venues dv WHERE dv.venue_id = sp.venue_id
+    ) AND NOT EXISTS (
+        SELECT 1 FROM faculty_venues fv WHERE fv.venue_id = sp.venue_id
+    ))::int AS shared
+FROM session_placements sp
+JOIN courses c ON c.course_id = sp.course_id
+JOIN departments d ON d.department_id = c.department_id
+WHERE sp.candidate_id = $1
+GROUP BY d.department_id, d.department_name
+ORDER BY d.department_name
+`
+
+type GetDepartmentVenueUsageRow struct {
+	DepartmentID   uuid.UUID
+	DepartmentName string
+	Sessions       int32
+	OwnDepartment  int32
+	OwnFaculty     int32
+	Shared         int32
+}
+
+func (q *Queries) GetDepartmentVenueUsage(ctx context.Context, candidateID uuid.UUID) ([]GetDepartmentVenueUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDepartmentVenueUsage, candidateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDepartmentVenueUsageRow
+	for rows.Next() {
+		var i GetDepartmentVenueUsageRow
+		if err := rows.Scan(
+			&i.DepartmentID,
+			&i.DepartmentName,
+			&i.Sessions,
+			&i.OwnDepartment,
+			&i.OwnFaculty,
+			&i.Shared,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestPublishedCandidate = `-- name: GetLatestPublishedCandidate :one
+SELECT id, fitness, university_id, candidate_status, start_of_day, end_of_day, created_at, updated_at, seed, population_size, generations, mutation_rate, tournament_size, elitism_fraction, repaired_from, published_at, published_by, solver, workers, generations_run, stop_reason, academic_session, semester FROM candidates
+WHERE university_id = $1 AND candidate_status = 'PUBLISHED'
+ORDER BY published_at DESC NULLS LAST, created_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestPublishedCandidate(ctx context.Context, universityID uuid.UUID) (Candidate, error) {
+	row := q.db.QueryRowContext(ctx, getLatestPublishedCandidate, universityID)
+	var i Candidate
+	err := row.Scan(
+		&i.ID,
+		&i.Fitness,
+		&i.UniversityID,
+		&i.CandidateStatus,
+		&i.StartOfDay,
+		&i.EndOfDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Seed,
+		&i.PopulationSize,
+		&i.Generations,
+		&i.MutationRate,
+		&i.TournamentSize,
+		&i.ElitismFraction,
+		&i.RepairedFrom,
+		&i.PublishedAt,
+		&i.PublishedBy,
+		&i.Solver,
+		&i.Workers,
+		&i.GenerationsRun,
+		&i.StopReason,
+		&i.AcademicSession,
+		&i.Semester,
+	)
+	return i, err
+}
+
+const getMovedSessions = `-- name: GetMovedSessions :many
+SELECT 
+    m.session_idx,
+    m.course_id,
+    c.course_code,
+    m.from_venue_id,
+    fv.venue_name AS from_venue_name,
+    m.from_day,
+    m.from_session_time,
+    m.to_venue_id,
+    tv.venue_name AS to_venue_name,
+    m.to_day,
+    m.to_session_time,
+    m.reason
+FROM candidate_moved_sessions m
+JOIN candidates cd ON cd.id = m.candidate_id
+JOIN courses c ON c.course_id = m.course_id
+LEFT JOIN venues fv ON fv.venue_id = m.from_venue_id
+JOIN venues tv ON tv.venue_id = m.to_venue_id
+WHERE m.candidate_id = $1 AND cd.university_id = $2
+ORDER BY c.course_code, m.session_idx
+`
+
+type GetMovedSessionsParams struct {
+	CandidateID  uuid.UUID
+	UniversityID uuid.UUID
+}
+
+type GetMovedSessionsRow struct {
+	SessionIdx      int32
+	CourseID        uuid.UUID
+	CourseCode      string
+	FromVenueID     uuid.NullUUID
+	FromVenueName   sql.NullString
+	FromDay         sql.NullString
+	FromSessionTime sql.NullTime
+	ToVenueID       uuid.UUID
+	ToVenueName     string
+	ToDay           string
+	ToSessionTime   time.Time
+	Reason          string
+}
+
+func (q *Queries) GetMovedSessions(ctx context.Context, arg GetMovedSessionsParams) ([]GetMovedSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMovedSessions, arg.CandidateID, arg.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMovedSessionsRow
+	for rows.Next() {
+		var i GetMovedSessionsRow
+		if err := rows.Scan(
+			&i.SessionIdx,
+			&i.CourseID,
+			&i.CourseCode,
+			&i.FromVenueID,
+			&i.FromVenueName,
+			&i.FromDay,
+			&i.FromSessionTime,
+			&i.ToVenueID,
+			&i.ToVenueName,
+			&i.ToDay,
+			&i.ToSessionTime,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPublishedCandidate = `-- name: GetPublishedCandidate :one
+SELECT id, fitness, university_id, candidate_status, start_of_day, end_of_day, created_at, updated_at, seed, population_size, generations, mutation_rate, tournament_size, elitism_fraction, repaired_from, published_at, published_by, solver, workers, generations_run, stop_reason, academic_session, semester FROM candidates
+WHERE university_id = $1 AND candidate_status = 'PUBLISHED'
+    AND academic_session IS NOT DISTINCT FROM $2
+    AND semester IS NOT DISTINCT FROM $3
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type GetPublishedCandidateParams struct {
+	UniversityID    uuid.UUID
+	AcademicSession sql.NullString
+	Semester        sql.NullString
+}
+
+func (q *Queries) GetPublishedCandidate(ctx context.Context, arg GetPublishedCandidateParams) (Candidate, error) {
+	row := q.db.QueryRowContext(ctx, getPublishedCandidate, arg.UniversityID, arg.AcademicSession, arg.Semester)
+	var i Candidate
+	err := row.Scan(
+		&i.ID,
+		&i.Fitness,
+		&i.UniversityID,
+		&i.CandidateStatus,
+		&i.StartOfDay,
+		&i.EndOfDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Seed,
+		&i.PopulationSize,
+		&i.Generations,
+		&i.MutationRate,
+		&i.TournamentSize,
+		&i.ElitismFraction,
+		&i.RepairedFrom,
+		&i.PublishedAt,
+		&i.PublishedBy,
+		&i.Solver,
+		&i.Workers,
+		&i.GenerationsRun,
+		&i.StopReason,
+		&i.AcademicSession,
+		&i.Semester,
+	)
+	return i, err
+}
+
+const getSessionPins = `-- name: GetSessionPins :many
+SELECT 
+    p.pin_id,
+    p.course_id,
+    c.course_code,
+    p.session_number,
+    p.day,
+    p.start_time::text AS start_time,
+    p.venue_id,
+    v.venue_name,
+    c.semester
+FROM timetable_session_pins p
+JOIN courses c ON c.course_id = p.course_id
+JOIN venues v ON v.venue_id = p.venue_id
+WHERE p.university_id = $1
+ORDER BY c.course_code, p.session_number
+`
+
+type GetSessionPinsRow struct {
+	PinID         uuid.UUID
+	CourseID      uuid.UUID
+	CourseCode    string
+	SessionNumber int32
+	Day           string
+	StartTime     string
+	VenueID       uuid.UUID
+	VenueName     string
+	Semester      string
+}
+
+func (q *Queries) GetSessionPins(ctx context.Context, universityID uuid.UUID) ([]GetSessionPinsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSessionPins, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSessionPinsRow
+	for rows.Next() {
+		var i GetSessionPinsRow
+		if err := rows.Scan(
+			&i.PinID,
+			&i.CourseID,
+			&i.CourseCode,
+			&i.SessionNumber,
+			&i.Day,
+			&i.StartTime,
+			&i.VenueID,
+			&i.VenueName,
+			&i.Semester,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStudentTimetableSessions = `-- name: GetStudentTimetableSessions :many
+-- a course split into n groups puts the student in group position % n, where position is the
+-- student's place among the students of the course by reg no
+WITH course_positions AS (
+    SELECT
+        sco.student_id,
+        sco.course_id,
+        (ROW_NUMBER() OVER (
+            PARTITION BY sco.course_id
+            ORDER BY st.student_reg_no NULLS LAST, st.student_id
+        ) - 1)::int AS position
+    FROM student_courses_offered sco
+    JOIN students st
+        ON st.student_id = sco.student_id
+    WHERE sco.course_id IN (
+        SELECT course_id FROM student_courses_offered WHERE student_id = $1
+    )
+)
 SELECT 
     sp.id AS session_id,
     sp.session_idx,
@@ -1499,25 +2987,41 @@ SELECT
     c.fitness,
     c.candidate_status,
     c.start_of_day,
-    c.end_of_day
+    c.end_of_day,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
 FROM session_placements sp
 JOIN candidates c 
     ON sp.candidate_id = c.id
-JOIN cohort_courses_offered cco
-    ON sp.course_id = cco.course_id
-WHERE 
-    cco.cohort_id = $1
-    AND cco.university_id = $2
-    AND c.university_id = $2
-    AND c.candidate_status = 'CURRENT'
+JOIN course_positions cp 
+    ON sp.course_id = cp.course_id
+JOIN students s 
+    ON cp.student_id = s.student_id
+WHERE s.student_id = $1
+  AND c.university_id = s.university_id
+  AND c.id = $2
+  AND c.candidate_status = 'PUBLISHED'
+  AND (sp.group_count <= 1 OR cp.position % sp.group_count = sp.group_idx)
+ORDER BY 
+    CASE sp.day
+        WHEN 'Monday' THEN 1
+        WHEN 'Tuesday' THEN 2
+        WHEN 'Wednesday' THEN 3
+        WHEN 'Thursday' THEN 4
+        WHEN 'Friday' THEN 5
+        WHEN 'Saturday' THEN 6
+        WHEN 'Sunday' THEN 7
+    END,
+    sp.session_time ASC
 `
 
-type GetCohortSessionsInCurrentTimetableParams struct {
-	CohortID     uuid.UUID
-	UniversityID uuid.UUID
+type GetStudentTimetableSessionsParams struct {
+	StudentID uuid.UUID
+	ID        uuid.UUID
 }
 
-type GetCohortSessionsInCurrentTimetableRow struct {
+type GetStudentTimetableSessionsRow struct {
 	SessionID       uuid.UUID
 	SessionIdx      int32
 	CourseID        uuid.UUID
@@ -1529,17 +3033,20 @@ type GetCohortSessionsInCurrentTimetableRow struct {
 	CandidateStatus string
 	StartOfDay      time.Time
 	EndOfDay        time.Time
+	SessionType     string
+	GroupIdx        int32
+	GroupCount      int32
 }
 
-func (q *Queries) GetCohortSessionsInCurrentTimetable(ctx context.Context, arg GetCohortSessionsInCurrentTimetableParams) ([]GetCohortSessionsInCurrentTimetableRow, error) {
-	rows, err := q.db.QueryContext(ctx, getCohortSessionsInCurrentTimetable, arg.CohortID, arg.UniversityID)
+func (q *Queries) GetStudentTimetableSessions(ctx context.Context, arg GetStudentTimetableSessionsParams) ([]GetStudentTimetableSessionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getStudentTimetableSessions, arg.StudentID, arg.ID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetCohortSessionsInCurrentTimetableRow
+	var items []GetStudentTimetableSessionsRow
 	for rows.Next() {
-		var i GetCohortSessionsInCurrentTimetableRow
+		var i GetStudentTimetableSessionsRow
 		if err := rows.Scan(
 			&i.SessionID,
 			&i.SessionIdx,
@@ -1552,6 +3059,9 @@ func (q *Queries) GetCohortSessionsInCurrentTimetable(ctx context.Context, arg G
 			&i.CandidateStatus,
 			&i.StartOfDay,
 			&i.EndOfDay,
+			&i.SessionType,
+			&i.GroupIdx,
+			&i.GroupCount,
 		); err != nil {
 			return nil, err
 		}
@@ -1566,8 +3076,146 @@ func (q *Queries) GetCohortSessionsInCurrentTimetable(ctx context.Context, arg G
 	return items, nil
 }
 
-const getStudentTimetableSessions = `-- name: GetStudentTimetableSessions :many
+const getTeachingDays = `-- name: GetTeachingDays :many
 SELECT 
+    university_id,
+    day,
+    start_time::text AS start_time,
+    end_time::text AS end_time
+FROM timetable_teaching_days
+WHERE university_id = $1
+`
+
+type GetTeachingDaysRow struct {
+	UniversityID uuid.UUID
+	Day          string
+	StartTime    string
+	EndTime      string
+}
+
+func (q *Queries) GetTeachingDays(ctx context.Context, universityID uuid.UUID) ([]GetTeachingDaysRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTeachingDays, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTeachingDaysRow
+	for rows.Next() {
+		var i GetTeachingDaysRow
+		if err := rows.Scan(
+			&i.UniversityID,
+			&i.Day,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTimetableConstraints = `-- name: GetTimetableConstraints :many
+SELECT university_id, constraint_name, enabled, weight, limit_value, created_at, updated_at, hard FROM timetable_constraints
+WHERE university_id = $1
+ORDER BY constraint_name
+`
+
+func (q *Queries) GetTimetableConstraints(ctx context.Context, universityID uuid.UUID) ([]TimetableConstraint, error) {
+	rows, err := q.db.QueryContext(ctx, getTimetableConstraints, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TimetableConstraint
+	for rows.Next() {
+		var i TimetableConstraint
+		if err := rows.Scan(
+			&i.UniversityID,
+			&i.ConstraintName,
+			&i.Enabled,
+			&i.Weight,
+			&i.LimitValue,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Hard,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTimetableSettings = `-- name: GetTimetableSettings :one
+SELECT university_id, population_size, generations, mutation_rate, tournament_size, elitism_fraction, seed, created_at, updated_at, slot_minutes, solver, stall_generations, time_budget_seconds, stop_when_feasible FROM timetable_settings
+WHERE university_id = $1
+`
+
+func (q *Queries) GetTimetableSettings(ctx context.Context, universityID uuid.UUID) (TimetableSetting, error) {
+	row := q.db.QueryRowContext(ctx, getTimetableSettings, universityID)
+	var i TimetableSetting
+	err := row.Scan(
+		&i.UniversityID,
+		&i.PopulationSize,
+		&i.Generations,
+		&i.MutationRate,
+		&i.TournamentSize,
+		&i.ElitismFraction,
+		&i.Seed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SlotMinutes,
+		&i.Solver,
+		&i.StallGenerations,
+		&i.TimeBudgetSeconds,
+		&i.StopWhenFeasible,
+	)
+	return i, err
+}
+
+const getUniversityCurrentSession = `-- name: GetUniversityCurrentSession :one
+SELECT current_session FROM universities
+WHERE university_id = $1
+`
+
+func (q *Queries) GetUniversityCurrentSession(ctx context.Context, universityID uuid.UUID) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getUniversityCurrentSession, universityID)
+	var current_session sql.NullString
+	err := row.Scan(&current_session)
+	return current_session, err
+}
+
+const getUserUniversity = `-- name: GetUserUniversity :one
+SELECT university_id FROM university_admin
+WHERE admin_id = $1 AND university_id IS NOT NULL
+UNION ALL
+SELECT lecturer_university_id FROM lecturers
+WHERE lecturer_id = $1 AND lecturer_university_id IS NOT NULL
+LIMIT 1
+`
+
+func (q *Queries) GetUserUniversity(ctx context.Context, adminID uuid.UUID) (uuid.NullUUID, error) {
+	row := q.db.QueryRowContext(ctx, getUserUniversity, adminID)
+	var university_id uuid.NullUUID
+	err := row.Scan(&university_id)
+	return university_id, err
+}
+
+const getVenueSessionsInCurrentTimetable = `-- name: GetVenueSessionsInCurrentTimetable :many
+SELECT
     sp.id AS session_id,
     sp.session_idx,
     sp.course_id,
@@ -1578,29 +3226,27 @@ SELECT
     c.fitness,
     c.candidate_status,
     c.start_of_day,
-    c.end_of_day
+    c.end_of_day,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
 FROM session_placements sp
-JOIN candidates c 
+JOIN candidates c
     ON sp.candidate_id = c.id
-JOIN student_courses_offered sco 
-    ON sp.course_id = sco.course_id
-JOIN students s 
-    ON sco.student_id = s.student_id
-WHERE s.student_id = $1
-  AND c.university_id = s.university_id
-  AND c.candidate_status = 'CURRENT'
-ORDER BY 
-    CASE sp.day
-        WHEN 'Monday' THEN 1
-        WHEN 'Tuesday' THEN 2
-        WHEN 'Wednesday' THEN 3
-        WHEN 'Thursday' THEN 4
-        WHEN 'Friday' THEN 5
-    END,
-    sp.session_time ASC
+WHERE
+    sp.venue_id = $1
+    AND c.university_id = $2
+    AND c.id = $3
+    AND c.candidate_status = 'PUBLISHED'
 `
 
-type GetStudentTimetableSessionsRow struct {
+type GetVenueSessionsInCurrentTimetableParams struct {
+	VenueID      uuid.UUID
+	UniversityID uuid.UUID
+	ID           uuid.UUID
+}
+
+type GetVenueSessionsInCurrentTimetableRow struct {
 	SessionID       uuid.UUID
 	SessionIdx      int32
 	CourseID        uuid.UUID
@@ -1612,17 +3258,20 @@ type GetStudentTimetableSessionsRow struct {
 	CandidateStatus string
 	StartOfDay      time.Time
 	EndOfDay        time.Time
+	SessionType     string
+	GroupIdx        int32
+	GroupCount      int32
 }
 
-func (q *Queries) GetStudentTimetableSessions(ctx context.Context, studentID uuid.UUID) ([]GetStudentTimetableSessionsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getStudentTimetableSessions, studentID)
+func (q *Queries) GetVenueSessionsInCurrentTimetable(ctx context.Context, arg GetVenueSessionsInCurrentTimetableParams) ([]GetVenueSessionsInCurrentTimetableRow, error) {
+	rows, err := q.db.QueryContext(ctx, getVenueSessionsInCurrentTimetable, arg.VenueID, arg.UniversityID, arg.ID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetStudentTimetableSessionsRow
+	var items []GetVenueSessionsInCurrentTimetableRow
 	for rows.Next() {
-		var i GetStudentTimetableSessionsRow
+		var i GetVenueSessionsInCurrentTimetableRow
 		if err := rows.Scan(
 			&i.SessionID,
 			&i.SessionIdx,
@@ -1635,6 +3284,60 @@ func (q *Queries) GetStudentTimetableSessions(ctx context.Context, studentID uui
 			&i.CandidateStatus,
 			&i.StartOfDay,
 			&i.EndOfDay,
+			&i.SessionType,
+			&i.GroupIdx,
+			&i.GroupCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVenueTravelTimes = `-- name: GetVenueTravelTimes :many
+SELECT
+    t.from_venue_id,
+    fv.venue_name AS from_venue_name,
+    t.to_venue_id,
+    tv.venue_name AS to_venue_name,
+    t.minutes
+FROM venue_travel_times t
+JOIN venues fv ON fv.venue_id = t.from_venue_id
+JOIN venues tv ON tv.venue_id = t.to_venue_id
+WHERE t.university_id = $1
+ORDER BY fv.venue_name, tv.venue_name
+`
+
+type GetVenueTravelTimesRow struct {
+	FromVenueID   uuid.UUID
+	FromVenueName string
+	ToVenueID     uuid.UUID
+	ToVenueName   string
+	Minutes       int32
+}
+
+func (q *Queries) GetVenueTravelTimes(ctx context.Context, universityID uuid.UUID) ([]GetVenueTravelTimesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getVenueTravelTimes, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetVenueTravelTimesRow
+	for rows.Next() {
+		var i GetVenueTravelTimesRow
+		if err := rows.Scan(
+			&i.FromVenueID,
+			&i.FromVenueName,
+			&i.ToVenueID,
+			&i.ToVenueName,
+			&i.Minutes,
 		); err != nil {
 			return nil, err
 		}
@@ -1749,24 +3452,100 @@ type InsertOtpParams struct {
 	UserType  string
 }
 
-func (q *Queries) InsertOtp(ctx context.Context, arg InsertOtpParams) (Otp, error) {
-	row := q.db.QueryRowContext(ctx, insertOtp,
-		arg.Otp,
-		arg.ExpiresAt,
-		arg.Email,
-		arg.UserType,
-	)
-	var i Otp
-	err := row.Scan(
-		&i.OtpID,
-		&i.Otp,
-		&i.ExpiresAt,
-		&i.Email,
-		&i.UserType,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+func (q *Queries) InsertOtp(ctx context.Context, arg InsertOtpParams) (Otp, error) {
+	row := q.db.QueryRowContext(ctx, insertOtp,
+		arg.Otp,
+		arg.ExpiresAt,
+		arg.Email,
+		arg.UserType,
+	)
+	var i Otp
+	err := row.Scan(
+		&i.OtpID,
+		&i.Otp,
+		&i.ExpiresAt,
+		&i.Email,
+		&i.UserType,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listCandidates = `-- name: ListCandidates :many
+SELECT id, fitness, university_id, candidate_status, start_of_day, end_of_day, created_at, updated_at, seed, population_size, generations, mutation_rate, tournament_size, elitism_fraction, repaired_from, published_at, published_by, solver, workers, generations_run, stop_reason, academic_session, semester FROM candidates
+WHERE university_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListCandidates(ctx context.Context, universityID uuid.UUID) ([]Candidate, error) {
+	rows, err := q.db.QueryContext(ctx, listCandidates, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Candidate
+	for rows.Next() {
+		var i Candidate
+		if err := rows.Scan(
+			&i.ID,
+			&i.Fitness,
+			&i.UniversityID,
+			&i.CandidateStatus,
+			&i.StartOfDay,
+			&i.EndOfDay,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Seed,
+			&i.PopulationSize,
+			&i.Generations,
+			&i.MutationRate,
+			&i.TournamentSize,
+			&i.ElitismFraction,
+			&i.RepairedFrom,
+			&i.PublishedAt,
+			&i.PublishedBy,
+			&i.Solver,
+			&i.Workers,
+			&i.GenerationsRun,
+			&i.StopReason,
+			&i.AcademicSession,
+			&i.Semester,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const publishCandidate = `-- name: PublishCandidate :execrows
+UPDATE candidates
+SET candidate_status = 'PUBLISHED',
+    published_at = NOW(),
+    published_by = $3,
+    updated_at = NOW()
+WHERE id = $1 AND university_id = $2
+`
+
+type PublishCandidateParams struct {
+	ID           uuid.UUID
+	UniversityID uuid.UUID
+	PublishedBy  uuid.NullUUID
+}
+
+func (q *Queries) PublishCandidate(ctx context.Context, arg PublishCandidateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, publishCandidate, arg.ID, arg.UniversityID, arg.PublishedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const registerLecturer = `-- name: RegisterLecturer :one
@@ -2035,24 +3814,6 @@ func (q *Queries) RequestLecturerConfirmation(ctx context.Context, arg RequestLe
 	return i, err
 }
 
-const restoreCurrentCandidate = `-- name: RestoreCurrentCandidate :exec
-UPDATE candidates AS c
-SET candidate_status = 'CURRENT',
-    updated_at = NOW()
-WHERE c.id = (
-  SELECT id
-  FROM candidates
-  WHERE candidates.university_id = $1
-  ORDER BY created_at DESC
-  LIMIT 1
-)
-`
-
-func (q *Queries) RestoreCurrentCandidate(ctx context.Context, universityID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, restoreCurrentCandidate, universityID)
-	return err
-}
-
 const retrieveAdmin = `-- name: RetrieveAdmin :one
 SELECT 
     admin_first_name,
@@ -2109,7 +3870,7 @@ func (q *Queries) RetrieveAdminEmail(ctx context.Context, adminEmail string) (Re
 }
 
 const retrieveAllCohorts = `-- name: RetrieveAllCohorts :many
-SELECT cohort_id, cohort_name, cohort_level, cohort_department_id, cohort_faculty_id, cohort_university_id, created_at, updated_at FROM cohorts
+SELECT cohort_id, cohort_name, cohort_level, cohort_department_id, cohort_faculty_id, cohort_university_id, created_at, updated_at, cohort_size FROM cohorts
 WHERE cohort_university_id = $1
 `
 
@@ -2131,6 +3892,7 @@ func (q *Queries) RetrieveAllCohorts(ctx context.Context, cohortUniversityID uui
 			&i.CohortUniversityID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CohortSize,
 		); err != nil {
 			return nil, err
 		}
@@ -2174,27 +3936,302 @@ type RetrieveAllCoursesRow struct {
 	Semester         string
 }
 
-func (q *Queries) RetrieveAllCourses(ctx context.Context, universityID uuid.UUID) ([]RetrieveAllCoursesRow, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveAllCourses, universityID)
+func (q *Queries) RetrieveAllCourses(ctx context.Context, universityID uuid.UUID) ([]RetrieveAllCoursesRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveAllCourses, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveAllCoursesRow
+	for rows.Next() {
+		var i RetrieveAllCoursesRow
+		if err := rows.Scan(
+			&i.CourseID,
+			&i.CourseCode,
+			&i.CourseTitle,
+			&i.CourseCreditUnit,
+			&i.CourseDuration,
+			&i.DepartmentID,
+			&i.UniversityID,
+			&i.LecturerID,
+			&i.SessionsPerWeek,
+			&i.Semester,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveAllCoursesAndTheirVenueIds = `-- name: RetrieveAllCoursesAndTheirVenueIds :many
+SELECT
+    c.course_id,
+    c.course_code,
+    c.course_title,
+    c.course_credit_unit,
+    c.course_duration,
+    c.department_id,
+    c.university_id,
+    c.lecturer_id,
+    c.sessions_per_week,
+    c.level,
+    c.semester,
+    c.lecturer_mode,
+    c.max_group_size,
+    d.faculty_id,
+    cpv.venue_id
+FROM courses c
+INNER JOIN
+    departments d
+ON
+    d.department_id = c.department_id
+LEFT JOIN 
+    courses_possible_venues cpv 
+ON 
+    cpv.course_id = c.course_id
+WHERE c.university_id = $1 AND (c.semester = $2 OR $2 = '')
+`
+
+type RetrieveAllCoursesAndTheirVenueIdsParams struct {
+	UniversityID uuid.UUID
+	Semester     string
+}
+
+type RetrieveAllCoursesAndTheirVenueIdsRow struct {
+	CourseID         uuid.UUID
+	CourseCode       string
+	CourseTitle      string
+	CourseCreditUnit int32
+	CourseDuration   int32
+	DepartmentID     uuid.UUID
+	UniversityID     uuid.UUID
+	LecturerID       uuid.NullUUID
+	SessionsPerWeek  int32
+	Level            int32
+	Semester         string
+	LecturerMode     string
+	MaxGroupSize     sql.NullInt32
+	FacultyID        uuid.UUID
+	VenueID          uuid.NullUUID
+}
+
+func (q *Queries) RetrieveAllCoursesAndTheirVenueIds(ctx context.Context, arg RetrieveAllCoursesAndTheirVenueIdsParams) ([]RetrieveAllCoursesAndTheirVenueIdsRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveAllCoursesAndTheirVenueIds, arg.UniversityID, arg.Semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveAllCoursesAndTheirVenueIdsRow
+	for rows.Next() {
+		var i RetrieveAllCoursesAndTheirVenueIdsRow
+		if err := rows.Scan(
+			&i.CourseID,
+			&i.CourseCode,
+			&i.CourseTitle,
+			&i.CourseCreditUnit,
+			&i.CourseDuration,
+			&i.DepartmentID,
+			&i.UniversityID,
+			&i.LecturerID,
+			&i.SessionsPerWeek,
+			&i.Level,
+			&i.Semester,
+			&i.LecturerMode,
+			&i.MaxGroupSize,
+			&i.FacultyID,
+			&i.VenueID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveAllUniversities = `-- name: RetrieveAllUniversities :many
+SELECT university_id, university_name, university_logo, university_abbr, email, website, phone_number, university_addr, current_session, created_at, updated_at FROM universities
+`
+
+func (q *Queries) RetrieveAllUniversities(ctx context.Context) ([]University, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveAllUniversities)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []University
+	for rows.Next() {
+		var i University
+		if err := rows.Scan(
+			&i.UniversityID,
+			&i.UniversityName,
+			&i.UniversityLogo,
+			&i.UniversityAbbr,
+			&i.Email,
+			&i.Website,
+			&i.PhoneNumber,
+			&i.UniversityAddr,
+			&i.CurrentSession,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveAllVenues = `-- name: RetrieveAllVenues :many
+SELECT 
+    venue_id,
+    venue_name,
+    capacity,
+    location,
+    venue_latitude,
+    venue_longitude,
+    venue_kind,
+    is_active,
+    is_restricted
+FROM venues
+WHERE university_id = $1
+`
+
+type RetrieveAllVenuesRow struct {
+	VenueID        uuid.UUID
+	VenueName      string
+	Capacity       int32
+	Location       sql.NullString
+	VenueLatitude  sql.NullFloat64
+	VenueLongitude sql.NullFloat64
+	VenueKind      sql.NullString
+	IsActive       sql.NullBool
+	IsRestricted   bool
+}
+
+func (q *Queries) RetrieveAllVenues(ctx context.Context, universityID uuid.UUID) ([]RetrieveAllVenuesRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveAllVenues, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveAllVenuesRow
+	for rows.Next() {
+		var i RetrieveAllVenuesRow
+		if err := rows.Scan(
+			&i.VenueID,
+			&i.VenueName,
+			&i.Capacity,
+			&i.Location,
+			&i.VenueLatitude,
+			&i.VenueLongitude,
+			&i.VenueKind,
+			&i.IsActive,
+			&i.IsRestricted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveCohortStudentCounts = `-- name: RetrieveCohortStudentCounts :many
+SELECT 
+    c.cohort_id,
+    COUNT(s.student_id)::int AS student_count
+FROM cohorts c
+LEFT JOIN students s
+ON s.student_department_id = c.cohort_department_id
+    AND s.student_level = c.cohort_level
+WHERE c.cohort_university_id = $1
+GROUP BY c.cohort_id
+`
+
+type RetrieveCohortStudentCountsRow struct {
+	CohortID     uuid.UUID
+	StudentCount int32
+}
+
+func (q *Queries) RetrieveCohortStudentCounts(ctx context.Context, cohortUniversityID uuid.UUID) ([]RetrieveCohortStudentCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveCohortStudentCounts, cohortUniversityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveCohortStudentCountsRow
+	for rows.Next() {
+		var i RetrieveCohortStudentCountsRow
+		if err := rows.Scan(&i.CohortID, &i.StudentCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveCohortsForAllCourses = `-- name: RetrieveCohortsForAllCourses :many
+SELECT 
+    cco.cohort_id,
+    cco.course_id,
+    cco.university_id
+FROM cohort_courses_offered cco
+INNER JOIN courses c
+ON c.course_id = cco.course_id
+WHERE cco.university_id = $1 AND (c.semester = $2 OR $2 = '')
+`
+
+type RetrieveCohortsForAllCoursesParams struct {
+	UniversityID uuid.UUID
+	Semester     string
+}
+
+type RetrieveCohortsForAllCoursesRow struct {
+	CohortID     uuid.UUID
+	CourseID     uuid.UUID
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) RetrieveCohortsForAllCourses(ctx context.Context, arg RetrieveCohortsForAllCoursesParams) ([]RetrieveCohortsForAllCoursesRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveCohortsForAllCourses, arg.UniversityID, arg.Semester)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []RetrieveAllCoursesRow
+	var items []RetrieveCohortsForAllCoursesRow
 	for rows.Next() {
-		var i RetrieveAllCoursesRow
-		if err := rows.Scan(
-			&i.CourseID,
-			&i.CourseCode,
-			&i.CourseTitle,
-			&i.CourseCreditUnit,
-			&i.CourseDuration,
-			&i.DepartmentID,
-			&i.UniversityID,
-			&i.LecturerID,
-			&i.SessionsPerWeek,
-			&i.Semester,
-		); err != nil {
+		var i RetrieveCohortsForAllCoursesRow
+		if err := rows.Scan(&i.CohortID, &i.CourseID, &i.UniversityID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2208,65 +4245,49 @@ func (q *Queries) RetrieveAllCourses(ctx context.Context, universityID uuid.UUID
 	return items, nil
 }
 
-const retrieveAllCoursesAndTheirVenueIds = `-- name: RetrieveAllCoursesAndTheirVenueIds :many
+const retrieveCourseComponentsForUni = `-- name: RetrieveCourseComponentsForUni :many
 SELECT
-    c.course_id,
-    c.course_code,
-    c.course_title,
-    c.course_credit_unit,
-    c.course_duration,
-    c.department_id,
-    c.university_id,
-    c.lecturer_id,
-    c.sessions_per_week,
-    c.level,
-    c.semester,
-    cpv.venue_id
-FROM courses c
-INNER JOIN 
-    courses_possible_venues cpv 
-ON 
-    cpv.course_id = c.course_id
-WHERE c.university_id = $1
+    cc.course_id,
+    cc.session_type,
+    cc.duration,
+    cc.sessions_per_week,
+    cc.venue_kind,
+    cc.max_group_size
+FROM course_components cc
+JOIN courses c ON c.course_id = cc.course_id
+WHERE cc.university_id = $1 AND (c.semester = $2 OR $2 = '')
 `
 
-type RetrieveAllCoursesAndTheirVenueIdsRow struct {
-	CourseID         uuid.UUID
-	CourseCode       string
-	CourseTitle      string
-	CourseCreditUnit int32
-	CourseDuration   int32
-	DepartmentID     uuid.UUID
-	UniversityID     uuid.UUID
-	LecturerID       uuid.NullUUID
-	SessionsPerWeek  int32
-	Level            int32
-	Semester         string
-	VenueID          uuid.UUID
+type RetrieveCourseComponentsForUniParams struct {
+	UniversityID uuid.UUID
+	Semester     string
+}
+
+type RetrieveCourseComponentsForUniRow struct {
+	CourseID        uuid.UUID
+	SessionType     string
+	Duration        int32
+	SessionsPerWeek int32
+	VenueKind       sql.NullString
+	MaxGroupSize    sql.NullInt32
 }
 
-func (q *Queries) RetrieveAllCoursesAndTheirVenueIds(ctx context.Context, universityID uuid.UUID) ([]RetrieveAllCoursesAndTheirVenueIdsRow, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveAllCoursesAndTheirVenueIds, universityID)
+func (q *Queries) RetrieveCourseComponentsForUni(ctx context.Context, arg RetrieveCourseComponentsForUniParams) ([]RetrieveCourseComponentsForUniRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveCourseComponentsForUni, arg.UniversityID, arg.Semester)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []RetrieveAllCoursesAndTheirVenueIdsRow
+	var items []RetrieveCourseComponentsForUniRow
 	for rows.Next() {
-		var i RetrieveAllCoursesAndTheirVenueIdsRow
+		var i RetrieveCourseComponentsForUniRow
 		if err := rows.Scan(
 			&i.CourseID,
-			&i.CourseCode,
-			&i.CourseTitle,
-			&i.CourseCreditUnit,
-			&i.CourseDuration,
-			&i.DepartmentID,
-			&i.UniversityID,
-			&i.LecturerID,
+			&i.SessionType,
+			&i.Duration,
 			&i.SessionsPerWeek,
-			&i.Level,
-			&i.Semester,
-			&i.VenueID,
+			&i.VenueKind,
+			&i.MaxGroupSize,
 		); err != nil {
 			return nil, err
 		}
@@ -2281,32 +4302,36 @@ func (q *Queries) RetrieveAllCoursesAndTheirVenueIds(ctx context.Context, univer
 	return items, nil
 }
 
-const retrieveAllUniversities = `-- name: RetrieveAllUniversities :many
-SELECT university_id, university_name, university_logo, university_abbr, email, website, phone_number, university_addr, current_session, created_at, updated_at FROM universities
+const retrieveCourseLecturersForUni = `-- name: RetrieveCourseLecturersForUni :many
+SELECT 
+    cl.course_id,
+    cl.lecturer_id
+FROM courses_lecturers cl
+INNER JOIN courses c
+ON c.course_id = cl.course_id
+WHERE c.university_id = $1 AND (c.semester = $2 OR $2 = '')
 `
 
-func (q *Queries) RetrieveAllUniversities(ctx context.Context) ([]University, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveAllUniversities)
+type RetrieveCourseLecturersForUniParams struct {
+	UniversityID uuid.UUID
+	Semester     string
+}
+
+type RetrieveCourseLecturersForUniRow struct {
+	CourseID   uuid.UUID
+	LecturerID uuid.UUID
+}
+
+func (q *Queries) RetrieveCourseLecturersForUni(ctx context.Context, arg RetrieveCourseLecturersForUniParams) ([]RetrieveCourseLecturersForUniRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveCourseLecturersForUni, arg.UniversityID, arg.Semester)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []University
+	var items []RetrieveCourseLecturersForUniRow
 	for rows.Next() {
-		var i University
-		if err := rows.Scan(
-			&i.UniversityID,
-			&i.UniversityName,
-			&i.UniversityLogo,
-			&i.UniversityAbbr,
-			&i.Email,
-			&i.Website,
-			&i.PhoneNumber,
-			&i.UniversityAddr,
-			&i.CurrentSession,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var i RetrieveCourseLecturersForUniRow
+		if err := rows.Scan(&i.CourseID, &i.LecturerID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2320,37 +4345,40 @@ func (q *Queries) RetrieveAllUniversities(ctx context.Context) ([]University, er
 	return items, nil
 }
 
-const retrieveAllVenues = `-- name: RetrieveAllVenues :many
-SELECT 
-    venue_id,
-    venue_name,
-    capacity,
-    location
-FROM venues
-WHERE university_id = $1
+const retrieveCourseRequiredFeaturesForUni = `-- name: RetrieveCourseRequiredFeaturesForUni :many
+SELECT
+    crf.course_id,
+    crf.session_type,
+    crf.feature
+FROM course_required_features crf
+JOIN courses c ON c.course_id = crf.course_id
+WHERE crf.university_id = $1 AND (c.semester = $2 OR $2 = '')
 `
 
-type RetrieveAllVenuesRow struct {
-	VenueID   uuid.UUID
-	VenueName string
-	Capacity  int32
-	Location  sql.NullString
+type RetrieveCourseRequiredFeaturesForUniParams struct {
+	UniversityID uuid.UUID
+	Semester     string
 }
 
-func (q *Queries) RetrieveAllVenues(ctx context.Context, universityID uuid.UUID) ([]RetrieveAllVenuesRow, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveAllVenues, universityID)
+type RetrieveCourseRequiredFeaturesForUniRow struct {
+	CourseID    uuid.UUID
+	SessionType sql.NullString
+	Feature     string
+}
+
+func (q *Queries) RetrieveCourseRequiredFeaturesForUni(ctx context.Context, arg RetrieveCourseRequiredFeaturesForUniParams) ([]RetrieveCourseRequiredFeaturesForUniRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveCourseRequiredFeaturesForUni, arg.UniversityID, arg.Semester)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []RetrieveAllVenuesRow
+	var items []RetrieveCourseRequiredFeaturesForUniRow
 	for rows.Next() {
-		var i RetrieveAllVenuesRow
+		var i RetrieveCourseRequiredFeaturesForUniRow
 		if err := rows.Scan(
-			&i.VenueID,
-			&i.VenueName,
-			&i.Capacity,
-			&i.Location,
+			&i.CourseID,
+			&i.SessionType,
+			&i.Feature,
 		); err != nil {
 			return nil, err
 		}
@@ -2365,31 +4393,34 @@ func (q *Queries) RetrieveAllVenues(ctx context.Context, universityID uuid.UUID)
 	return items, nil
 }
 
-const retrieveCohortsForAllCourses = `-- name: RetrieveCohortsForAllCourses :many
-SELECT 
-    cohort_id,
-    course_id,
-    university_id
-FROM cohort_courses_offered
-WHERE university_id = $1
+const retrieveCourseStudentCounts = `-- name: RetrieveCourseStudentCounts :many
+SELECT
+    sco.course_id,
+    COUNT(st.student_id)::int AS student_count
+FROM student_courses_offered sco
+JOIN students st
+    ON st.student_id = sco.student_id
+JOIN courses c
+    ON c.course_id = sco.course_id
+WHERE c.university_id = $1
+GROUP BY sco.course_id
 `
 
-type RetrieveCohortsForAllCoursesRow struct {
-	CohortID     uuid.UUID
+type RetrieveCourseStudentCountsRow struct {
 	CourseID     uuid.UUID
-	UniversityID uuid.UUID
+	StudentCount int32
 }
 
-func (q *Queries) RetrieveCohortsForAllCourses(ctx context.Context, universityID uuid.UUID) ([]RetrieveCohortsForAllCoursesRow, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveCohortsForAllCourses, universityID)
+func (q *Queries) RetrieveCourseStudentCounts(ctx context.Context, universityID uuid.UUID) ([]RetrieveCourseStudentCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveCourseStudentCounts, universityID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []RetrieveCohortsForAllCoursesRow
+	var items []RetrieveCourseStudentCountsRow
 	for rows.Next() {
-		var i RetrieveCohortsForAllCoursesRow
-		if err := rows.Scan(&i.CohortID, &i.CourseID, &i.UniversityID); err != nil {
+		var i RetrieveCourseStudentCountsRow
+		if err := rows.Scan(&i.CourseID, &i.StudentCount); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2521,6 +4552,74 @@ func (q *Queries) RetrieveCoursesForADepartment(ctx context.Context, arg Retriev
 	return items, nil
 }
 
+const retrieveCoursesForSemester = `-- name: RetrieveCoursesForSemester :many
+SELECT
+    course_id,
+    course_code,
+    course_title,
+    course_credit_unit,
+    course_duration,
+    department_id,
+    university_id,
+    lecturer_id,
+    sessions_per_week,
+    semester
+FROM courses
+WHERE university_id = $1 AND (semester = $2 OR $2 = '')
+`
+
+type RetrieveCoursesForSemesterParams struct {
+	UniversityID uuid.UUID
+	Semester     string
+}
+
+type RetrieveCoursesForSemesterRow struct {
+	CourseID         uuid.UUID
+	CourseCode       string
+	CourseTitle      string
+	CourseCreditUnit int32
+	CourseDuration   int32
+	DepartmentID     uuid.UUID
+	UniversityID     uuid.UUID
+	LecturerID       uuid.NullUUID
+	SessionsPerWeek  int32
+	Semester         string
+}
+
+func (q *Queries) RetrieveCoursesForSemester(ctx context.Context, arg RetrieveCoursesForSemesterParams) ([]RetrieveCoursesForSemesterRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveCoursesForSemester, arg.UniversityID, arg.Semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveCoursesForSemesterRow
+	for rows.Next() {
+		var i RetrieveCoursesForSemesterRow
+		if err := rows.Scan(
+			&i.CourseID,
+			&i.CourseCode,
+			&i.CourseTitle,
+			&i.CourseCreditUnit,
+			&i.CourseDuration,
+			&i.DepartmentID,
+			&i.UniversityID,
+			&i.LecturerID,
+			&i.SessionsPerWeek,
+			&i.Semester,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const retrieveDean = `-- name: RetrieveDean :one
 SELECT
     lecturer_id,
@@ -2553,35 +4652,120 @@ func (q *Queries) RetrieveDean(ctx context.Context, deanID uuid.UUID) (RetrieveD
 	return i, err
 }
 
-const retrieveDeptsForAFaculty = `-- name: RetrieveDeptsForAFaculty :many
-SELECT department_id, department_name, department_code, faculty_id, university_id, number_of_levels, created_at, updated_at FROM departments
-WHERE faculty_id = $1 AND university_id = $2
+const retrieveDeptsForAFaculty = `-- name: RetrieveDeptsForAFaculty :many
+SELECT department_id, department_name, department_code, faculty_id, university_id, number_of_levels, created_at, updated_at FROM departments
+WHERE faculty_id = $1 AND university_id = $2
+`
+
+type RetrieveDeptsForAFacultyParams struct {
+	FacultyID    uuid.UUID
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) RetrieveDeptsForAFaculty(ctx context.Context, arg RetrieveDeptsForAFacultyParams) ([]Department, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveDeptsForAFaculty, arg.FacultyID, arg.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Department
+	for rows.Next() {
+		var i Department
+		if err := rows.Scan(
+			&i.DepartmentID,
+			&i.DepartmentName,
+			&i.DepartmentCode,
+			&i.FacultyID,
+			&i.UniversityID,
+			&i.NumberOfLevels,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveElectiveGroupCourses = `-- name: RetrieveElectiveGroupCourses :many
+SELECT
+    eg.elective_group_id,
+    eg.cohort_id,
+    egc.course_id
+FROM elective_groups eg
+JOIN elective_group_courses egc ON egc.elective_group_id = eg.elective_group_id
+JOIN courses c ON c.course_id = egc.course_id
+WHERE eg.university_id = $1 AND (c.semester = $2 OR $2 = '')
+`
+
+type RetrieveElectiveGroupCoursesParams struct {
+	UniversityID uuid.UUID
+	Semester     string
+}
+
+type RetrieveElectiveGroupCoursesRow struct {
+	ElectiveGroupID uuid.UUID
+	CohortID        uuid.UUID
+	CourseID        uuid.UUID
+}
+
+func (q *Queries) RetrieveElectiveGroupCourses(ctx context.Context, arg RetrieveElectiveGroupCoursesParams) ([]RetrieveElectiveGroupCoursesRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveElectiveGroupCourses, arg.UniversityID, arg.Semester)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveElectiveGroupCoursesRow
+	for rows.Next() {
+		var i RetrieveElectiveGroupCoursesRow
+		if err := rows.Scan(&i.ElectiveGroupID, &i.CohortID, &i.CourseID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveElectiveStudentOverlaps = `-- name: RetrieveElectiveStudentOverlaps :many
+SELECT DISTINCT
+    a.course_id AS first_course_id,
+    b.course_id AS second_course_id
+FROM student_courses_offered a
+JOIN student_courses_offered b ON b.student_id = a.student_id AND a.course_id < b.course_id
+JOIN elective_group_courses ga ON ga.course_id = a.course_id
+JOIN elective_group_courses gb ON gb.course_id = b.course_id AND gb.elective_group_id = ga.elective_group_id
+JOIN elective_groups eg ON eg.elective_group_id = ga.elective_group_id
+WHERE eg.university_id = $1
 `
 
-type RetrieveDeptsForAFacultyParams struct {
-	FacultyID    uuid.UUID
-	UniversityID uuid.UUID
+type RetrieveElectiveStudentOverlapsRow struct {
+	FirstCourseID  uuid.UUID
+	SecondCourseID uuid.UUID
 }
 
-func (q *Queries) RetrieveDeptsForAFaculty(ctx context.Context, arg RetrieveDeptsForAFacultyParams) ([]Department, error) {
-	rows, err := q.db.QueryContext(ctx, retrieveDeptsForAFaculty, arg.FacultyID, arg.UniversityID)
+func (q *Queries) RetrieveElectiveStudentOverlaps(ctx context.Context, universityID uuid.UUID) ([]RetrieveElectiveStudentOverlapsRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveElectiveStudentOverlaps, universityID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Department
+	var items []RetrieveElectiveStudentOverlapsRow
 	for rows.Next() {
-		var i Department
-		if err := rows.Scan(
-			&i.DepartmentID,
-			&i.DepartmentName,
-			&i.DepartmentCode,
-			&i.FacultyID,
-			&i.UniversityID,
-			&i.NumberOfLevels,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var i RetrieveElectiveStudentOverlapsRow
+		if err := rows.Scan(&i.FirstCourseID, &i.SecondCourseID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -3144,6 +5328,94 @@ func (q *Queries) RetrieveUniversitiesWithLimit(ctx context.Context, limit int32
 	return items, nil
 }
 
+const retrieveVenueFeaturesForUni = `-- name: RetrieveVenueFeaturesForUni :many
+SELECT
+    venue_id,
+    feature
+FROM venue_features
+WHERE university_id = $1
+`
+
+type RetrieveVenueFeaturesForUniRow struct {
+	VenueID uuid.UUID
+	Feature string
+}
+
+func (q *Queries) RetrieveVenueFeaturesForUni(ctx context.Context, universityID uuid.UUID) ([]RetrieveVenueFeaturesForUniRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveVenueFeaturesForUni, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveVenueFeaturesForUniRow
+	for rows.Next() {
+		var i RetrieveVenueFeaturesForUniRow
+		if err := rows.Scan(
+			&i.VenueID,
+			&i.Feature,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const retrieveVenueOwnersForUni = `-- name: RetrieveVenueOwnersForUni :many
+SELECT
+    venue_id,
+    faculty_id,
+    NULL::uuid AS department_id
+FROM faculty_venues
+WHERE faculty_venues.university_id = $1
+UNION ALL
+SELECT
+    venue_id,
+    NULL::uuid AS faculty_id,
+    department_id
+FROM dept_venues
+WHERE dept_venues.university_id = $1
+`
+
+type RetrieveVenueOwnersForUniRow struct {
+	VenueID      uuid.UUID
+	FacultyID    uuid.NullUUID
+	DepartmentID uuid.NullUUID
+}
+
+func (q *Queries) RetrieveVenueOwnersForUni(ctx context.Context, universityID uuid.UUID) ([]RetrieveVenueOwnersForUniRow, error) {
+	rows, err := q.db.QueryContext(ctx, retrieveVenueOwnersForUni, universityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RetrieveVenueOwnersForUniRow
+	for rows.Next() {
+		var i RetrieveVenueOwnersForUniRow
+		if err := rows.Scan(
+			&i.VenueID,
+			&i.FacultyID,
+			&i.DepartmentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const revokeRefreshToken = `-- name: RevokeRefreshToken :one
 UPDATE refresh_tokens
 SET is_revoked = TRUE
@@ -3166,6 +5438,53 @@ func (q *Queries) RevokeRefreshToken(ctx context.Context, userID uuid.UUID) (Ref
 	return i, err
 }
 
+const setCohortSize = `-- name: SetCohortSize :one
+UPDATE cohorts
+SET cohort_size = $1,
+    updated_at = NOW()
+WHERE cohort_id = $2
+RETURNING cohort_id, cohort_name, cohort_level, cohort_department_id, cohort_faculty_id, cohort_university_id, created_at, updated_at, cohort_size
+`
+
+type SetCohortSizeParams struct {
+	CohortSize sql.NullInt32
+	CohortID   uuid.UUID
+}
+
+func (q *Queries) SetCohortSize(ctx context.Context, arg SetCohortSizeParams) (Cohort, error) {
+	row := q.db.QueryRowContext(ctx, setCohortSize, arg.CohortSize, arg.CohortID)
+	var i Cohort
+	err := row.Scan(
+		&i.CohortID,
+		&i.CohortName,
+		&i.CohortLevel,
+		&i.CohortDepartmentID,
+		&i.CohortFacultyID,
+		&i.CohortUniversityID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CohortSize,
+	)
+	return i, err
+}
+
+const setCourseLecturerMode = `-- name: SetCourseLecturerMode :exec
+UPDATE courses
+SET lecturer_mode = $1,
+    updated_at = NOW()
+WHERE course_id = $2
+`
+
+type SetCourseLecturerModeParams struct {
+	LecturerMode string
+	CourseID     uuid.UUID
+}
+
+func (q *Queries) SetCourseLecturerMode(ctx context.Context, arg SetCourseLecturerModeParams) error {
+	_, err := q.db.ExecContext(ctx, setCourseLecturerMode, arg.LecturerMode, arg.CourseID)
+	return err
+}
+
 const setCourseLecturers = `-- name: SetCourseLecturers :one
 INSERT INTO courses_lecturers(
     course_id,lecturer_id
@@ -3192,6 +5511,23 @@ func (q *Queries) SetCourseLecturers(ctx context.Context, arg SetCourseLecturers
 	return i, err
 }
 
+const setCourseMaxGroupSize = `-- name: SetCourseMaxGroupSize :exec
+UPDATE courses
+SET max_group_size = $1,
+    updated_at = NOW()
+WHERE course_id = $2
+`
+
+type SetCourseMaxGroupSizeParams struct {
+	MaxGroupSize sql.NullInt32
+	CourseID     uuid.UUID
+}
+
+func (q *Queries) SetCourseMaxGroupSize(ctx context.Context, arg SetCourseMaxGroupSizeParams) error {
+	_, err := q.db.ExecContext(ctx, setCourseMaxGroupSize, arg.MaxGroupSize, arg.CourseID)
+	return err
+}
+
 const setCoursePossibleVenue = `-- name: SetCoursePossibleVenue :exec
 INSERT INTO courses_possible_venues(
     course_id,
@@ -3281,6 +5617,61 @@ func (q *Queries) SetStudentCourse(ctx context.Context, arg SetStudentCoursePara
 	return i, err
 }
 
+const setTimetableSlotMinutes = `-- name: SetTimetableSlotMinutes :exec
+INSERT INTO timetable_settings(
+    university_id,slot_minutes
+)VALUES($1,$2)
+ON CONFLICT (university_id) DO UPDATE
+SET slot_minutes = EXCLUDED.slot_minutes,
+    updated_at = NOW()
+`
+
+type SetTimetableSlotMinutesParams struct {
+	UniversityID uuid.UUID
+	SlotMinutes  int32
+}
+
+func (q *Queries) SetTimetableSlotMinutes(ctx context.Context, arg SetTimetableSlotMinutesParams) error {
+	_, err := q.db.ExecContext(ctx, setTimetableSlotMinutes, arg.UniversityID, arg.SlotMinutes)
+	return err
+}
+
+const setVenueKind = `-- name: SetVenueKind :exec
+UPDATE venues
+SET venue_kind = $1,
+    updated_at = NOW()
+WHERE venue_id = $2 AND university_id = $3
+`
+
+type SetVenueKindParams struct {
+	VenueKind    sql.NullString
+	VenueID      uuid.UUID
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) SetVenueKind(ctx context.Context, arg SetVenueKindParams) error {
+	_, err := q.db.ExecContext(ctx, setVenueKind, arg.VenueKind, arg.VenueID, arg.UniversityID)
+	return err
+}
+
+const setVenueRestricted = `-- name: SetVenueRestricted :exec
+UPDATE venues
+SET is_restricted = $1,
+    updated_at = NOW()
+WHERE venue_id = $2 AND university_id = $3
+`
+
+type SetVenueRestrictedParams struct {
+	IsRestricted bool
+	VenueID      uuid.UUID
+	UniversityID uuid.UUID
+}
+
+func (q *Queries) SetVenueRestricted(ctx context.Context, arg SetVenueRestrictedParams) error {
+	_, err := q.db.ExecContext(ctx, setVenueRestricted, arg.IsRestricted, arg.VenueID, arg.UniversityID)
+	return err
+}
+
 const updateAdminInfo = `-- name: UpdateAdminInfo :one
 UPDATE university_admin
 SET admin_middle_name = $1, admin_phone_number = $2, admin_staff_card = $3, admin_number = $4, university_id = $5
@@ -3324,6 +5715,24 @@ func (q *Queries) UpdateAdminInfo(ctx context.Context, arg UpdateAdminInfoParams
 	return i, err
 }
 
+const updateCandidateStatus = `-- name: UpdateCandidateStatus :exec
+UPDATE candidates
+SET candidate_status = $3,
+    updated_at = NOW()
+WHERE id = $1 AND university_id = $2
+`
+
+type UpdateCandidateStatusParams struct {
+	ID              uuid.UUID
+	UniversityID    uuid.UUID
+	CandidateStatus string
+}
+
+func (q *Queries) UpdateCandidateStatus(ctx context.Context, arg UpdateCandidateStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateCandidateStatus, arg.ID, arg.UniversityID, arg.CandidateStatus)
+	return err
+}
+
 const updateCohort = `-- name: UpdateCohort :one
 UPDATE cohorts
 SET
@@ -3332,7 +5741,7 @@ SET
     cohort_faculty_id = $3,
     cohort_university_id = $4
 WHERE cohort_id = $5
-RETURNING cohort_id, cohort_name, cohort_level, cohort_department_id, cohort_faculty_id, cohort_university_id, created_at, updated_at
+RETURNING cohort_id, cohort_name, cohort_level, cohort_department_id, cohort_faculty_id, cohort_university_id, created_at, updated_at, cohort_size
 `
 
 type UpdateCohortParams struct {
@@ -3361,6 +5770,7 @@ func (q *Queries) UpdateCohort(ctx context.Context, arg UpdateCohortParams) (Coh
 		&i.CohortUniversityID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CohortSize,
 	)
 	return i, err
 }
@@ -3377,7 +5787,7 @@ SET
     level = $7,
     semester = $8
 WHERE course_id = $9
-RETURNING course_id, course_code, course_title, course_credit_unit, course_duration, department_id, university_id, lecturer_id, sessions_per_week, level, semester, created_at, updated_at
+RETURNING course_id, course_code, course_title, course_credit_unit, course_duration, department_id, university_id, lecturer_id, sessions_per_week, level, semester, created_at, updated_at, lecturer_mode
 `
 
 type UpdateCourseParams struct {
@@ -3419,6 +5829,8 @@ func (q *Queries) UpdateCourse(ctx context.Context, arg UpdateCourseParams) (Cou
 		&i.Semester,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.LecturerMode,
+		&i.MaxGroupSize,
 	)
 	return i, err
 }
@@ -3828,3 +6240,240 @@ func (q *Queries) UpdateUniversity(ctx context.Context, arg UpdateUniversityPara
 	)
 	return i, err
 }
+
+const upsertCandidateReview = `-- name: UpsertCandidateReview :one
+INSERT INTO candidate_reviews(
+    candidate_id,university_id,reviewer_id,reviewer_role,faculty_id,department_id,decision,comment
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8)
+ON CONFLICT (candidate_id,reviewer_id,reviewer_role)
+DO UPDATE SET
+    faculty_id = EXCLUDED.faculty_id,
+    department_id = EXCLUDED.department_id,
+    decision = EXCLUDED.decision,
+    comment = EXCLUDED.comment,
+    updated_at = NOW()
+RETURNING review_id
+`
+
+type UpsertCandidateReviewParams struct {
+	CandidateID  uuid.UUID
+	UniversityID uuid.UUID
+	ReviewerID   uuid.UUID
+	ReviewerRole string
+	FacultyID    uuid.NullUUID
+	DepartmentID uuid.NullUUID
+	Decision     string
+	Comment      string
+}
+
+func (q *Queries) UpsertCandidateReview(ctx context.Context, arg UpsertCandidateReviewParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, upsertCandidateReview,
+		arg.CandidateID,
+		arg.UniversityID,
+		arg.ReviewerID,
+		arg.ReviewerRole,
+		arg.FacultyID,
+		arg.DepartmentID,
+		arg.Decision,
+		arg.Comment,
+	)
+	var review_id uuid.UUID
+	err := row.Scan(&review_id)
+	return review_id, err
+}
+
+const upsertElectiveGroup = `-- name: UpsertElectiveGroup :one
+INSERT INTO elective_groups(
+    university_id,cohort_id,group_name
+)VALUES($1,$2,$3)
+ON CONFLICT (cohort_id,group_name) DO UPDATE
+SET updated_at = NOW()
+RETURNING elective_group_id
+`
+
+type UpsertElectiveGroupParams struct {
+	UniversityID uuid.UUID
+	CohortID     uuid.UUID
+	GroupName    string
+}
+
+func (q *Queries) UpsertElectiveGroup(ctx context.Context, arg UpsertElectiveGroupParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, upsertElectiveGroup, arg.UniversityID, arg.CohortID, arg.GroupName)
+	var elective_group_id uuid.UUID
+	err := row.Scan(&elective_group_id)
+	return elective_group_id, err
+}
+
+const upsertSessionPin = `-- name: UpsertSessionPin :one
+INSERT INTO timetable_session_pins(
+    university_id,course_id,session_number,day,start_time,venue_id
+)VALUES($1,$2,$3,$4,$5::text::time,$6)
+ON CONFLICT (course_id,session_number) DO UPDATE
+SET day = EXCLUDED.day,
+    start_time = EXCLUDED.start_time,
+    venue_id = EXCLUDED.venue_id,
+    updated_at = NOW()
+RETURNING pin_id
+`
+
+type UpsertSessionPinParams struct {
+	UniversityID  uuid.UUID
+	CourseID      uuid.UUID
+	SessionNumber int32
+	Day           string
+	StartTime     string
+	VenueID       uuid.UUID
+}
+
+func (q *Queries) UpsertSessionPin(ctx context.Context, arg UpsertSessionPinParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, upsertSessionPin,
+		arg.UniversityID,
+		arg.CourseID,
+		arg.SessionNumber,
+		arg.Day,
+		arg.StartTime,
+		arg.VenueID,
+	)
+	var pin_id uuid.UUID
+	err := row.Scan(&pin_id)
+	return pin_id, err
+}
+
+const upsertTimetableConstraint = `-- name: UpsertTimetableConstraint :one
+INSERT INTO timetable_constraints(
+    university_id,constraint_name,enabled,weight,limit_value,hard
+)VALUES($1,$2,$3,$4,$5,$6)
+ON CONFLICT (university_id,constraint_name) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    weight = EXCLUDED.weight,
+    limit_value = EXCLUDED.limit_value,
+    hard = EXCLUDED.hard,
+    updated_at = NOW()
+RETURNING university_id, constraint_name, enabled, weight, limit_value, created_at, updated_at, hard
+`
+
+type UpsertTimetableConstraintParams struct {
+	UniversityID   uuid.UUID
+	ConstraintName string
+	Enabled        bool
+	Weight         float64
+	LimitValue     float64
+	Hard           bool
+}
+
+func (q *Queries) UpsertTimetableConstraint(ctx context.Context, arg UpsertTimetableConstraintParams) (TimetableConstraint, error) {
+	row := q.db.QueryRowContext(ctx, upsertTimetableConstraint,
+		arg.UniversityID,
+		arg.ConstraintName,
+		arg.Enabled,
+		arg.Weight,
+		arg.LimitValue,
+		arg.Hard,
+	)
+	var i TimetableConstraint
+	err := row.Scan(
+		&i.UniversityID,
+		&i.ConstraintName,
+		&i.Enabled,
+		&i.Weight,
+		&i.LimitValue,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Hard,
+	)
+	return i, err
+}
+
+const upsertTimetableSettings = `-- name: UpsertTimetableSettings :one
+INSERT INTO timetable_settings(
+    university_id,population_size,generations,mutation_rate,tournament_size,elitism_fraction,seed,solver,
+    stall_generations,time_budget_seconds,stop_when_feasible
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+ON CONFLICT (university_id) DO UPDATE
+SET population_size = EXCLUDED.population_size,
+    generations = EXCLUDED.generations,
+    mutation_rate = EXCLUDED.mutation_rate,
+    tournament_size = EXCLUDED.tournament_size,
+    elitism_fraction = EXCLUDED.elitism_fraction,
+    seed = EXCLUDED.seed,
+    solver = EXCLUDED.solver,
+    stall_generations = EXCLUDED.stall_generations,
+    time_budget_seconds = EXCLUDED.time_budget_seconds,
+    stop_when_feasible = EXCLUDED.stop_when_feasible,
+    updated_at = NOW()
+RETURNING university_id, population_size, generations, mutation_rate, tournament_size, elitism_fraction, seed, created_at, updated_at, slot_minutes, solver, stall_generations, time_budget_seconds, stop_when_feasible
+`
+
+type UpsertTimetableSettingsParams struct {
+	UniversityID      uuid.UUID
+	PopulationSize    int32
+	Generations       int32
+	MutationRate      float64
+	TournamentSize    int32
+	ElitismFraction   float64
+	Seed              sql.NullInt64
+	Solver            string
+	StallGenerations  int32
+	TimeBudgetSeconds int32
+	StopWhenFeasible  bool
+}
+
+func (q *Queries) UpsertTimetableSettings(ctx context.Context, arg UpsertTimetableSettingsParams) (TimetableSetting, error) {
+	row := q.db.QueryRowContext(ctx, upsertTimetableSettings,
+		arg.UniversityID,
+		arg.PopulationSize,
+		arg.Generations,
+		arg.MutationRate,
+		arg.TournamentSize,
+		arg.ElitismFraction,
+		arg.Seed,
+		arg.Solver,
+		arg.StallGenerations,
+		arg.TimeBudgetSeconds,
+		arg.StopWhenFeasible,
+	)
+	var i TimetableSetting
+	err := row.Scan(
+		&i.UniversityID,
+		&i.PopulationSize,
+		&i.Generations,
+		&i.MutationRate,
+		&i.TournamentSize,
+		&i.ElitismFraction,
+		&i.Seed,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SlotMinutes,
+		&i.Solver,
+		&i.StallGenerations,
+		&i.TimeBudgetSeconds,
+		&i.StopWhenFeasible,
+	)
+	return i, err
+}
+
+const upsertVenueTravelTime = `-- name: UpsertVenueTravelTime :exec
+INSERT INTO venue_travel_times(
+    university_id,from_venue_id,to_venue_id,minutes
+)VALUES($1,$2,$3,$4)
+ON CONFLICT (from_venue_id,to_venue_id) DO UPDATE
+SET minutes = EXCLUDED.minutes,
+    updated_at = NOW()
+`
+
+type UpsertVenueTravelTimeParams struct {
+	UniversityID uuid.UUID
+	FromVenueID  uuid.UUID
+	ToVenueID    uuid.UUID
+	Minutes      int32
+}
+
+func (q *Queries) UpsertVenueTravelTime(ctx context.Context, arg UpsertVenueTravelTimeParams) error {
+	_, err := q.db.ExecContext(ctx, upsertVenueTravelTime,
+		arg.UniversityID,
+		arg.FromVenueID,
+		arg.ToVenueID,
+		arg.Minutes,
+	)
+	return err
+}
@@ -39,10 +39,17 @@ func (cohq *CohortQueries) CountCohortsForAUni(ctx context.Context,uniId uuid.UU
 	return cohq.q.CountCohortsForOneUni(ctx,uniId)
 }
 
-func (cohq *CohortQueries) RetrieveTotalCohortCourses(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveCohortsForAllCoursesRow,error){
-	return cohq.q.RetrieveCohortsForAllCourses(ctx,uniId)
+func (cohq *CohortQueries) RetrieveTotalCohortCourses(ctx context.Context,params sqlc.RetrieveCohortsForAllCoursesParams)([]sqlc.RetrieveCohortsForAllCoursesRow,error){
+	return cohq.q.RetrieveCohortsForAllCourses(ctx,params)
 }
 
 func (uq *CohortQueries) FetchCohortsForADepartment(ctx context.Context,deptId uuid.UUID)([]sqlc.FetchCohortsForADepartmentRow,error){
 	return uq.q.FetchCohortsForADepartment(ctx,deptId)
-}
\ No newline at end of file
+}
+func (cohq *CohortQueries) SetCohortSize(ctx context.Context,params sqlc.SetCohortSizeParams)(sqlc.Cohort,error){
+	return cohq.q.SetCohortSize(ctx,params)
+}
+
+func (cohq *CohortQueries) RetrieveCohortStudentCounts(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveCohortStudentCountsRow,error){
+	return cohq.q.RetrieveCohortStudentCounts(ctx,uniId)
+}
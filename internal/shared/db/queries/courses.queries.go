@@ -60,8 +60,12 @@ func (cq *CoursesQueries) RetrieveAllCourses(ctx context.Context, uniId uuid.UUI
 	return cq.q.RetrieveAllCourses(ctx,uniId)
 }
 
-func (cq *CoursesQueries) RetrieveAllCoursesAndVenues(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveAllCoursesAndTheirVenueIdsRow,error){
-	return cq.q.RetrieveAllCoursesAndTheirVenueIds(ctx,uniId)
+func (cq *CoursesQueries) RetrieveCoursesForSemester(ctx context.Context, params sqlc.RetrieveCoursesForSemesterParams)([]sqlc.RetrieveCoursesForSemesterRow,error){
+	return cq.q.RetrieveCoursesForSemester(ctx,params)
+}
+
+func (cq *CoursesQueries) RetrieveAllCoursesAndVenues(ctx context.Context, params sqlc.RetrieveAllCoursesAndTheirVenueIdsParams)([]sqlc.RetrieveAllCoursesAndTheirVenueIdsRow,error){
+	return cq.q.RetrieveAllCoursesAndTheirVenueIds(ctx,params)
 }
 
 func (cq *CoursesQueries) CreateCohortCourse(ctx context.Context,params sqlc.CreateCohortCourseParams)(sqlc.CohortCoursesOffered,error){
@@ -77,8 +81,8 @@ func (cq *CoursesQueries) FetchSessionsForACohort(ctx context.Context,params sql
 	return cq.q.GetCohortSessionsInCurrentTimetable(ctx,params)
 }
 
-func (cq *CoursesQueries) FetchSessionsForAStudent(ctx context.Context,studentId uuid.UUID)([]sqlc.GetStudentTimetableSessionsRow,error){
-	return cq.q.GetStudentTimetableSessions(ctx,studentId)
+func (cq *CoursesQueries) FetchSessionsForAStudent(ctx context.Context,params sqlc.GetStudentTimetableSessionsParams)([]sqlc.GetStudentTimetableSessionsRow,error){
+	return cq.q.GetStudentTimetableSessions(ctx,params)
 }
 
 func (cq *CoursesQueries) SetCoursePossibleVenue(ctx context.Context,arg sqlc.SetCoursePossibleVenueParams)error{
@@ -99,4 +103,39 @@ func (cq *CoursesQueries) RetrieveCoursesForACohort(ctx context.Context,cohortId
 
 func (cq *CoursesQueries) FetchAllCourses(ctx context.Context, uniId uuid.UUID)([]sqlc.FetchAllCoursesRow,error){
 	return cq.q.FetchAllCourses(ctx,uniId)
-}
\ No newline at end of file
+}
+func (cq *CoursesQueries) DeleteCourseLecturer(ctx context.Context,param sqlc.DeleteCourseLecturerParams)error{
+	return cq.q.DeleteCourseLecturer(ctx,param)
+}
+
+func (cq *CoursesQueries) SetCourseLecturerMode(ctx context.Context,param sqlc.SetCourseLecturerModeParams)error{
+	return cq.q.SetCourseLecturerMode(ctx,param)
+}
+
+func (cq *CoursesQueries) SetCourseMaxGroupSize(ctx context.Context,param sqlc.SetCourseMaxGroupSizeParams)error{
+	return cq.q.SetCourseMaxGroupSize(ctx,param)
+}
+
+func (cq *CoursesQueries) RetrieveCourseLecturersForUni(ctx context.Context,params sqlc.RetrieveCourseLecturersForUniParams)([]sqlc.RetrieveCourseLecturersForUniRow,error){
+	return cq.q.RetrieveCourseLecturersForUni(ctx,params)
+}
+
+func (cq *CoursesQueries) RetrieveCourseStudentCounts(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveCourseStudentCountsRow,error){
+	return cq.q.RetrieveCourseStudentCounts(ctx,uniId)
+}
+
+func (cq *CoursesQueries) FetchElectiveGroupsForACohort(ctx context.Context,cohortId uuid.UUID)([]sqlc.FetchElectiveGroupsForACohortRow,error){
+	return cq.q.FetchElectiveGroupsForACohort(ctx,cohortId)
+}
+
+func (cq *CoursesQueries) DeleteElectiveGroup(ctx context.Context,params sqlc.DeleteElectiveGroupParams)error{
+	return cq.q.DeleteElectiveGroup(ctx,params)
+}
+
+func (cq *CoursesQueries) FetchCourseComponents(ctx context.Context,courseId uuid.UUID)([]sqlc.FetchCourseComponentsRow,error){
+	return cq.q.FetchCourseComponents(ctx,courseId)
+}
+
+func (cq *CoursesQueries) FetchCourseRequiredFeatures(ctx context.Context,courseId uuid.UUID)([]sqlc.FetchCourseRequiredFeaturesRow,error){
+	return cq.q.FetchCourseRequiredFeatures(ctx,courseId)
+}
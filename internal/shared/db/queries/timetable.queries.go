@@ -2,6 +2,7 @@ package queries
 
 import (
 	"context"
+	"database/sql"
 
 	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
 	"github.com/google/uuid"
@@ -31,9 +32,159 @@ func (tmtq *TimeTableQueries) CreateCandidate(ctx context.Context, params sqlc.C
 	return tmtq.q.CreateCandidate(ctx,params)
 }
 
-func (tmtq *TimeTableQueries) DeprecateLatestCandidate(ctx context.Context,uniId uuid.UUID)error{
-	return tmtq.q.DeprecateLatestCandidate(ctx,uniId)
+func (tmtq *TimeTableQueries) GetTimetableSettings(ctx context.Context,uniId uuid.UUID)(sqlc.TimetableSetting,error){
+	return tmtq.q.GetTimetableSettings(ctx,uniId)
 }
-func (tmtq *TimeTableQueries) RestoreCurrentCandidate(ctx context.Context,uniId uuid.UUID)error{
-	return tmtq.q.RestoreCurrentCandidate(ctx,uniId)
+
+func (tmtq *TimeTableQueries) UpsertTimetableSettings(ctx context.Context,params sqlc.UpsertTimetableSettingsParams)(sqlc.TimetableSetting,error){
+	return tmtq.q.UpsertTimetableSettings(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetTimetableConstraints(ctx context.Context,uniId uuid.UUID)([]sqlc.TimetableConstraint,error){
+	return tmtq.q.GetTimetableConstraints(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) UpsertTimetableConstraint(ctx context.Context,params sqlc.UpsertTimetableConstraintParams)(sqlc.TimetableConstraint,error){
+	return tmtq.q.UpsertTimetableConstraint(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) SetTimetableSlotMinutes(ctx context.Context,params sqlc.SetTimetableSlotMinutesParams)error{
+	return tmtq.q.SetTimetableSlotMinutes(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetTeachingDays(ctx context.Context,uniId uuid.UUID)([]sqlc.GetTeachingDaysRow,error){
+	return tmtq.q.GetTeachingDays(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) UpsertSessionPin(ctx context.Context,params sqlc.UpsertSessionPinParams)(uuid.UUID,error){
+	return tmtq.q.UpsertSessionPin(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetSessionPins(ctx context.Context,uniId uuid.UUID)([]sqlc.GetSessionPinsRow,error){
+	return tmtq.q.GetSessionPins(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) DeleteSessionPin(ctx context.Context,params sqlc.DeleteSessionPinParams)error{
+	return tmtq.q.DeleteSessionPin(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateById(ctx context.Context,arg sqlc.GetCandidateByIdParams)(sqlc.Candidate,error){
+	return tmtq.q.GetCandidateById(ctx,arg)
+}
+
+func (tmtq *TimeTableQueries) ListCandidates(ctx context.Context,uniId uuid.UUID)([]sqlc.Candidate,error){
+	return tmtq.q.ListCandidates(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateSessions(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateSessionsRow,error){
+	return tmtq.q.GetCandidateSessions(ctx,candidateId)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateCohortSessions(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateCohortSessionsRow,error){
+	return tmtq.q.GetCandidateCohortSessions(ctx,candidateId)
+}
+
+func (tmtq *TimeTableQueries) GetPublishedCandidate(ctx context.Context,params sqlc.GetPublishedCandidateParams)(sqlc.Candidate,error){
+	return tmtq.q.GetPublishedCandidate(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetUniversityCurrentSession(ctx context.Context,uniId uuid.UUID)(sql.NullString,error){
+	return tmtq.q.GetUniversityCurrentSession(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) GetUserUniversity(ctx context.Context,userId uuid.UUID)(uuid.NullUUID,error){
+	return tmtq.q.GetUserUniversity(ctx,userId)
+}
+
+func (tmtq *TimeTableQueries) GetLatestPublishedCandidate(ctx context.Context,uniId uuid.UUID)(sqlc.Candidate,error){
+	return tmtq.q.GetLatestPublishedCandidate(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) UpdateCandidateStatus(ctx context.Context,params sqlc.UpdateCandidateStatusParams)error{
+	return tmtq.q.UpdateCandidateStatus(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateSessionsForFaculty(ctx context.Context,params sqlc.GetCandidateSessionsForFacultyParams)([]sqlc.GetCandidateSessionsForFacultyRow,error){
+	return tmtq.q.GetCandidateSessionsForFaculty(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateSessionsForDepartment(ctx context.Context,params sqlc.GetCandidateSessionsForDepartmentParams)([]sqlc.GetCandidateSessionsForDepartmentRow,error){
+	return tmtq.q.GetCandidateSessionsForDepartment(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) UpsertCandidateReview(ctx context.Context,params sqlc.UpsertCandidateReviewParams)(uuid.UUID,error){
+	return tmtq.q.UpsertCandidateReview(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateReviews(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateReviewsRow,error){
+	return tmtq.q.GetCandidateReviews(ctx,candidateId)
+}
+
+// the faculty of a dean and the department of a HOD scope what they review
+func (tmtq *TimeTableQueries) RetrieveDean(ctx context.Context,deanId uuid.UUID)(sqlc.RetrieveDeanRow,error){
+	return tmtq.q.RetrieveDean(ctx,deanId)
+}
+
+func (tmtq *TimeTableQueries) RetrieveHod(ctx context.Context,hodId uuid.UUID)(sqlc.RetrieveHodRow,error){
+	return tmtq.q.RetrieveHod(ctx,hodId)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateSessionPlacements(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateSessionPlacementsRow,error){
+	return tmtq.q.GetCandidateSessionPlacements(ctx,candidateId)
+}
+
+func (tmtq *TimeTableQueries) GetMovedSessions(ctx context.Context,params sqlc.GetMovedSessionsParams)([]sqlc.GetMovedSessionsRow,error){
+	return tmtq.q.GetMovedSessions(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetCandidateFitnessHistory(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateFitnessHistoryRow,error){
+	return tmtq.q.GetCandidateFitnessHistory(ctx,candidateId)
+}
+
+func (tmtq *TimeTableQueries) GetCourseDepartments(ctx context.Context,uniId uuid.UUID)([]sqlc.GetCourseDepartmentsRow,error){
+	return tmtq.q.GetCourseDepartments(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) GetVenueTravelTimes(ctx context.Context,uniId uuid.UUID)([]sqlc.GetVenueTravelTimesRow,error){
+	return tmtq.q.GetVenueTravelTimes(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) UpsertVenueTravelTime(ctx context.Context,params sqlc.UpsertVenueTravelTimeParams)error{
+	return tmtq.q.UpsertVenueTravelTime(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) DeleteVenueTravelTime(ctx context.Context,params sqlc.DeleteVenueTravelTimeParams)error{
+	return tmtq.q.DeleteVenueTravelTime(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetDepartmentVenueUsage(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetDepartmentVenueUsageRow,error){
+	return tmtq.q.GetDepartmentVenueUsage(ctx,candidateId)
+}
+
+func (tmtq *TimeTableQueries) RetrieveElectiveGroupCourses(ctx context.Context,params sqlc.RetrieveElectiveGroupCoursesParams)([]sqlc.RetrieveElectiveGroupCoursesRow,error){
+	return tmtq.q.RetrieveElectiveGroupCourses(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) RetrieveElectiveStudentOverlaps(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveElectiveStudentOverlapsRow,error){
+	return tmtq.q.RetrieveElectiveStudentOverlaps(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) RetrieveCourseComponentsForUni(ctx context.Context,params sqlc.RetrieveCourseComponentsForUniParams)([]sqlc.RetrieveCourseComponentsForUniRow,error){
+	return tmtq.q.RetrieveCourseComponentsForUni(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) GetVenueSessionsInCurrentTimetable(ctx context.Context,params sqlc.GetVenueSessionsInCurrentTimetableParams)([]sqlc.GetVenueSessionsInCurrentTimetableRow,error){
+	return tmtq.q.GetVenueSessionsInCurrentTimetable(ctx,params)
+}
+
+func (tmtq *TimeTableQueries) RetrieveVenueFeaturesForUni(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveVenueFeaturesForUniRow,error){
+	return tmtq.q.RetrieveVenueFeaturesForUni(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) RetrieveVenueOwnersForUni(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveVenueOwnersForUniRow,error){
+	return tmtq.q.RetrieveVenueOwnersForUni(ctx,uniId)
+}
+
+func (tmtq *TimeTableQueries) RetrieveCourseRequiredFeaturesForUni(ctx context.Context,params sqlc.RetrieveCourseRequiredFeaturesForUniParams)([]sqlc.RetrieveCourseRequiredFeaturesForUniRow,error){
+	return tmtq.q.RetrieveCourseRequiredFeaturesForUni(ctx,params)
 }
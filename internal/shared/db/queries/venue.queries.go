@@ -48,4 +48,16 @@ func (vq *VenueQueries) RetrieveTotalVenueCount(ctx context.Context, universityI
 
 func (vq  *VenueQueries) RetrieveAllVenues(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveAllVenuesRow,error){
 	return vq.q.RetrieveAllVenues(ctx,uniId)
-}
\ No newline at end of file
+}
+
+func (vq *VenueQueries) SetVenueKind(ctx context.Context, params sqlc.SetVenueKindParams)error{
+	return vq.q.SetVenueKind(ctx,params)
+}
+
+func (vq *VenueQueries) SetVenueRestricted(ctx context.Context, params sqlc.SetVenueRestrictedParams)error{
+	return vq.q.SetVenueRestricted(ctx,params)
+}
+
+func (vq *VenueQueries) FetchVenueFeatures(ctx context.Context, venueId uuid.UUID)([]string,error){
+	return vq.q.FetchVenueFeatures(ctx,venueId)
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,6 +32,17 @@ func Float64ToNullFloat64(s float64)sql.NullFloat64{
     }
 }
 
+// nil becomes null
+func Int32PtrToNullInt32(n *int32)sql.NullInt32{
+    if n == nil{
+        return sql.NullInt32{}
+    }
+    return sql.NullInt32{
+        Int32: *n,
+        Valid: true,
+    }
+}
+
 func NullTimeToTime(t sql.NullTime)(time.Time,error){
     if !t.Valid{
         return time.Time{},errors.New("No time present")
@@ -110,3 +122,18 @@ func NullUUIDToUUid(val uuid.NullUUID)(uuid.UUID,error){
     }
     return val.UUID,nil
 }
+
+// trims and upper cases tags e.g " projector" to "PROJECTOR", empty and repeated ones are dropped
+func NormalizeTags(tags []string) []string {
+    out := make([]string, 0, len(tags))
+    seen := make(map[string]bool)
+    for _, tag := range tags {
+        tag = strings.ToUpper(strings.TrimSpace(tag))
+        if tag == "" || seen[tag] {
+            continue
+        }
+        seen[tag] = true
+        out = append(out, tag)
+    }
+    return out
+}
@@ -0,0 +1,811 @@
+package computed_test
+
+import (
+	"log/slog"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+)
+
+// the candidate building, crossover and mutation of the genetic algorithm as they were before the
+// occupancy grids became bitsets, one bool per slot of every venue, lecturer and cohort. kept as
+// the reference the benchmarks in occupancy_test.go measure the bitsets against
+
+// a session needs at least one lecturer and all of them must be known
+func legacyValidLecturerIdxs(pre *computed.PreComputed, session *computed.SessionAtom) bool {
+	if len(session.LecturerIdxs) == 0 {
+		return false
+	}
+	for _, lecturerIdx := range session.LecturerIdxs {
+		if lecturerIdx < 0 || lecturerIdx >= len(pre.LecturerUnavailable) {
+			return false
+		}
+	}
+	return true
+}
+
+// true if any slot the session would take is outside the hours of its day
+func legacyIsBlocked(pre *computed.PreComputed, start int, duration int) bool {
+	for si := start; si < start+duration; si++ {
+		if si < len(pre.BlockedSlots) && pre.BlockedSlots[si] {
+			return true
+		}
+	}
+	return false
+}
+
+// next function is to compute top feasible pairs
+func legacyFeasiblePairs(pre *computed.PreComputed, session *computed.SessionAtom, venueOccupied [][]bool, lecturerOccupied [][]bool, cohortOccupied [][]bool) []computed.FeasiblePair {
+	totalSlots := pre.TotalSlots
+	feasible := make([]computed.FeasiblePair, 0, totalSlots)
+
+	// Add bounds checking for session indices
+	if !legacyValidLecturerIdxs(pre, session) {
+		slog.Error("Invalid lecturer index", "lecturerIdxs", session.LecturerIdxs, "max", len(pre.LecturerUnavailable))
+		return feasible // Return empty if invalid
+	}
+
+	// iterates through all possible slots
+	for start := 0; start < totalSlots; start++ {
+		// prevents cross day boundary i.e a session crossing a day
+		startDaySlot := (start / pre.SlotsPerDay) * pre.SlotsPerDay
+		EndDaySlot := startDaySlot + pre.SlotsPerDay
+
+		if start+session.SessionDuration > EndDaySlot {
+			continue
+		}
+
+		// prevents slotting outside the hours of the day
+		if legacyIsBlocked(pre, start, session.SessionDuration) {
+			continue
+		}
+
+		// prevents slotting in periods of unavailable lecturers for the session
+		lectOk := true
+		s := start + session.SessionDuration
+
+		// Add bounds checking for lecturer unavailable
+		for si := start; si < s; si++ {
+			if si >= totalSlots {
+				lectOk = false
+				break
+			}
+			// every lecturer of the session has to be free
+			for _, lecturerIdx := range session.LecturerIdxs {
+				// Check if lecturer unavailable array has enough length
+				if si >= len(pre.LecturerUnavailable[lecturerIdx]) {
+					lectOk = false
+					break
+				}
+				if pre.LecturerUnavailable[lecturerIdx][si] ||
+					(si < len(lecturerOccupied[lecturerIdx]) && lecturerOccupied[lecturerIdx][si]) {
+					lectOk = false
+					break
+				}
+			}
+			if !lectOk {
+				break
+			}
+		}
+		if !lectOk {
+			continue
+		}
+
+		// now prevents cohorts conflict
+		cohortsConflict := false
+		for ci := start; ci < s; ci++ {
+			if ci >= totalSlots {
+				cohortsConflict = true
+				break
+			}
+			for _, c := range session.CohortIdxs {
+				// Add bounds checking for cohort index
+				if c < 0 || c >= len(cohortOccupied) {
+					cohortsConflict = true
+					break
+				}
+				if ci < len(cohortOccupied[c]) && cohortOccupied[c][ci] {
+					cohortsConflict = true
+					break
+				}
+			}
+			if cohortsConflict {
+				break
+			}
+		}
+
+		if cohortsConflict {
+			continue
+		}
+
+		// prevents venue conflict
+		for _, v := range session.AllowedVenuesIdx {
+			// Add bounds checking for venue index
+			if v < 0 || v >= len(venueOccupied) {
+				continue
+			}
+
+			venueOk := true
+			for vi := start; vi < s; vi++ {
+				if vi >= totalSlots {
+					venueOk = false
+					break
+				}
+				// Check venue unavailable bounds
+				if v < len(pre.VenueUnavailable) && vi < len(pre.VenueUnavailable[v]) && pre.VenueUnavailable[v][vi] {
+					venueOk = false
+					break
+				}
+				if vi < len(venueOccupied[v]) && venueOccupied[v][vi] {
+					venueOk = false
+					break
+				}
+			}
+			if !venueOk {
+				continue
+			}
+			feasible = append(feasible, computed.FeasiblePair{
+				SlotIdx:  start,
+				VenueIdx: v,
+				Score:    legacyWastedSeats(pre, session, v),
+				Reasons:  "",
+			})
+		}
+	}
+	// pairs in the snuggest rooms come first so the top k prefer them
+	sort.SliceStable(feasible, func(i, j int) bool {
+		return feasible[i].Score < feasible[j].Score
+	})
+	return feasible
+}
+
+// fraction of the venue left empty by the session, 0 when the capacity is unknown
+func legacyWastedSeats(pre *computed.PreComputed, session *computed.SessionAtom, venueIdx int) float64 {
+	if venueIdx >= len(pre.VenueCapacities) || pre.VenueCapacities[venueIdx] <= 0 {
+		return 0
+	}
+	capacity := float64(pre.VenueCapacities[venueIdx])
+	return (capacity - float64(session.Headcount)) / capacity
+}
+
+func legacyLeastBadPair(pre *computed.PreComputed, session *computed.SessionAtom, venueOccupied [][]bool, lecturerOccupied [][]bool, cohortOccupied [][]bool) computed.FeasiblePair {
+	totalSlots := pre.TotalSlots
+
+	bestPair := computed.FeasiblePair{
+		Score: math.MaxFloat64,
+	}
+
+	// Add bounds checking for session indices
+	if !legacyValidLecturerIdxs(pre, session) {
+		slog.Error("Invalid lecturer index in ComputeLeastBadPair", "lecturerIdxs", session.LecturerIdxs)
+		return bestPair
+	}
+
+	for start := 0; start < totalSlots; start++ {
+		// prevents cross day boundary i.e a session crossing a day
+		startDaySlot := (start / pre.SlotsPerDay) * pre.SlotsPerDay
+		EndDaySlot := startDaySlot + pre.SlotsPerDay
+
+		if start+session.SessionDuration > EndDaySlot {
+			continue
+		}
+
+		if legacyIsBlocked(pre, start, session.SessionDuration) {
+			continue
+		}
+
+		for _, v := range session.AllowedVenuesIdx {
+			// Skip invalid venue indices
+			if v < 0 || v >= len(venueOccupied) {
+				continue
+			}
+
+			conflictScore := 0.0
+			s := start + session.SessionDuration
+
+			for si := start; si < s; si++ {
+				if si >= totalSlots {
+					conflictScore += 1500 // Penalty for out of bounds
+					continue
+				}
+
+				for _, lecturerIdx := range session.LecturerIdxs {
+					// Lecturer unavailable check with bounds
+					if si < len(pre.LecturerUnavailable[lecturerIdx]) {
+						if pre.LecturerUnavailable[lecturerIdx][si] {
+							conflictScore += 10
+						}
+					}
+
+					// Lecturer occupied check with bounds
+					if lecturerIdx < len(lecturerOccupied) && si < len(lecturerOccupied[lecturerIdx]) {
+						if lecturerOccupied[lecturerIdx][si] {
+							conflictScore += 1500
+						}
+					}
+				}
+
+				// Venue unavailable check with bounds
+				if v < len(pre.VenueUnavailable) && si < len(pre.VenueUnavailable[v]) {
+					if pre.VenueUnavailable[v][si] {
+						conflictScore += 10
+					}
+				}
+
+				// Venue occupied check with bounds
+				if v < len(venueOccupied) && si < len(venueOccupied[v]) {
+					if venueOccupied[v][si] {
+						conflictScore += 1500
+					}
+				}
+
+				// Cohort occupied check with bounds
+				for _, c := range session.CohortIdxs {
+					if c >= 0 && c < len(cohortOccupied) && si < len(cohortOccupied[c]) {
+						if cohortOccupied[c][si] {
+							conflictScore += 500
+						}
+					} else {
+						conflictScore += 500 // Penalty for invalid cohort index
+					}
+				}
+			}
+
+			if conflictScore < bestPair.Score {
+				bestPair.Score = conflictScore
+				bestPair.VenueIdx = v
+				bestPair.SlotIdx = start
+			}
+		}
+	}
+	return bestPair
+}
+
+// this function builds a candidate timetable
+func legacyBuildOneCandidate(r *rand.Rand, pre *computed.PreComputed, k int) *computed.Candidate {
+	if pre == nil {
+		slog.Error("computed.PreComputed is nil")
+		return &computed.Candidate{Placements: []computed.SessionPlacement{}}
+	}
+
+	if len(pre.SessionAtoms) == 0 {
+		slog.Error("No session atoms in computed.PreComputed")
+		return &computed.Candidate{Placements: []computed.SessionPlacement{}}
+	}
+
+	slog.Info("Building candidate",
+		"totalSessions", len(pre.SessionAtoms),
+		"totalVenues", pre.NumVenues,
+		"totalLecturers", pre.NumLecturers,
+		"totalSlots", pre.TotalSlots,
+		"totalCohorts", pre.NumCohorts)
+	totalSessions := len(pre.SessionAtoms)
+	totalVenues := pre.NumVenues
+	totalLecturers := pre.NumLecturers
+	totalSlots := pre.TotalSlots
+	totalCohorts := pre.NumCohorts
+
+	placements := make([]computed.SessionPlacement, totalSessions)
+
+	// create an order slice to be used to order session placement
+	order := make([]int, totalSessions)
+
+	// input appropriate matching indexes into order array
+	for i := 0; i < totalSessions; i++ {
+		order[i] = i
+	}
+
+	// sort the order array according giving preference to sessions with less allowed venues and longer durations
+	sort.Slice(order, func(i, j int) bool {
+		a := pre.SessionAtoms[order[i]]
+		b := pre.SessionAtoms[order[j]]
+		scoreA := float64(len(a.AllowedVenuesIdx))*0.5 + float64(a.SessionDuration)*1.0
+		scoreB := float64(len(b.AllowedVenuesIdx))*0.5 + float64(b.SessionDuration)*1.0
+		return scoreA < scoreB // less allowed venues = harder -> come earlier
+	})
+
+	// compute venueOccupied
+	venueOcc := make([][]bool, totalVenues)
+	for i := range venueOcc {
+		venueOcc[i] = make([]bool, totalSlots)
+	}
+
+	// compute lecturerOccupied
+	lecturerOcc := make([][]bool, totalLecturers)
+	for i := range lecturerOcc {
+		lecturerOcc[i] = make([]bool, totalSlots)
+	}
+
+	// compute cohortsOccupied
+	cohortOcc := make([][]bool, totalCohorts)
+	for i := range cohortOcc {
+		cohortOcc[i] = make([]bool, totalSlots)
+	}
+
+	// pinned sessions go in first so everything else is placed around them
+	for sessionIdx := range pre.SessionAtoms {
+		if pre.SessionAtoms[sessionIdx].Pinned {
+			placements[sessionIdx] = legacyPinnedPlacement(&pre.SessionAtoms[sessionIdx])
+		}
+	}
+	legacyMarkPinned(pre, venueOcc, lecturerOcc, cohortOcc)
+
+	// placement of sessions into appropriate slots and venue in order
+	for _, sessionIdx := range order {
+		if sessionIdx < 0 || sessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+
+		session := &pre.SessionAtoms[sessionIdx]
+		if session.Pinned {
+			continue
+		}
+
+		feasible := legacyFeasiblePairs(pre, session, venueOcc, lecturerOcc, cohortOcc)
+
+		// if there are feasible pairs
+		if len(feasible) > 0 {
+			chosen := computed.ChooseTopSampleK(feasible, k, r)
+			placements[sessionIdx] = computed.SessionPlacement{
+				SessionIdx: sessionIdx,
+				CourseIdx:  session.CourseIdx,
+				VenueIdx:   chosen.VenueIdx,
+				SlotIdx:    chosen.SlotIdx,
+				Conflict:   false,
+				Score:      0.0,
+			}
+
+			// mark occupancy
+			for d := 0; d < session.SessionDuration; d++ {
+				// si means slot index
+				si := chosen.SlotIdx + d
+				if si >= totalSlots {
+					break
+				}
+				if chosen.VenueIdx < len(venueOcc) && si < len(venueOcc[chosen.VenueIdx]) {
+					venueOcc[chosen.VenueIdx][si] = true
+				}
+				for _, lecturerIdx := range session.LecturerIdxs {
+					if lecturerIdx < len(lecturerOcc) && si < len(lecturerOcc[lecturerIdx]) {
+						lecturerOcc[lecturerIdx][si] = true
+					}
+				}
+				for _, c := range session.CohortIdxs {
+					if c < len(cohortOcc) && si < len(cohortOcc[c]) {
+						cohortOcc[c][si] = true
+					}
+				}
+			}
+		} else {
+			// fallback: selects least bad pair
+			best := legacyLeastBadPair(pre, session, venueOcc, lecturerOcc, cohortOcc)
+			placements[sessionIdx] = computed.SessionPlacement{
+				SessionIdx: sessionIdx,
+				CourseIdx:  session.CourseIdx,
+				VenueIdx:   best.VenueIdx,
+				SlotIdx:    best.SlotIdx,
+				Conflict:   true,
+				Score:      best.Score,
+			}
+
+			// mark tempoary occupancy
+			for d := 0; d < session.SessionDuration; d++ {
+				// si means slot index
+				si := best.SlotIdx + d
+				if si >= totalSlots {
+					break
+				}
+				if best.VenueIdx < len(venueOcc) && si < len(venueOcc[best.VenueIdx]) {
+					venueOcc[best.VenueIdx][si] = true
+				}
+				for _, lecturerIdx := range session.LecturerIdxs {
+					if lecturerIdx < len(lecturerOcc) && si < len(lecturerOcc[lecturerIdx]) {
+						lecturerOcc[lecturerIdx][si] = true
+					}
+				}
+				for _, c := range session.CohortIdxs {
+					if c < len(cohortOcc) && si < len(cohortOcc[c]) {
+						cohortOcc[c][si] = true
+					}
+				}
+			}
+		}
+	}
+
+	return &computed.Candidate{
+		Placements: placements,
+	}
+}
+
+func legacyDetermineBestParent(pre *computed.PreComputed, parent1 *computed.Candidate, parent2 *computed.Candidate, CourseIdx int, lecturerOcc [][]bool, venueOcc [][]bool, cohortOcc [][]bool) []computed.SessionPlacement {
+	parent1ConflictScore := 0
+	parent1SessionPlacements := make([]computed.SessionPlacement, 0)
+
+	parent2ConflictScore := 0
+	parent2SessionPlacements := make([]computed.SessionPlacement, 0)
+
+	for _, session := range parent1.Placements {
+		// Add bounds checking for session.SessionIdx
+		if session.SessionIdx < 0 || session.SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+
+		hasConflict := false
+		lecturerIdxs := pre.SessionAtoms[session.SessionIdx].LecturerIdxs
+		cohortIdxs := pre.SessionAtoms[session.SessionIdx].CohortIdxs
+
+		if session.CourseIdx == CourseIdx {
+			// a pinned session is already marked and never moves
+			if pre.SessionAtoms[session.SessionIdx].Pinned {
+				parent1SessionPlacements = append(parent1SessionPlacements, legacyPinnedPlacement(&pre.SessionAtoms[session.SessionIdx]))
+				continue
+			}
+
+			// Check lecturer bounds of every lecturer of the session
+			for _, lecturerIdx := range lecturerIdxs {
+				if lecturerIdx >= 0 && lecturerIdx < len(lecturerOcc) && session.SlotIdx < len(lecturerOcc[lecturerIdx]) {
+					if lecturerOcc[lecturerIdx][session.SlotIdx] {
+						parent1ConflictScore += 1500
+						hasConflict = true
+					}
+				} else {
+					// If bounds are invalid, count as conflict
+					parent1ConflictScore += 1500
+					hasConflict = true
+				}
+			}
+
+			// Check venue bounds
+			if session.VenueIdx >= 0 && session.VenueIdx < len(venueOcc) && session.SlotIdx < len(venueOcc[session.VenueIdx]) {
+				if venueOcc[session.VenueIdx][session.SlotIdx] {
+					parent1ConflictScore += 1500
+					hasConflict = true
+				}
+			} else {
+				parent1ConflictScore += 1500
+				hasConflict = true
+			}
+
+			// Check cohort bounds
+			for _, cohortIdx := range cohortIdxs {
+				if cohortIdx >= 0 && cohortIdx < len(cohortOcc) && session.SlotIdx < len(cohortOcc[cohortIdx]) {
+					if cohortOcc[cohortIdx][session.SlotIdx] {
+						parent1ConflictScore += 500
+						hasConflict = true
+					}
+				} else {
+					parent1ConflictScore += 500
+					hasConflict = true
+				}
+			}
+
+			session.Conflict = hasConflict
+			parent1SessionPlacements = append(parent1SessionPlacements, session)
+		}
+	}
+
+	// Apply the same bounds checking for parent2
+	for _, session := range parent2.Placements {
+		if session.SessionIdx < 0 || session.SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+
+		hasConflict := false
+		lecturerIdxs := pre.SessionAtoms[session.SessionIdx].LecturerIdxs
+		cohortIdxs := pre.SessionAtoms[session.SessionIdx].CohortIdxs
+
+		if session.CourseIdx == CourseIdx {
+			// a pinned session is already marked and never moves
+			if pre.SessionAtoms[session.SessionIdx].Pinned {
+				parent2SessionPlacements = append(parent2SessionPlacements, legacyPinnedPlacement(&pre.SessionAtoms[session.SessionIdx]))
+				continue
+			}
+
+			// Check lecturer bounds of every lecturer of the session for parent2
+			for _, lecturerIdx := range lecturerIdxs {
+				if lecturerIdx >= 0 && lecturerIdx < len(lecturerOcc) && session.SlotIdx < len(lecturerOcc[lecturerIdx]) {
+					if lecturerOcc[lecturerIdx][session.SlotIdx] {
+						parent2ConflictScore += 1500
+						hasConflict = true
+					}
+				} else {
+					parent2ConflictScore += 1500
+					hasConflict = true
+				}
+			}
+
+			// Check venue bounds for parent2
+			if session.VenueIdx >= 0 && session.VenueIdx < len(venueOcc) && session.SlotIdx < len(venueOcc[session.VenueIdx]) {
+				if venueOcc[session.VenueIdx][session.SlotIdx] {
+					parent2ConflictScore += 1500
+					hasConflict = true
+				}
+			} else {
+				parent2ConflictScore += 1500
+				hasConflict = true
+			}
+
+			// Check cohort bounds for parent2
+			for _, cohortIdx := range cohortIdxs {
+				if cohortIdx >= 0 && cohortIdx < len(cohortOcc) && session.SlotIdx < len(cohortOcc[cohortIdx]) {
+					if cohortOcc[cohortIdx][session.SlotIdx] {
+						parent2ConflictScore += 500
+						hasConflict = true
+					}
+				} else {
+					parent2ConflictScore += 500
+					hasConflict = true
+				}
+			}
+
+			session.Conflict = hasConflict
+			parent2SessionPlacements = append(parent2SessionPlacements, session)
+		}
+	}
+
+	if parent1ConflictScore < parent2ConflictScore {
+		return parent1SessionPlacements
+	} else {
+		return parent2SessionPlacements
+	}
+}
+
+func legacyRepairChild(pre *computed.PreComputed, childCandidate []computed.SessionPlacement, lecOcc [][]bool, venueOcc [][]bool, cohortOcc [][]bool) {
+	for idx := 0; idx < len(childCandidate); idx++ {
+		if childCandidate[idx].SessionIdx < 0 || childCandidate[idx].SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+
+		sessionAtom := pre.SessionAtoms[childCandidate[idx].SessionIdx]
+		if sessionAtom.Pinned {
+			continue
+		}
+		if childCandidate[idx].Conflict {
+			leastBadPair := legacyLeastBadPair(pre, &sessionAtom, venueOcc, lecOcc, cohortOcc)
+
+			for d := 0; d < sessionAtom.SessionDuration; d++ {
+				si := childCandidate[idx].SlotIdx + d
+				// remove old occupancy with bounds checking
+				for _, lecturerIdx := range sessionAtom.LecturerIdxs {
+					if lecturerIdx < len(lecOcc) && si < len(lecOcc[lecturerIdx]) {
+						lecOcc[lecturerIdx][si] = false
+					}
+				}
+				if childCandidate[idx].VenueIdx < len(venueOcc) && si < len(venueOcc[childCandidate[idx].VenueIdx]) {
+					venueOcc[childCandidate[idx].VenueIdx][si] = false
+				}
+				for _, cohortIdx := range sessionAtom.CohortIdxs {
+					if cohortIdx < len(cohortOcc) && si < len(cohortOcc[cohortIdx]) {
+						cohortOcc[cohortIdx][si] = false
+					}
+				}
+			}
+
+			childCandidate[idx].SlotIdx = leastBadPair.SlotIdx
+			childCandidate[idx].VenueIdx = leastBadPair.VenueIdx
+			childCandidate[idx].Score = leastBadPair.Score
+
+			// modify the childCandidate[idx] with the least bad pair
+			childCandidate[idx].Conflict = (leastBadPair.Score > 0.0)
+			for d := 0; d < sessionAtom.SessionDuration; d++ {
+				si := childCandidate[idx].SlotIdx + d
+				if si >= pre.TotalSlots {
+					break
+				}
+				// update occupancy with bounds checking
+				for _, lecturerIdx := range sessionAtom.LecturerIdxs {
+					if lecturerIdx < len(lecOcc) && si < len(lecOcc[lecturerIdx]) {
+						lecOcc[lecturerIdx][si] = true
+					}
+				}
+				if childCandidate[idx].VenueIdx < len(venueOcc) && si < len(venueOcc[childCandidate[idx].VenueIdx]) {
+					venueOcc[childCandidate[idx].VenueIdx][si] = true
+				}
+				for _, cohortIdx := range sessionAtom.CohortIdxs {
+					if cohortIdx < len(cohortOcc) && si < len(cohortOcc[cohortIdx]) {
+						cohortOcc[cohortIdx][si] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+func legacyCrossover(pre *computed.PreComputed, parent1 *computed.Candidate, parent2 *computed.Candidate, CourseSessions [][]computed.SessionAtom) (*computed.Candidate, [][]bool, [][]bool, [][]bool) {
+	totalSlots := pre.TotalSlots
+	childCandidate := make([]computed.SessionPlacement, 0, totalSlots)
+
+	venueOccupied := make([][]bool, pre.NumVenues)
+	for i := range venueOccupied {
+		venueOccupied[i] = make([]bool, totalSlots)
+	}
+
+	lecturerOccupied := make([][]bool, pre.NumLecturers)
+	for i := range lecturerOccupied {
+		lecturerOccupied[i] = make([]bool, totalSlots)
+	}
+
+	cohortOccupied := make([][]bool, pre.NumCohorts)
+	for i := range cohortOccupied {
+		cohortOccupied[i] = make([]bool, totalSlots)
+	}
+	legacyMarkPinned(pre, venueOccupied, lecturerOccupied, cohortOccupied)
+
+	for courseIdx := range CourseSessions {
+		placements := legacyDetermineBestParent(pre, parent1, parent2, courseIdx, lecturerOccupied, venueOccupied, cohortOccupied)
+		for _, placement := range placements {
+			childCandidate = append(childCandidate, placement)
+
+			// Add bounds checking for session index
+			if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) {
+				continue
+			}
+
+			lecturerIdxs := pre.SessionAtoms[placement.SessionIdx].LecturerIdxs
+			cohortIdxs := pre.SessionAtoms[placement.SessionIdx].CohortIdxs
+
+			sessionAtom := pre.SessionAtoms[placement.SessionIdx]
+			// pinned occupancy was marked before any parent was looked at
+			if sessionAtom.Pinned {
+				continue
+			}
+			for d := 0; d < sessionAtom.SessionDuration; d++ {
+				si := d + placement.SlotIdx
+				if si >= pre.TotalSlots {
+					break
+				}
+				// mark occupancy for lecturer venue and cohorts with bounds checking
+				for _, lecturerIdx := range lecturerIdxs {
+					if lecturerIdx < len(lecturerOccupied) && si < len(lecturerOccupied[lecturerIdx]) {
+						lecturerOccupied[lecturerIdx][si] = true
+					}
+				}
+				if placement.VenueIdx < len(venueOccupied) && si < len(venueOccupied[placement.VenueIdx]) {
+					venueOccupied[placement.VenueIdx][si] = true
+				}
+				for _, cohortidx := range cohortIdxs {
+					if cohortidx < len(cohortOccupied) && si < len(cohortOccupied[cohortidx]) {
+						cohortOccupied[cohortidx][si] = true
+					}
+				}
+			}
+		}
+	}
+
+	// then i would run a repair function on the child and return the child timetable
+	legacyRepairChild(pre, childCandidate, lecturerOccupied, venueOccupied, cohortOccupied)
+
+	// calculate fitness of child candidate
+	actualChildCandidate := &computed.Candidate{
+		Placements: childCandidate,
+	}
+	return actualChildCandidate, lecturerOccupied, venueOccupied, cohortOccupied
+}
+
+func legacyMutation(pre *computed.PreComputed, childCandidate *computed.Candidate, r *rand.Rand, mutationRate float64, lecOcc [][]bool, venueOcc [][]bool, cohortOcc [][]bool) {
+	for placementIdx, placement := range childCandidate.Placements {
+		if r.Float64() < mutationRate {
+			// Add bounds checking
+			if placementIdx < 0 || placementIdx >= len(childCandidate.Placements) {
+				continue
+			}
+			if childCandidate.Placements[placementIdx].SessionIdx < 0 || childCandidate.Placements[placementIdx].SessionIdx >= len(pre.SessionAtoms) {
+				continue
+			}
+
+			sessionAtom := pre.SessionAtoms[childCandidate.Placements[placementIdx].SessionIdx]
+			if sessionAtom.Pinned {
+				continue
+			}
+
+			// remove occupancy
+			for d := 0; d < sessionAtom.SessionDuration; d++ {
+				si := placement.SlotIdx + d
+				if si >= pre.TotalSlots {
+					break
+				}
+				for _, lecturerIdx := range sessionAtom.LecturerIdxs {
+					if lecturerIdx < len(lecOcc) && si < len(lecOcc[lecturerIdx]) {
+						lecOcc[lecturerIdx][si] = false
+					}
+				}
+				if placement.VenueIdx < len(venueOcc) && si < len(venueOcc[placement.VenueIdx]) {
+					venueOcc[placement.VenueIdx][si] = false
+				}
+				for _, cohortidx := range sessionAtom.CohortIdxs {
+					if cohortidx < len(cohortOcc) && si < len(cohortOcc[cohortidx]) {
+						cohortOcc[cohortidx][si] = false
+					}
+				}
+			}
+
+			//  should run and add some variety for 30% of the chosen placements
+			if r.Float64() > 0.3 {
+				// find a random new slot - FIXED: Add bounds checking for random slot
+				maxSlot := pre.TotalSlots - sessionAtom.SessionDuration
+				if maxSlot <= 0 {
+					maxSlot = 1
+				}
+				randomSlot := r.Intn(maxSlot)
+
+				if len(sessionAtom.AllowedVenuesIdx) > 0 {
+					randomVenue := sessionAtom.AllowedVenuesIdx[r.Intn(len(sessionAtom.AllowedVenuesIdx))]
+					childCandidate.Placements[placementIdx].SlotIdx = randomSlot
+					childCandidate.Placements[placementIdx].VenueIdx = randomVenue
+					childCandidate.Placements[placementIdx].Conflict = true
+				}
+			} else {
+				// for the other 70%
+				leastBadPair := legacyLeastBadPair(pre, &sessionAtom, venueOcc, lecOcc, cohortOcc)
+				childCandidate.Placements[placementIdx].SlotIdx = leastBadPair.SlotIdx
+				childCandidate.Placements[placementIdx].VenueIdx = leastBadPair.VenueIdx
+				childCandidate.Placements[placementIdx].Score = leastBadPair.Score
+				childCandidate.Placements[placementIdx].Conflict = (leastBadPair.Score > 0.0)
+			}
+
+			// reoccupy occupancy
+			for d := 0; d < sessionAtom.SessionDuration; d++ {
+				si := childCandidate.Placements[placementIdx].SlotIdx + d
+				if si >= pre.TotalSlots {
+					break
+				}
+				for _, lecturerIdx := range sessionAtom.LecturerIdxs {
+					if lecturerIdx < len(lecOcc) && si < len(lecOcc[lecturerIdx]) {
+						lecOcc[lecturerIdx][si] = true
+					}
+				}
+				if childCandidate.Placements[placementIdx].VenueIdx < len(venueOcc) && si < len(venueOcc[childCandidate.Placements[placementIdx].VenueIdx]) {
+					venueOcc[childCandidate.Placements[placementIdx].VenueIdx][si] = true
+				}
+				for _, cohortidx := range sessionAtom.CohortIdxs {
+					if cohortidx < len(cohortOcc) && si < len(cohortOcc[cohortidx]) {
+						cohortOcc[cohortidx][si] = true
+					}
+				}
+			}
+		}
+	}
+}
+
+// the placement of a pinned session, never changes between candidates
+func legacyPinnedPlacement(session *computed.SessionAtom) computed.SessionPlacement {
+	return computed.SessionPlacement{
+		SessionIdx: session.SessionIdx,
+		CourseIdx:  session.CourseIdx,
+		VenueIdx:   session.PinnedVenueIdx,
+		SlotIdx:    session.PinnedSlotIdx,
+		Conflict:   false,
+		Score:      0.0,
+	}
+}
+
+// marks the slots, venue, lecturers and cohorts of every pinned session as taken
+func legacyMarkPinned(pre *computed.PreComputed, venueOcc [][]bool, lecturerOcc [][]bool, cohortOcc [][]bool) {
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		if !session.Pinned {
+			continue
+		}
+		for d := 0; d < session.SessionDuration; d++ {
+			si := session.PinnedSlotIdx + d
+			if si >= pre.TotalSlots {
+				break
+			}
+			if session.PinnedVenueIdx < len(venueOcc) {
+				venueOcc[session.PinnedVenueIdx][si] = true
+			}
+			for _, lecturerIdx := range session.LecturerIdxs {
+				if lecturerIdx < len(lecturerOcc) {
+					lecturerOcc[lecturerIdx][si] = true
+				}
+			}
+			for _, cohortIdx := range session.CohortIdxs {
+				if cohortIdx < len(cohortOcc) {
+					cohortOcc[cohortIdx][si] = true
+				}
+			}
+		}
+	}
+}
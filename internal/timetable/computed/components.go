@@ -0,0 +1,74 @@
+package computed
+
+import (
+	"sort"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+const (
+	SessionTypeLecture  = "LECTURE"
+	SessionTypeLab      = "LAB"
+	SessionTypeTutorial = "TUTORIAL"
+)
+
+// one kind of session a course has every week, e.g a 2 hour lecture twice a week in a hall
+type CourseComponent struct {
+	SessionType      string
+	DurationHours    int
+	SessionsPerWeek  int
+	VenueKind        string   // the kind of venue the sessions need, empty for any venue of the course
+	RequiredFeatures []string // the venue features the sessions need, see AttachRequiredFeatures
+	MaxGroupSize     int      // the course's when the component has none, 0 to never split the class
+}
+
+// the order the components of a course are turned into sessions in, so session numbers stay put
+func sessionTypeOrder(sessionType string) int {
+	switch sessionType {
+	case SessionTypeLecture:
+		return 0
+	case SessionTypeLab:
+		return 1
+	case SessionTypeTutorial:
+		return 2
+	}
+	return 3
+}
+
+// sets the components of every course, a course with none gets a single lecture component made
+// of its course_duration and sessions_per_week
+func AttachCourseComponents(courseData []modifiedCourseAndVenueData, componentRows []sqlc.RetrieveCourseComponentsForUniRow) []modifiedCourseAndVenueData {
+	components := make(map[uuid.UUID][]CourseComponent)
+	for _, row := range componentRows {
+		components[row.CourseID] = append(components[row.CourseID], CourseComponent{
+			SessionType:     row.SessionType,
+			DurationHours:   int(row.Duration),
+			SessionsPerWeek: int(row.SessionsPerWeek),
+			VenueKind:       row.VenueKind.String,
+			MaxGroupSize:    int(row.MaxGroupSize.Int32),
+		})
+	}
+	for i, course := range courseData {
+		list, ok := components[course.CourseId]
+		if !ok {
+			courseData[i].Components = []CourseComponent{{
+				SessionType:     SessionTypeLecture,
+				DurationHours:   int(course.CourseDuration),
+				SessionsPerWeek: int(course.SessionsPerWeek),
+				MaxGroupSize:    course.MaxGroupSize,
+			}}
+			continue
+		}
+		for j := range list {
+			if list[j].MaxGroupSize == 0 {
+				list[j].MaxGroupSize = course.MaxGroupSize
+			}
+		}
+		sort.SliceStable(list, func(a, b int) bool {
+			return sessionTypeOrder(list[a].SessionType) < sessionTypeOrder(list[b].SessionType)
+		})
+		courseData[i].Components = list
+	}
+	return courseData
+}
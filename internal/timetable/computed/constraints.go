@@ -0,0 +1,513 @@
+package computed
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// a soft rule a whole candidate is scored against.
+// the penalty of a constraint is its weight times whatever Evaluate returns, or 1500 times it
+// for a constraint the university made hard
+type Constraint interface {
+	Name() string
+	Weight() float64
+	// how badly the candidate breaks the rule e.g number of idle hours, 0 means it is respected.
+	// buf is the scratch space of the worker scoring the candidate
+	Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64
+	// every place the candidate breaks the rule, the amounts add up to Evaluate
+	Violations(pre *PreComputed, cand *Candidate) []Violation
+}
+
+const (
+	MaxConsecutiveHours   = "MAX_CONSECUTIVE_HOURS"
+	NoIdleGaps            = "NO_IDLE_GAPS"
+	LunchBreak            = "LUNCH_BREAK"
+	CourseDaySpread       = "COURSE_DAY_SPREAD"
+	LecturerMaxDailyHours = "LECTURER_MAX_DAILY_HOURS"
+	TravelTime            = "TRAVEL_TIME"
+	VenueOwnership        = "VENUE_OWNERSHIP"
+)
+
+// how a university has configured one constraint
+type ConstraintSetting struct {
+	Name       string
+	Enabled    bool
+	Weight     float64
+	LimitValue float64 // meaning depends on the constraint e.g hours, or the hour lunch starts
+	Hard       bool    // every breach costs as much as a clash instead of the weight
+}
+
+// builds a constraint from its weight and limit
+type constraintFactory func(weight float64, limit float64) Constraint
+
+var constraintRegistry = map[string]constraintFactory{
+	MaxConsecutiveHours: func(weight float64, limit float64) Constraint {
+		return maxConsecutiveHoursConstraint{weight: weight, maxHours: limit}
+	},
+	NoIdleGaps: func(weight float64, limit float64) Constraint {
+		return noIdleGapsConstraint{weight: weight}
+	},
+	LunchBreak: func(weight float64, limit float64) Constraint {
+		return lunchBreakConstraint{weight: weight, lunchHour: limit}
+	},
+	CourseDaySpread: func(weight float64, limit float64) Constraint {
+		return courseDaySpreadConstraint{weight: weight}
+	},
+	LecturerMaxDailyHours: func(weight float64, limit float64) Constraint {
+		return lecturerMaxDailyHoursConstraint{weight: weight, maxHours: limit}
+	},
+	TravelTime: func(weight float64, limit float64) Constraint {
+		return travelTimeConstraint{weight: weight, changeoverMinutes: limit}
+	},
+	VenueOwnership: func(weight float64, limit float64) Constraint {
+		return venueOwnershipConstraint{weight: weight}
+	},
+}
+
+// the settings used for a university that has not configured its constraints
+func DefaultConstraintSettings() []ConstraintSetting {
+	return []ConstraintSetting{
+		{Name: MaxConsecutiveHours, Enabled: true, Weight: 50, LimitValue: 3},
+		{Name: NoIdleGaps, Enabled: true, Weight: 10},
+		{Name: LunchBreak, Enabled: true, Weight: 20, LimitValue: 13},
+		{Name: CourseDaySpread, Enabled: true, Weight: 30},
+		{Name: LecturerMaxDailyHours, Enabled: true, Weight: 40, LimitValue: 6},
+		{Name: TravelTime, Enabled: true, Weight: 30, LimitValue: 10},
+		{Name: VenueOwnership, Enabled: true, Weight: 5},
+	}
+}
+
+func IsKnownConstraint(name string) bool {
+	_, ok := constraintRegistry[name]
+	return ok
+}
+
+// builds the enabled constraints in name order so fitness is summed the same way on every run
+func BuildConstraints(settings []ConstraintSetting) ([]Constraint, error) {
+	sorted := make([]ConstraintSetting, len(settings))
+	copy(sorted, settings)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	constraints := make([]Constraint, 0, len(sorted))
+	for _, setting := range sorted {
+		factory, ok := constraintRegistry[setting.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown constraint %q", setting.Name)
+		}
+		if setting.Weight < 0 {
+			return nil, fmt.Errorf("constraint %q has a negative weight", setting.Name)
+		}
+		if !setting.Enabled {
+			continue
+		}
+		constraint := factory(setting.Weight, setting.LimitValue)
+		if setting.Hard {
+			constraint = hardConstraint{constraint}
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+// a constraint the university made hard, its breaches are reported with the hard violations and
+// cost as much as a clash so the solver treats them the same way
+type hardConstraint struct {
+	Constraint
+}
+
+func isHard(constraint Constraint) bool {
+	_, ok := constraint.(hardConstraint)
+	return ok
+}
+
+// sums the penalty of every constraint the candidate is scored against, the hard ones apart from
+// the soft ones. a nil buf scores in buffers of its own
+func ComputeConstraintPenalties(pre *PreComputed, cand *Candidate, buf *SearchBuffers) (float64, float64) {
+	if buf == nil {
+		buf = newScoringSearchBuffers(pre)
+	}
+	hardPenalty, softPenalty := 0.0, 0.0
+	for _, constraint := range pre.Constraints {
+		if isHard(constraint) {
+			hardPenalty += 1500 * constraint.Evaluate(pre, cand, buf)
+			continue
+		}
+		softPenalty += constraint.Weight() * constraint.Evaluate(pre, cand, buf)
+	}
+	return hardPenalty, softPenalty
+}
+
+// converts hours to a number of slots, never less than one
+func hoursToSlots(pre *PreComputed, hours float64) int {
+	slotMinutes := pre.SlotMinutes
+	if slotMinutes <= 0 {
+		slotMinutes = 60
+	}
+	slots := int(math.Round(hours * 60 / float64(slotMinutes)))
+	if slots < 1 {
+		slots = 1
+	}
+	return slots
+}
+
+// marks every slot each row of the cohort occupancy is busy in for a candidate on the grid of
+// the buffers. electives and the groups of a split course book rows of their own, so no row has
+// sessions no student attends all of
+func cohortBusySlots(pre *PreComputed, cand *Candidate, buf *SearchBuffers) *Occupancy {
+	busy := buf.cohortBusy
+	busy.Reset()
+	for _, placement := range cand.Placements {
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) || placement.SlotIdx < 0 {
+			continue
+		}
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		for _, c := range session.bookedCohortKeys() {
+			busy.SetRange(c, placement.SlotIdx, session.SessionDuration)
+		}
+	}
+	return busy
+}
+
+// marks every slot each lecturer is busy in for a candidate on the grid of the buffers
+func lecturerBusySlots(pre *PreComputed, cand *Candidate, buf *SearchBuffers) *Occupancy {
+	busy := buf.lecturerBusy
+	busy.Reset()
+	for _, placement := range cand.Placements {
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) || placement.SlotIdx < 0 {
+			continue
+		}
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		for _, l := range session.LecturerIdxs {
+			busy.SetRange(l, placement.SlotIdx, session.SessionDuration)
+		}
+	}
+	return busy
+}
+
+func numDays(pre *PreComputed) int {
+	if pre.SlotsPerDay <= 0 {
+		return 0
+	}
+	return pre.TotalSlots / pre.SlotsPerDay
+}
+
+// one place a candidate breaks a soft constraint, found while evaluating it
+type breach struct {
+	slotIdx int // first slot of the breach
+	slots   int
+	amount  int    // what the breach adds to Evaluate
+	count   int    // what was counted e.g the length of a run, used in messages
+	idx     int    // the cohort, lecturer or course that breaks the rule
+	venues  [2]int // the venues the breach is about, used in messages
+	nVenues int
+}
+
+func (b breach) venueIdxs() []int {
+	return append([]int(nil), b.venues[:b.nVenues]...)
+}
+
+// what walking a candidate found, the amounts of the breaches added up and, while violations
+// are collected, every breach. Evaluate calls its visitor directly with one on the stack so
+// scoring a candidate allocates nothing
+type breaches struct {
+	total   int
+	collect func(b breach)
+}
+
+func (found *breaches) report(b breach) {
+	found.total += b.amount
+	if found.collect != nil {
+		found.collect(b)
+	}
+}
+
+// walks a candidate and reports every breach of a constraint
+type breachVisitor func(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches)
+
+// turns the breaches of a constraint into violations, message builds the text of one breach
+func collectBreaches(c Constraint, visit breachVisitor, pre *PreComputed, cand *Candidate, message func(b breach) Violation) []Violation {
+	violations := make([]Violation, 0)
+	visit(pre, cand, newScoringSearchBuffers(pre), &breaches{collect: func(b breach) {
+		v := message(b)
+		v.Type = c.Name()
+		v.SlotIdx = b.slotIdx
+		v.Slots = b.slots
+		v.Amount = float64(b.amount)
+		v.Penalty = c.Weight() * v.Amount
+		violations = append(violations, v)
+	}})
+	return violations
+}
+
+// a cohort should not sit through more than maxHours in a row
+type maxConsecutiveHoursConstraint struct {
+	weight   float64
+	maxHours float64
+}
+
+func (c maxConsecutiveHoursConstraint) Name() string    { return MaxConsecutiveHours }
+func (c maxConsecutiveHoursConstraint) Weight() float64 { return c.weight }
+
+// every slot past the limit in a run counts once
+func (c maxConsecutiveHoursConstraint) Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64 {
+	var found breaches
+	c.visit(pre, cand, buf, &found)
+	return float64(found.total)
+}
+
+func (c maxConsecutiveHoursConstraint) Violations(pre *PreComputed, cand *Candidate) []Violation {
+	return collectBreaches(c, c.visit, pre, cand, func(b breach) Violation {
+		return Violation{
+			CohortIdxs: cohortKeyCohorts(pre, b.idx),
+			Message:    fmt.Sprintf("%s has %d slots in a row, %d more than allowed", cohortKeyName(pre, b.idx), b.count, b.amount),
+		}
+	})
+}
+
+func (c maxConsecutiveHoursConstraint) visit(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	maxSlots := hoursToSlots(pre, c.maxHours)
+	busy := cohortBusySlots(pre, cand, buf)
+	for key := 0; key < busy.Rows(); key++ {
+		slots := busy.Row(key)
+		for day := 0; day < numDays(pre); day++ {
+			dayEnd := (day + 1) * pre.SlotsPerDay
+			run := 0
+			for s := day * pre.SlotsPerDay; s <= dayEnd; s++ {
+				if s < dayEnd && slots.Test(s) {
+					run++
+					continue
+				}
+				if run > maxSlots {
+					found.report(breach{slotIdx: s - run, slots: run, amount: run - maxSlots, count: run, idx: key})
+				}
+				run = 0
+			}
+		}
+	}
+}
+
+// a cohort should not have free slots between its first and last session of a day
+type noIdleGapsConstraint struct {
+	weight float64
+}
+
+func (c noIdleGapsConstraint) Name() string    { return NoIdleGaps }
+func (c noIdleGapsConstraint) Weight() float64 { return c.weight }
+
+func (c noIdleGapsConstraint) Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64 {
+	var found breaches
+	c.visit(pre, cand, buf, &found)
+	return float64(found.total)
+}
+
+func (c noIdleGapsConstraint) Violations(pre *PreComputed, cand *Candidate) []Violation {
+	return collectBreaches(c, c.visit, pre, cand, func(b breach) Violation {
+		return Violation{
+			CohortIdxs: cohortKeyCohorts(pre, b.idx),
+			Message:    fmt.Sprintf("%s has %d free slots between its first and last session of the day", cohortKeyName(pre, b.idx), b.count),
+		}
+	})
+}
+
+func (c noIdleGapsConstraint) visit(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	busy := cohortBusySlots(pre, cand, buf)
+	for key := 0; key < busy.Rows(); key++ {
+		slots := busy.Row(key)
+		for day := 0; day < numDays(pre); day++ {
+			first, last := -1, -1
+			for s := day * pre.SlotsPerDay; s < (day+1)*pre.SlotsPerDay; s++ {
+				if slots.Test(s) {
+					if first == -1 {
+						first = s
+					}
+					last = s
+				}
+			}
+			gaps := 0
+			for s := first + 1; first != -1 && s < last; s++ {
+				if !slots.Test(s) {
+					gaps++
+				}
+			}
+			if gaps > 0 {
+				found.report(breach{slotIdx: first, slots: last - first + 1, amount: gaps, count: gaps, idx: key})
+			}
+		}
+	}
+}
+
+// a cohort with classes on a day should be free for the hour starting at lunchHour (e.g 13 for 1pm)
+type lunchBreakConstraint struct {
+	weight    float64
+	lunchHour float64
+}
+
+func (c lunchBreakConstraint) Name() string    { return LunchBreak }
+func (c lunchBreakConstraint) Weight() float64 { return c.weight }
+
+func (c lunchBreakConstraint) Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64 {
+	var found breaches
+	c.visit(pre, cand, buf, &found)
+	return float64(found.total)
+}
+
+func (c lunchBreakConstraint) Violations(pre *PreComputed, cand *Candidate) []Violation {
+	return collectBreaches(c, c.visit, pre, cand, func(b breach) Violation {
+		return Violation{
+			CohortIdxs: cohortKeyCohorts(pre, b.idx),
+			Message:    fmt.Sprintf("%s has class in %d slots of the lunch hour", cohortKeyName(pre, b.idx), b.count),
+		}
+	})
+}
+
+func (c lunchBreakConstraint) visit(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	slotMinutes := pre.SlotMinutes
+	if slotMinutes <= 0 {
+		slotMinutes = 60
+	}
+	lunchStart := int(c.lunchHour*60) - pre.DayStartMinutes
+	lunchEnd := lunchStart + 60
+
+	// the slots of the day that overlap the lunch hour, they are next to each other
+	firstLunch, lunchSlots := -1, 0
+	for s := 0; s < pre.SlotsPerDay; s++ {
+		slotStart := s * slotMinutes
+		if slotStart < lunchEnd && slotStart+slotMinutes > lunchStart {
+			if firstLunch == -1 {
+				firstLunch = s
+			}
+			lunchSlots++
+		}
+	}
+	if lunchSlots == 0 {
+		return
+	}
+
+	busy := cohortBusySlots(pre, cand, buf)
+	for key := 0; key < busy.Rows(); key++ {
+		slots := busy.Row(key)
+		for day := 0; day < numDays(pre); day++ {
+			start := day*pre.SlotsPerDay + firstLunch
+			if clashes := slots.CountInRange(start, lunchSlots); clashes > 0 {
+				found.report(breach{slotIdx: start, slots: lunchSlots, amount: clashes, count: clashes, idx: key})
+			}
+		}
+	}
+}
+
+// sessions of the same course should fall on different days
+type courseDaySpreadConstraint struct {
+	weight float64
+}
+
+func (c courseDaySpreadConstraint) Name() string    { return CourseDaySpread }
+func (c courseDaySpreadConstraint) Weight() float64 { return c.weight }
+
+// every extra session of a course on a day it already has one counts once. the sessions of the
+// whole class and of every group of a split course are counted apart, since the parallel groups
+// of a course on the same day are different students
+func (c courseDaySpreadConstraint) Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64 {
+	var found breaches
+	c.visit(pre, cand, buf, &found)
+	return float64(found.total)
+}
+
+func (c courseDaySpreadConstraint) Violations(pre *PreComputed, cand *Candidate) []Violation {
+	return collectBreaches(c, c.visit, pre, cand, func(b breach) Violation {
+		if b.idx < pre.NumCourses {
+			return Violation{
+				CourseIdxs: []int{b.idx},
+				Message:    fmt.Sprintf("%s has %d sessions on the same day", courseCode(pre, b.idx), b.count),
+			}
+		}
+		key := b.idx - pre.NumCourses
+		return Violation{
+			CourseIdxs: groupKeyCourses(pre, key),
+			Message:    fmt.Sprintf("%s has %d sessions on the same day", cohortKeyName(pre, key), b.count),
+		}
+	})
+}
+
+// the row a placement is counted on, its course for a session of the whole class and after the
+// courses the cohort occupancy row of its group for a session of one group
+func spreadRow(pre *PreComputed, placement SessionPlacement) int {
+	if placement.SessionIdx >= 0 && placement.SessionIdx < len(pre.SessionAtoms) {
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		if session.isGroup() && len(session.CohortKeys) == 1 {
+			return pre.NumCourses + session.CohortKeys[0]
+		}
+	}
+	return placement.CourseIdx
+}
+
+func (c courseDaySpreadConstraint) visit(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	days := numDays(pre)
+	if days == 0 {
+		return
+	}
+	perDay := buf.courseDays
+	clear(perDay)
+	for _, placement := range cand.Placements {
+		if placement.CourseIdx < 0 || placement.CourseIdx >= pre.NumCourses {
+			continue
+		}
+		day := placement.SlotIdx / pre.SlotsPerDay
+		if day < 0 || day >= days {
+			continue
+		}
+		perDay[spreadRow(pre, placement)*days+day]++
+	}
+	for key, count := range perDay {
+		if count > 1 {
+			day := key % days
+			found.report(breach{slotIdx: day * pre.SlotsPerDay, slots: pre.SlotsPerDay, amount: count - 1, count: count, idx: key / days})
+		}
+	}
+}
+
+// a lecturer should not teach more than maxHours in a day
+type lecturerMaxDailyHoursConstraint struct {
+	weight   float64
+	maxHours float64
+}
+
+func (c lecturerMaxDailyHoursConstraint) Name() string    { return LecturerMaxDailyHours }
+func (c lecturerMaxDailyHoursConstraint) Weight() float64 { return c.weight }
+
+// every slot taught past the limit counts once
+func (c lecturerMaxDailyHoursConstraint) Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64 {
+	var found breaches
+	c.visit(pre, cand, buf, &found)
+	return float64(found.total)
+}
+
+func (c lecturerMaxDailyHoursConstraint) Violations(pre *PreComputed, cand *Candidate) []Violation {
+	return collectBreaches(c, c.visit, pre, cand, func(b breach) Violation {
+		return Violation{
+			LecturerIdxs: []int{b.idx},
+			Message:      fmt.Sprintf("%s teaches %d slots in a day, %d more than allowed", lecturerName(pre, b.idx), b.count, b.amount),
+		}
+	})
+}
+
+func (c lecturerMaxDailyHoursConstraint) visit(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	maxSlots := hoursToSlots(pre, c.maxHours)
+	busy := lecturerBusySlots(pre, cand, buf)
+	for lecturerIdx := 0; lecturerIdx < busy.Rows(); lecturerIdx++ {
+		slots := busy.Row(lecturerIdx)
+		for day := 0; day < numDays(pre); day++ {
+			taught := 0
+			for s := day * pre.SlotsPerDay; s < (day+1)*pre.SlotsPerDay; s++ {
+				if slots.Test(s) {
+					taught++
+				}
+			}
+			if taught > maxSlots {
+				found.report(breach{slotIdx: day * pre.SlotsPerDay, slots: pre.SlotsPerDay, amount: taught - maxSlots, count: taught, idx: lecturerIdx})
+			}
+		}
+	}
+}
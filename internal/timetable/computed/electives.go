@@ -0,0 +1,203 @@
+package computed
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+// the courses of a cohort it takes exactly one of, they may run at the same time for the cohort
+type ElectiveGroup struct {
+	CohortIdx  int
+	CourseIdxs []int
+}
+
+// the rows of the cohort occupancy the session books, its cohorts when no cohort has elective groups
+func (s *SessionAtom) bookedCohortKeys() []int {
+	if s.CohortKeys == nil {
+		return s.CohortIdxs
+	}
+	return s.CohortKeys
+}
+
+// the rows of the cohort occupancy that must be free where the session goes
+func (s *SessionAtom) clashCohortKeys() []int {
+	if s.ClashKeys == nil {
+		return s.CohortIdxs
+	}
+	return s.ClashKeys
+}
+
+// the rows of the cohort occupancy, one per cohort and one per key AssignCohortKeys adds
+func cohortKeyRows(pre *PreComputed) int {
+	return max(pre.NumCohorts, pre.NumCohortKeys)
+}
+
+// the cohorts of the students behind a row of the cohort occupancy
+func cohortKeyCohorts(pre *PreComputed, key int) []int {
+	if key < pre.NumCohorts {
+		return []int{key}
+	}
+	cohorts := make([]int, 0)
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		if sharesAny(session.bookedCohortKeys(), []int{key}) {
+			cohorts = appendUnique(cohorts, session.CohortIdxs...)
+		}
+	}
+	return cohorts
+}
+
+// names the students behind a row of the cohort occupancy in messages, the cohort itself for the
+// first NumCohorts rows, e.g "group 2 of MTH204 in CSC 300L" or "CSC 300L taking MTH204" after them
+func cohortKeyName(pre *PreComputed, key int) string {
+	if key < pre.NumCohorts {
+		return cohortName(pre, key)
+	}
+	courses := make([]int, 0)
+	var group *SessionAtom
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		if !sharesAny(session.bookedCohortKeys(), []int{key}) {
+			continue
+		}
+		courses = appendUnique(courses, session.CourseIdx)
+		if session.isGroup() {
+			group = session
+		}
+	}
+	cohorts := strings.Join(namesOf(cohortKeyCohorts(pre, key), func(idx int) string { return cohortName(pre, idx) }), ", ")
+	if group != nil {
+		return fmt.Sprintf("group %d of %s in %s", group.GroupIdx+1, courseCode(pre, group.CourseIdx), cohorts)
+	}
+	return fmt.Sprintf("%s taking %s", cohorts, strings.Join(namesOf(courses, func(idx int) string { return courseCode(pre, idx) }), " and "))
+}
+
+// true if the two sessions may not overlap because of a cohort or a student they share
+func cohortsClash(a *SessionAtom, b *SessionAtom) bool {
+	return sharesAny(a.clashCohortKeys(), b.bookedCohortKeys())
+}
+
+// decides which rows of the cohort occupancy every session books and which it must find free,
+// so the electives of a group can share a slot of their cohort. the first NumCohorts rows are
+// the cohorts, after them every elective course gets a row only its own sessions book. a session
+// a whole cohort takes books the row of the cohort and clashes with the rows of all its electives,
+// an elective clashes with the cohort, itself and the electives of the other groups of the cohort.
+// every pair of courses of a group in sharedStudents, pairs of course idxs some real student takes
+// both of, gets a row both book so they never overlap either. the groups of a split course get
+// rows last, see assignGroupKeys. no row is booked by two sessions that may overlap, so
+// unbooking one never frees a slot the other still holds
+func AssignCohortKeys(pre *PreComputed, groups []ElectiveGroup, sharedStudents [][2]int) {
+	next := pre.NumCohorts
+	courseKeys := make(map[int]int)
+	courseKey := func(courseIdx int) int {
+		key, ok := courseKeys[courseIdx]
+		if !ok {
+			key = next
+			next++
+			courseKeys[courseIdx] = key
+		}
+		return key
+	}
+	groupOf := make(map[[2]int]int)           // [cohortIdx, courseIdx] to the group
+	cohortElectives := make(map[int][][2]int) // cohortIdx to the [group, row] of its electives
+	for g, group := range groups {
+		for _, courseIdx := range group.CourseIdxs {
+			// a course in two groups of the same cohort stays in the first
+			if _, ok := groupOf[[2]int{group.CohortIdx, courseIdx}]; ok {
+				continue
+			}
+			groupOf[[2]int{group.CohortIdx, courseIdx}] = g
+			cohortElectives[group.CohortIdx] = append(cohortElectives[group.CohortIdx], [2]int{g, courseKey(courseIdx)})
+		}
+	}
+
+	// only the pairs that would otherwise be allowed to overlap need a row
+	pairKeys := make(map[int][]int)
+	for _, pair := range sharedStudents {
+		sameGroup := false
+		for _, group := range groups {
+			first, firstOk := groupOf[[2]int{group.CohortIdx, pair[0]}]
+			second, secondOk := groupOf[[2]int{group.CohortIdx, pair[1]}]
+			if firstOk && secondOk && first == second {
+				sameGroup = true
+				break
+			}
+		}
+		if !sameGroup || pair[0] == pair[1] {
+			continue
+		}
+		pairKeys[pair[0]] = append(pairKeys[pair[0]], next)
+		pairKeys[pair[1]] = append(pairKeys[pair[1]], next)
+		next++
+	}
+
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		booked, clash := make([]int, 0, len(session.CohortIdxs)), make([]int, 0, len(session.CohortIdxs))
+		for _, cohortIdx := range session.CohortIdxs {
+			g, elective := groupOf[[2]int{cohortIdx, session.CourseIdx}]
+			if !elective {
+				booked = appendUnique(booked, cohortIdx)
+				clash = appendUnique(clash, cohortIdx)
+				for _, other := range cohortElectives[cohortIdx] {
+					clash = appendUnique(clash, other[1])
+				}
+				continue
+			}
+			own := courseKeys[session.CourseIdx]
+			booked = appendUnique(booked, own)
+			clash = appendUnique(clash, cohortIdx, own)
+			for _, other := range cohortElectives[cohortIdx] {
+				if other[0] != g {
+					clash = appendUnique(clash, other[1])
+				}
+			}
+		}
+		booked = appendUnique(booked, pairKeys[session.CourseIdx]...)
+		clash = appendUnique(clash, pairKeys[session.CourseIdx]...)
+		session.CohortKeys = booked
+		session.ClashKeys = clash
+	}
+	next = assignGroupKeys(pre, next)
+	pre.NumCohortKeys = next
+	pre.ElectiveGroups = groups
+	pre.SharedStudents = sharedStudents
+}
+
+// the elective groups and the pairs of their courses students share as idxs, rows of cohorts or
+// courses that are not scheduled are left out
+func electivesFromRows(groupRows []sqlc.RetrieveElectiveGroupCoursesRow, overlapRows []sqlc.RetrieveElectiveStudentOverlapsRow, cohortMap map[uuid.UUID]int, coursesMap map[uuid.UUID]int) ([]ElectiveGroup, [][2]int) {
+	sort.Slice(groupRows, func(i, j int) bool {
+		return bytes.Compare(groupRows[i].ElectiveGroupID[:], groupRows[j].ElectiveGroupID[:]) < 0
+	})
+	groups := make([]ElectiveGroup, 0)
+	groupIdx := make(map[uuid.UUID]int)
+	for _, row := range groupRows {
+		cohortIdx, cohortOk := cohortMap[row.CohortID]
+		courseIdx, courseOk := coursesMap[row.CourseID]
+		if !cohortOk || !courseOk {
+			continue
+		}
+		g, ok := groupIdx[row.ElectiveGroupID]
+		if !ok {
+			g = len(groups)
+			groupIdx[row.ElectiveGroupID] = g
+			groups = append(groups, ElectiveGroup{CohortIdx: cohortIdx})
+		}
+		groups[g].CourseIdxs = append(groups[g].CourseIdxs, courseIdx)
+	}
+	sharedStudents := make([][2]int, 0, len(overlapRows))
+	for _, row := range overlapRows {
+		first, firstOk := coursesMap[row.FirstCourseID]
+		second, secondOk := coursesMap[row.SecondCourseID]
+		if firstOk && secondOk {
+			sharedStudents = append(sharedStudents, [2]int{first, second})
+		}
+	}
+	return groups, sharedStudents
+}
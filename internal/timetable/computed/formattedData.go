@@ -1,9 +1,11 @@
 package computed
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,6 +25,11 @@ func NewComputed(repo repository.TimetableRepository) *Computed {
     }
 }
 
+const (
+	LecturerModeTeam   = "TEAM"   // every lecturer of the course teaches each session together
+	LecturerModeRotate = "ROTATE" // sessions take turns between the lecturers of the course
+)
+
 type modifiedCourseAndVenueData struct{
 	CourseId uuid.UUID
 	CourseCode string
@@ -30,13 +37,29 @@ type modifiedCourseAndVenueData struct{
 	CourseCreditiUnit int32
 	CourseDuration int32
 	DepartmentId uuid.UUID
+	FacultyId uuid.UUID
 	UniversityId uuid.UUID
 	LecturerId uuid.NullUUID
+	LecturerIds []uuid.UUID // courses.lecturer_id and everyone in courses_lecturers, sorted
+	LecturerMode string
 	SessionsPerWeek int32
 	Cohorts []uuid.UUID
 	Level int32
 	Semester string
-	PossibleVenues []uuid.UUID
+	PossibleVenues []uuid.UUID // courses_possible_venues, when empty the venues are derived, see derivedCourseVenues
+	Components []CourseComponent // the kinds of session the course has, see AttachCourseComponents
+	MaxGroupSize int // a bigger class is split into parallel groups, 0 to never split it
+}
+
+func uuidLess(a uuid.UUID, b uuid.UUID) bool {
+	return bytes.Compare(a[:], b[:]) < 0
+}
+
+// sorts rows by their ids so the idx of everything never depends on the order postgres returns rows in
+func sortByUUID[T any](rows []T, id func(T) uuid.UUID) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return uuidLess(id(rows[i]), id(rows[j]))
+	})
 }
 
 // maps all cohorts ids to idx
@@ -49,7 +72,7 @@ func MapCohortIdToIdx(cohorts []sqlc.Cohort)map[uuid.UUID]int{
 }
 
 // maps all courses ids to idx
-func MapCoursesIdtoIdx(courses []sqlc.RetrieveAllCoursesRow)map[uuid.UUID]int{
+func MapCoursesIdtoIdx(courses []sqlc.RetrieveCoursesForSemesterRow)map[uuid.UUID]int{
 	coursesMap := make(map[uuid.UUID]int)
 	for i,v := range courses{
 		coursesMap[v.CourseID] = i
@@ -116,18 +139,26 @@ func ModifyCourseData(courseData []sqlc.RetrieveAllCoursesAndTheirVenueIdsRow) [
                 CourseTitle:       v.CourseTitle,
                 CourseCreditiUnit: v.CourseCreditUnit,
                 CourseDuration:    v.CourseDuration,
+                DepartmentId:      v.DepartmentID,
+                FacultyId:         v.FacultyID,
                 SessionsPerWeek:   v.SessionsPerWeek,
                 Semester:          v.Semester,
-                PossibleVenues:    []uuid.UUID{v.VenueID},
+                LecturerMode:      v.LecturerMode,
+                MaxGroupSize:      int(v.MaxGroupSize.Int32),
+                PossibleVenues:    []uuid.UUID{},
                 LecturerId:        v.LecturerID, // PRESERVE THE LECTURER ID
             }
+            // a course without possible venues comes back once with no venue
+            if v.VenueID.Valid {
+                course.PossibleVenues = append(course.PossibleVenues, v.VenueID.UUID)
+            }
             courseDataMap[v.CourseID] = course
             slog.Info("Created new course entry with lecturer", 
                 "courseId", v.CourseID,
                 "lecturerValid", v.LecturerID.Valid)
-        } else {
+        } else if v.VenueID.Valid {
             // Course already exists - just add the venue
-            course.PossibleVenues = append(course.PossibleVenues, v.VenueID)
+            course.PossibleVenues = append(course.PossibleVenues, v.VenueID.UUID)
             courseDataMap[v.CourseID] = course
             slog.Info("Added venue to existing course", 
                 "courseId", v.CourseID,
@@ -146,29 +177,113 @@ func ModifyCourseData(courseData []sqlc.RetrieveAllCoursesAndTheirVenueIdsRow) [
             "lecturerUUID", course.LecturerId.UUID,
             "venues", len(course.PossibleVenues))
     }
+    // map iteration order is random, sessions atoms must come out in the same order on every run
+    sortByUUID(modifiedData, func(c modifiedCourseAndVenueData) uuid.UUID { return c.CourseId })
 
     slog.Info("=== DEBUG ModifyCourseData END ===", "courses", len(modifiedData))
     return modifiedData
 }
 
 
+// merges the lecturers from courses_lecturers into every course, the course's own lecturer_id counts as one of them
+func AttachCourseLecturers(courseData []modifiedCourseAndVenueData, courseLecturers []sqlc.RetrieveCourseLecturersForUniRow) []modifiedCourseAndVenueData {
+	extra := make(map[uuid.UUID][]uuid.UUID)
+	for _, row := range courseLecturers {
+		extra[row.CourseID] = append(extra[row.CourseID], row.LecturerID)
+	}
+
+	for i, course := range courseData {
+		seen := make(map[uuid.UUID]bool)
+		lecturerIds := make([]uuid.UUID, 0, len(extra[course.CourseId])+1)
+		if course.LecturerId.Valid {
+			seen[course.LecturerId.UUID] = true
+			lecturerIds = append(lecturerIds, course.LecturerId.UUID)
+		}
+		for _, lecturerId := range extra[course.CourseId] {
+			if !seen[lecturerId] {
+				seen[lecturerId] = true
+				lecturerIds = append(lecturerIds, lecturerId)
+			}
+		}
+		// the rotation order has to be the same on every run
+		sort.Slice(lecturerIds, func(a, b int) bool {
+			return uuidLess(lecturerIds[a], lecturerIds[b])
+		})
+		courseData[i].LecturerIds = lecturerIds
+	}
+	return courseData
+}
+
+// the lecturers needed for the nth session of a course
+func sessionLecturerIdxs(lecturerIdxs []int, mode string, n int) []int {
+	if mode == LecturerModeRotate {
+		return []int{lecturerIdxs[n%len(lecturerIdxs)]}
+	}
+	return lecturerIdxs
+}
+
+// the size of every cohort by idx. an explicit cohort_size wins, otherwise the students at that level in the department are counted
+func ComputeCohortSizes(cohorts []sqlc.Cohort, studentCounts []sqlc.RetrieveCohortStudentCountsRow, cohortMap map[uuid.UUID]int) []int {
+	sizes := make([]int, len(cohortMap))
+	for _, row := range studentCounts {
+		if idx, ok := cohortMap[row.CohortID]; ok {
+			sizes[idx] = int(row.StudentCount)
+		}
+	}
+	for _, cohort := range cohorts {
+		idx, ok := cohortMap[cohort.CohortID]
+		if ok && cohort.CohortSize.Valid {
+			sizes[idx] = int(cohort.CohortSize.Int32)
+		}
+	}
+	return sizes
+}
+
+// the students enrolled in every course by idx, the students GetStudentTimetableSessions deals out
+// to the groups of a split course
+func ComputeCourseSizes(studentCounts []sqlc.RetrieveCourseStudentCountsRow, courseMap map[uuid.UUID]int) []int {
+	sizes := make([]int, len(courseMap))
+	for _, row := range studentCounts {
+		if idx, ok := courseMap[row.CourseID]; ok {
+			sizes[idx] = int(row.StudentCount)
+		}
+	}
+	return sizes
+}
+
+// the capacity of every venue by idx
+func ComputeVenueCapacities(venues []sqlc.RetrieveAllVenuesRow, venueMap map[uuid.UUID]int) []int {
+	capacities := make([]int, len(venueMap))
+	for _, venue := range venues {
+		if idx, ok := venueMap[venue.VenueID]; ok {
+			capacities[idx] = int(venue.Capacity)
+		}
+	}
+	return capacities
+}
 
-func CreateSessionAtoms(lecturerMap map[uuid.UUID]int, venueMap map[uuid.UUID]int, courseMap map[uuid.UUID]int, cohortMap map[uuid.UUID]int, courseData []modifiedCourseAndVenueData, cohortCourseData map[uuid.UUID][]uuid.UUID) []SessionAtom {
+func CreateSessionAtoms(lecturerMap map[uuid.UUID]int, venueMap map[uuid.UUID]int, courseMap map[uuid.UUID]int, cohortMap map[uuid.UUID]int, courseData []modifiedCourseAndVenueData, cohortCourseData map[uuid.UUID][]uuid.UUID, cohortSizes []int, courseSizes []int, venueCapacities []int, venues []VenueInfo, week TeachingWeek) ([]SessionAtom, error) {
     sessionAtoms := make([]SessionAtom, 0)
     counter := 0
+    // courses whose students do not fit in any of their venues
+    tooBig := make([]string, 0)
+    // components none of whose course venues are of the kind or have the features they need
+    missingNeeds := make([]string, 0)
 	slog.Info("the course data","data",courseData)
 
     for _, v := range courseData {
-        // Validate lecturer exists
-        validLecturerId, err := utils.NullUUIDToUUid(v.LecturerId)
-        if err != nil {
-            slog.Warn("Course has invalid lecturer ID, skipping", "courseId", v.CourseId, "error", err)
-            continue
+        // Convert lecturer IDs to indexes
+        lecturerIdxs := make([]int, 0, len(v.LecturerIds))
+        for _, lecturerId := range v.LecturerIds {
+            if lecturerIdx, exists := lecturerMap[lecturerId]; exists {
+                lecturerIdxs = append(lecturerIdxs, lecturerIdx)
+            } else {
+                slog.Warn("Lecturer not found in map, skipping", "lecturerId", lecturerId, "courseId", v.CourseId)
+            }
         }
 
-        lecturerIdx, lecturerExists := lecturerMap[validLecturerId]
-        if !lecturerExists {
-            slog.Warn("Lecturer not found in map, skipping course", "lecturerId", validLecturerId, "courseId", v.CourseId)
+        if len(lecturerIdxs) == 0 {
+            slog.Warn("No valid lecturers found for course, skipping", "courseId", v.CourseId)
             continue
         }
 
@@ -200,37 +315,130 @@ func CreateSessionAtoms(lecturerMap map[uuid.UUID]int, venueMap map[uuid.UUID]in
             continue
         }
 
-        // Convert venue IDs to indexes
+        // Convert venue IDs to indexes, the possible venues of the course override the derived ones
+        // but never a venue restricted to other departments
         venueIdxs := make([]int, 0, len(v.PossibleVenues))
         for _, venueId := range v.PossibleVenues {
             if venueIdx, exists := venueMap[venueId]; exists {
+                if venueIdx < len(venues) && venues[venueIdx].barredFrom(v.DepartmentId, v.FacultyId) {
+                    slog.Warn("Venue is restricted to other departments, skipping", "venueId", venueId, "courseId", v.CourseId)
+                    continue
+                }
                 venueIdxs = append(venueIdxs, venueIdx)
             } else {
                 slog.Warn("Venue not found in map, skipping", "venueId", venueId, "courseId", v.CourseId)
             }
         }
+        if len(v.PossibleVenues) == 0 {
+            venueIdxs = derivedCourseVenues(v, venues)
+        }
 
         if len(venueIdxs) == 0 {
             slog.Warn("No valid venues found for course, skipping", "courseId", v.CourseId)
             continue
         }
 
-        // Create session atoms for each session per week
-        for i := 0; i < int(v.SessionsPerWeek); i++ {
-            counter++
-            sessionAtoms = append(sessionAtoms, SessionAtom{
-                SessionIdx:       counter - 1, // Use 0-based indexing
-                CourseIdx:        courseIdx,
-                LecturerIdx:      lecturerIdx,
-                CohortIdxs:       cohortIdxs,
-                SessionDuration:  int(v.CourseDuration),
-                AllowedVenuesIdx: venueIdxs,
+        // every student enrolled in the course sits in the same room, unless the class is split into
+        // groups. the groups are sized from the same students they are dealt out to, a course nobody
+        // is enrolled in yet is sized from the cohorts taking it
+        headcount := 0
+        if courseIdx < len(courseSizes) {
+            headcount = courseSizes[courseIdx]
+        }
+        if headcount == 0 {
+            for _, cohortIdx := range cohortIdxs {
+                if cohortIdx < len(cohortSizes) {
+                    headcount += cohortSizes[cohortIdx]
+                }
+            }
+        }
+
+        // the nth session of the course counts across its components and groups for the lecturer rotation
+        n := 0
+        for _, component := range v.Components {
+            kindVenues := componentVenues(venueIdxs, component, venues)
+            if len(kindVenues) == 0 {
+                missingNeeds = append(missingNeeds, fmt.Sprintf("%s %s (needs %s)", v.CourseCode, component.SessionType, componentNeeds(component)))
+                continue
+            }
+
+            // every group needs a venue the biggest group fits in
+            groups := groupCount(headcount, component.MaxGroupSize)
+            largestGroup := groupHeadcount(headcount, groups, 0)
+
+            fittingVenues := make([]int, 0, len(kindVenues))
+            largestCapacity := 0
+            for _, venueIdx := range kindVenues {
+                if venueCapacities[venueIdx] > largestCapacity {
+                    largestCapacity = venueCapacities[venueIdx]
+                }
+                if venueCapacities[venueIdx] >= largestGroup {
+                    fittingVenues = append(fittingVenues, venueIdx)
+                }
+            }
+            if len(fittingVenues) == 0 {
+                if groups > 1 {
+                    tooBig = append(tooBig, fmt.Sprintf("%s %s (%d students a group, largest venue holds %d)", v.CourseCode, component.SessionType, largestGroup, largestCapacity))
+                } else {
+                    tooBig = append(tooBig, fmt.Sprintf("%s %s (%d students, largest venue holds %d)", v.CourseCode, component.SessionType, headcount, largestCapacity))
+                }
+                continue
+            }
+
+            // the course's own rooms first, then the smallest rooms so the scheduler prefers them
+            ranks := courseVenueRanks(v, fittingVenues, venues)
+            order := make([]int, len(fittingVenues))
+            for i := range order {
+                order[i] = i
+            }
+            sort.SliceStable(order, func(i, j int) bool {
+                if ranks[order[i]] != ranks[order[j]] {
+                    return ranks[order[i]] < ranks[order[j]]
+                }
+                return venueCapacities[fittingVenues[order[i]]] < venueCapacities[fittingVenues[order[j]]]
             })
+            sortedVenues, sortedRanks := make([]int, len(order)), make([]int, len(order))
+            for i, o := range order {
+                sortedVenues[i], sortedRanks[i] = fittingVenues[o], ranks[o]
+            }
+
+            // Create session atoms for each session per week, one per group when the class is split
+            for i := 0; i < component.SessionsPerWeek; i++ {
+                for g := 0; g < groups; g++ {
+                    counter++
+                    atom := SessionAtom{
+                        SessionIdx:       counter - 1, // Use 0-based indexing
+                        CourseIdx:        courseIdx,
+                        LecturerIdxs:     sessionLecturerIdxs(lecturerIdxs, v.LecturerMode, n),
+                        CohortIdxs:       cohortIdxs,
+                        SessionDuration:  week.DurationSlots(component.DurationHours),
+                        AllowedVenuesIdx: sortedVenues,
+                        VenueRanks:       sortedRanks,
+                        Headcount:        headcount,
+                        SessionType:      component.SessionType,
+                    }
+                    if groups > 1 {
+                        atom.GroupIdx = g
+                        atom.GroupCount = groups
+                        atom.Headcount = groupHeadcount(headcount, groups, g)
+                    }
+                    sessionAtoms = append(sessionAtoms, atom)
+                    n++
+                }
+            }
         }
     }
 
+    if len(missingNeeds) > 0 {
+        return nil, fmt.Errorf("no venue has what is needed for: %s", strings.Join(missingNeeds, "; "))
+    }
+
+    if len(tooBig) > 0 {
+        return nil, fmt.Errorf("no venue is large enough for: %s", strings.Join(tooBig, ", "))
+    }
+
     slog.Info("Created session atoms", "count", len(sessionAtoms))
-    return sessionAtoms
+    return sessionAtoms, nil
 }
 // Add these helper functions to your computed package
 func parseTimeFromString(timeStr string) (time.Time, error) {
@@ -264,8 +472,9 @@ func parseTimeFromString(timeStr string) (time.Time, error) {
 }
 
 
-func ComputeLecturerUnavailability(lectUnavailable []sqlc.RetrieveTotalLecturerUnavailabilityRow, lecturerMap map[uuid.UUID]int, days []string, slotDuration time.Duration, startOfDay time.Time, slotsPerDay int) [][]bool {
-    totalSlots := len(days) * slotsPerDay
+func ComputeLecturerUnavailability(lectUnavailable []sqlc.RetrieveTotalLecturerUnavailabilityRow, lecturerMap map[uuid.UUID]int, week TeachingWeek) [][]bool {
+    slotsPerDay := week.SlotsPerDay()
+    totalSlots := week.TotalSlots()
 
     lecturerUnavailable := make([][]bool, len(lecturerMap))
     for i := range lecturerUnavailable {
@@ -273,7 +482,7 @@ func ComputeLecturerUnavailability(lectUnavailable []sqlc.RetrieveTotalLecturerU
     }
 
     dayIndex := make(map[string]int)
-    for i, d := range days {
+    for i, d := range week.DayNames() {
         dayIndex[d] = i
     }
 
@@ -289,19 +498,12 @@ func ComputeLecturerUnavailability(lectUnavailable []sqlc.RetrieveTotalLecturerU
             slog.Debug("Day not in configured days", "day", row.Day, "lecturerId", row.LecturerID)
             continue
         }
-		startTime,_ := parseTimeFromString(row.StartTime)
-		endTime,_ := parseTimeFromString(row.EndTime)
+		startMinutes,_ := ParseMinutes(row.StartTime)
+		endMinutes,_ := ParseMinutes(row.EndTime)
         
-        startSlot := int(startTime.Sub(startOfDay) / slotDuration)
-        endSlot := int(endTime.Sub(startOfDay) / slotDuration)
+        startSlot, endSlot := week.SlotRange(startMinutes, endMinutes)
 
-        // Validate and clamp slot ranges
-        if startSlot < 0 {
-            startSlot = 0
-        }
-        if endSlot > slotsPerDay {
-            endSlot = slotsPerDay
-        }
+        // Validate slot ranges
         if startSlot >= endSlot {
             slog.Debug("Invalid time range for lecturer unavailability", 
                 "lecturerId", row.LecturerID, "startSlot", startSlot, "endSlot", endSlot)
@@ -320,8 +522,9 @@ func ComputeLecturerUnavailability(lectUnavailable []sqlc.RetrieveTotalLecturerU
     return lecturerUnavailable
 }
 
-func ComputeVenueUnavaibility(venueUnavailable []sqlc.RetrieveTotalVenueUnavailabilityRow, venueMap map[uuid.UUID]int, days []string, slotDuration time.Duration, startOfDay time.Time, slotsPerDay int) [][]bool {
-    totalSlots := len(days) * slotsPerDay
+func ComputeVenueUnavaibility(venueUnavailable []sqlc.RetrieveTotalVenueUnavailabilityRow, venueMap map[uuid.UUID]int, week TeachingWeek) [][]bool {
+    slotsPerDay := week.SlotsPerDay()
+    totalSlots := week.TotalSlots()
 
     venUnavailable := make([][]bool, len(venueMap))
     for i := range venUnavailable {
@@ -329,32 +532,20 @@ func ComputeVenueUnavaibility(venueUnavailable []sqlc.RetrieveTotalVenueUnavaila
     }
 
     dayIndex := make(map[string]int)
-    for i, d := range days {
+    for i, d := range week.DayNames() {
         dayIndex[d] = i
     }
 
     for _, row := range venueUnavailable {
-		startTimeF,_ := parseTimeFromString(row.StartTime)
-		endTimeF,_ := parseTimeFromString(row.EndTime)
+		startMinutes,_ := ParseMinutes(row.StartTime)
+		endMinutes,_ := ParseMinutes(row.EndTime)
 
         venueIdx, exists := venueMap[row.VenueID]
         if !exists {
             continue
         }
         
-        // Check if we have valid day and time data
-        // if !row.Day.Valid || startTime ==  || !row.EndTime.Valid {
-        //     slog.Debug("Skipping venue unavailability row with null values", 
-        //         "venueId", row.VenueID, 
-        //         "dayValid", row.Day.Valid,
-        //         "startTimeValid", row.StartTime.Valid,
-        //         "endTimeValid", row.EndTime.Valid)
-        //     continue
-        // }
-        
         dayStr := row.Day.String
-        startTime := startTimeF
-        endTime := endTimeF
         
         didx, ok := dayIndex[dayStr]
         if !ok {
@@ -363,16 +554,9 @@ func ComputeVenueUnavaibility(venueUnavailable []sqlc.RetrieveTotalVenueUnavaila
         }
         
         // Calculate slots
-        startSlot := int(startTime.Sub(startOfDay) / slotDuration)
-        endSlot := int(endTime.Sub(startOfDay) / slotDuration)
+        startSlot, endSlot := week.SlotRange(startMinutes, endMinutes)
 
-        // Validate and clamp slot ranges
-        if startSlot < 0 {
-            startSlot = 0
-        }
-        if endSlot > slotsPerDay {
-            endSlot = slotsPerDay
-        }
+        // Validate slot ranges
         if startSlot >= endSlot {
             slog.Debug("Invalid time range for venue unavailability", 
                 "venueId", row.VenueID, "startSlot", startSlot, "endSlot", endSlot)
@@ -390,310 +574,262 @@ func ComputeVenueUnavaibility(venueUnavailable []sqlc.RetrieveTotalVenueUnavaila
 
     return venUnavailable
 }
-func (c *Computed) ComputePreComputed(ctx context.Context, uniId uuid.UUID, slotsPerDay int, startOfDay time.Time, days []string, slotDuration time.Duration) (*PreComputed, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int) {
-    slog.Info("=== START ComputePreComputed DEBUG ===")
-    slog.Info("Parameters", "universityId", uniId, "slotsPerDay", slotsPerDay, "days", days, "startOfDay", startOfDay, "slotDuration", slotDuration)
+// builds the data the solver runs on from the courses of the semester, every course when it is empty,
+// with their pinned sessions fixed in place
+func (c *Computed) ComputePreComputed(ctx context.Context, uniId uuid.UUID, semester string, week TeachingWeek) (*PreComputed, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int, error) {
+    pre, cohortMap, venueMap, lecturerMap, coursesMap, err := c.buildPreComputed(ctx, uniId, semester, week)
+    if err != nil {
+        return nil, nil, nil, nil, nil, err
+    }
 
-    // 1. DEBUG: Check raw courses data
-    slog.Info("=== STEP 1: Checking raw courses ===")
-    rawCoursesData, err := c.timetableRepository.RetrieveAllCourses(ctx, uniId)
+    rawPins, err := c.timetableRepository.RetrieveSessionPins(ctx, uniId)
     if err != nil {
-        slog.Error("❌ Failed to retrieve courses", "error", err, "universityId", uniId)
-        return nil, nil, nil, nil, nil
+        slog.Error("❌ Failed to retrieve pinned sessions", "error", err, "universityId", uniId)
+        return nil, nil, nil, nil, nil, err
     }
-    slog.Info("Raw courses retrieved", "count", len(rawCoursesData))
-    for i, course := range rawCoursesData {
-        slog.Info("Raw course", 
-            "index", i,
-            "courseId", course.CourseID,
-            "courseCode", course.CourseCode,
-            "courseTitle", course.CourseTitle,
-            "sessionsPerWeek", course.SessionsPerWeek,
-            "duration", course.CourseDuration)
+    pins, err := SessionPinsFromRows(PinsForSemester(rawPins, semester))
+    if err != nil {
+        return nil, nil, nil, nil, nil, err
     }
+    if err := ApplySessionPins(pre, pins, week, coursesMap, venueMap); err != nil {
+        return nil, nil, nil, nil, nil, err
+    }
+    slog.Info("Pinned sessions applied", "count", len(pins))
+
+    return pre, cohortMap, venueMap, lecturerMap, coursesMap, nil
+}
 
-    // 2. DEBUG: Check raw course-venue relationships (THIS IS CRITICAL)
-    slog.Info("=== STEP 2: Checking course-venue relationships ===")
-    rawCourseAndVenueData, err := c.timetableRepository.RetrieveAllCoursesAndVenues(ctx, uniId)
+// checks the given pins of the semester against the data of the university without saving anything
+func (c *Computed) CheckSessionPins(ctx context.Context, uniId uuid.UUID, semester string, week TeachingWeek, pins []SessionPin) error {
+    pre, _, venueMap, _, coursesMap, err := c.buildPreComputed(ctx, uniId, semester, week)
+    if err != nil {
+        return err
+    }
+    return ApplySessionPins(pre, pins, week, coursesMap, venueMap)
+}
+
+// every row of a university the solver data is built from, as the repository returns them
+type UniversityRows struct {
+    Courses                []sqlc.RetrieveCoursesForSemesterRow
+    CoursesAndVenues       []sqlc.RetrieveAllCoursesAndTheirVenueIdsRow
+    CourseLecturers        []sqlc.RetrieveCourseLecturersForUniRow
+    Lecturers              []sqlc.RetrieveTotalLecturersRow
+    CohortsForCourses      []sqlc.RetrieveCohortsForAllCoursesRow
+    Venues                 []sqlc.RetrieveAllVenuesRow
+    Cohorts                []sqlc.Cohort
+    CohortStudentCounts    []sqlc.RetrieveCohortStudentCountsRow
+    CourseStudentCounts    []sqlc.RetrieveCourseStudentCountsRow
+    LecturerUnavailability []sqlc.RetrieveTotalLecturerUnavailabilityRow
+    VenueUnavailability    []sqlc.RetrieveTotalVenueUnavailabilityRow
+    TravelTimes            []sqlc.GetVenueTravelTimesRow
+    ElectiveGroups         []sqlc.RetrieveElectiveGroupCoursesRow
+    ElectiveOverlaps       []sqlc.RetrieveElectiveStudentOverlapsRow
+    CourseComponents       []sqlc.RetrieveCourseComponentsForUniRow
+    VenueFeatures          []sqlc.RetrieveVenueFeaturesForUniRow
+    VenueOwners            []sqlc.RetrieveVenueOwnersForUniRow
+    RequiredFeatures       []sqlc.RetrieveCourseRequiredFeaturesForUniRow
+}
+
+// loads the rows of a university with only the courses of the semester, every course when it is empty
+func (c *Computed) loadUniversityRows(ctx context.Context, uniId uuid.UUID, semester string) (UniversityRows, error) {
+    var rows UniversityRows
+    var err error
+
+    rows.Courses, err = c.timetableRepository.RetrieveCoursesForSemester(ctx, uniId, semester)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve courses", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
+    }
+    rows.CoursesAndVenues, err = c.timetableRepository.RetrieveAllCoursesAndVenues(ctx, uniId, semester)
     if err != nil {
         slog.Error("❌ Failed to retrieve course-venue relationships", "error", err, "universityId", uniId)
-        return nil, nil, nil, nil, nil
+        return UniversityRows{}, err
     }
-    slog.Info("Course-venue relationships retrieved", "count", len(rawCourseAndVenueData))
-    
-    targetCourseId := uuid.MustParse("1e71ea2b-d69e-4855-92e5-13a7095fb508")
-    foundCourseVenueData := false
-    for i, rel := range rawCourseAndVenueData {
-        slog.Info("Course-venue relationship", 
-            "index", i,
-            "courseId", rel.CourseID,
-            "venueId", rel.VenueID,
-            "lecturerId", rel.LecturerID,
-            "lecturerValid", rel.LecturerID.Valid,
-            "lecturerUUID", rel.LecturerID.UUID)
-        
-        if rel.CourseID == targetCourseId {
-            foundCourseVenueData = true
-            slog.Info("✅ TARGET COURSE FOUND in course-venue data", 
-                "courseId", rel.CourseID,
-                "lecturerValid", rel.LecturerID.Valid,
-                "lecturerUUID", rel.LecturerID.UUID)
-        }
+    rows.CourseLecturers, err = c.timetableRepository.RetrieveCourseLecturers(ctx, uniId, semester)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve course lecturers", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
     }
-    if !foundCourseVenueData {
-        slog.Error("❌ TARGET COURSE NOT FOUND in course-venue relationships", "courseId", targetCourseId)
+    rows.CourseComponents, err = c.timetableRepository.RetrieveCourseComponents(ctx, uniId, semester)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve course components", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
     }
-
-    // 3. DEBUG: Check raw lecturers
-    slog.Info("=== STEP 3: Checking lecturers ===")
-    rawLecturersData, err := c.timetableRepository.RetrieveTotalLecturers(ctx, utils.UuidToNullUUID(uniId))
+    rows.RequiredFeatures, err = c.timetableRepository.RetrieveCourseRequiredFeatures(ctx, uniId, semester)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve course required features", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
+    }
+    rows.Lecturers, err = c.timetableRepository.RetrieveTotalLecturers(ctx, utils.UuidToNullUUID(uniId))
     if err != nil {
         slog.Error("❌ Failed to retrieve lecturers", "error", err, "universityId", uniId)
-        return nil, nil, nil, nil, nil
+        return UniversityRows{}, err
     }
-    slog.Info("Lecturers retrieved", "count", len(rawLecturersData))
-    targetLecturerId := uuid.MustParse("ef5ddd4c-3784-488c-90bb-392dc21b41c5")
-    foundLecturer := false
-    for i, lecturer := range rawLecturersData {
-        slog.Info("Raw lecturer", 
-            "index", i,
-            "lecturerId", lecturer.LecturerID,
-            "name", lecturer.LecturerFirstName)
-        if lecturer.LecturerID == targetLecturerId {
-            foundLecturer = true
-            slog.Info("✅ TARGET LECTURER FOUND", "lecturerId", targetLecturerId)
-        }
+    rows.CohortsForCourses, err = c.timetableRepository.RetrieveCohortsForAllCourses(ctx, uniId, semester)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve cohort-course relationships", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
     }
-    if !foundLecturer {
-        slog.Error("❌ TARGET LECTURER NOT FOUND in raw data", "lecturerId", targetLecturerId)
+    rows.Venues, err = c.timetableRepository.RetrieveAllVenues(ctx, uniId)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve venues", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
     }
-
-    // 4. DEBUG: Check cohorts for courses
-    slog.Info("=== STEP 4: Checking cohort-course relationships ===")
-    rawCohortCourseData, err := c.timetableRepository.RetrieveCohortsForAllCourses(ctx, uniId)
+    rows.VenueFeatures, err = c.timetableRepository.RetrieveVenueFeatures(ctx, uniId)
     if err != nil {
-        slog.Error("❌ Failed to retrieve cohort-course relationships", "error", err, "universityId", uniId)
-        return nil, nil, nil, nil, nil
-    }
-    slog.Info("Cohort-course relationships retrieved", "count", len(rawCohortCourseData))
-    foundCohorts := false
-    for _, rel := range rawCohortCourseData {
-        if rel.CourseID == targetCourseId {
-            slog.Info("✅ TARGET COURSE HAS COHORT", "courseId", rel.CourseID, "cohortId", rel.CohortID)
-            foundCohorts = true
-        }
+        slog.Error("❌ Failed to retrieve venue features", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
+    }
+    rows.VenueOwners, err = c.timetableRepository.RetrieveVenueOwners(ctx, uniId)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve venue owners", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
+    }
+    rows.Cohorts, err = c.timetableRepository.RetrieveAllCohorts(ctx, uniId)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve cohorts", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
     }
-    if !foundCohorts {
-        slog.Error("❌ TARGET COURSE HAS NO COHORTS", "courseId", targetCourseId)
+    rows.CohortStudentCounts, err = c.timetableRepository.RetrieveCohortStudentCounts(ctx, uniId)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve cohort student counts", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
+    }
+    rows.CourseStudentCounts, err = c.timetableRepository.RetrieveCourseStudentCounts(ctx, uniId)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve course student counts", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
     }
 
-    // 5. DEBUG: Check venues
-    slog.Info("=== STEP 5: Checking venues ===")
-    rawVenuesData, err := c.timetableRepository.RetrieveAllVenues(ctx, uniId)
+    // a university without any unavailability recorded is still scheduled
+    rows.LecturerUnavailability, err = c.timetableRepository.RetrieveTotalLecturerUnavailability(ctx, utils.UuidToNullUUID(uniId))
     if err != nil {
-        slog.Error("❌ Failed to retrieve venues", "error", err, "universityId", uniId)
-        return nil, nil, nil, nil, nil
+        slog.Warn("Failed to retrieve lecturer unavailability, using empty data", "error", err)
+        rows.LecturerUnavailability = []sqlc.RetrieveTotalLecturerUnavailabilityRow{}
     }
-    slog.Info("Venues retrieved", "count", len(rawVenuesData))
-    for i, venue := range rawVenuesData {
-        slog.Info("Raw venue", 
-            "index", i,
-            "venueId", venue.VenueID,
-            "venueName", venue.VenueName)
+    rows.VenueUnavailability, err = c.timetableRepository.RetrieveTotalVenueUnavailability(ctx, uniId)
+    if err != nil {
+        slog.Warn("Failed to retrieve venue unavailability, using empty data", "error", err)
+        rows.VenueUnavailability = []sqlc.RetrieveTotalVenueUnavailabilityRow{}
+    }
+    // travel times are worked out from the venue coordinates when none are set
+    rows.TravelTimes, err = c.timetableRepository.RetrieveVenueTravelTimes(ctx, uniId)
+    if err != nil {
+        slog.Warn("Failed to retrieve venue travel times, using coordinates only", "error", err)
+        rows.TravelTimes = []sqlc.GetVenueTravelTimesRow{}
     }
+    rows.ElectiveGroups, err = c.timetableRepository.RetrieveElectiveGroupCourses(ctx, uniId, semester)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve elective groups", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
+    }
+    rows.ElectiveOverlaps, err = c.timetableRepository.RetrieveElectiveStudentOverlaps(ctx, uniId)
+    if err != nil {
+        slog.Error("❌ Failed to retrieve students taking electives of the same group", "error", err, "universityId", uniId)
+        return UniversityRows{}, err
+    }
+
+    slog.Info("University rows retrieved",
+        "universityId", uniId,
+        "semester", semester,
+        "courses", len(rows.Courses),
+        "courseVenues", len(rows.CoursesAndVenues),
+        "lecturers", len(rows.Lecturers),
+        "cohortCourses", len(rows.CohortsForCourses),
+        "venues", len(rows.Venues),
+        "cohorts", len(rows.Cohorts))
+    return rows, nil
+}
 
-    // 6. DEBUG: Check cohorts
-    slog.Info("=== STEP 6: Checking cohorts ===")
-    rawCohortData, err := c.timetableRepository.RetrieveAllCohorts(ctx, uniId)
+func (c *Computed) buildPreComputed(ctx context.Context, uniId uuid.UUID, semester string, week TeachingWeek) (*PreComputed, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int, error) {
+    slog.Info("Parameters", "universityId", uniId, "semester", semester, "slotsPerDay", week.SlotsPerDay(), "days", week.DayNames(), "slotMinutes", week.SlotMinutes)
+    rows, err := c.loadUniversityRows(ctx, uniId, semester)
     if err != nil {
-        slog.Error("❌ Failed to retrieve cohorts", "error", err, "universityId", uniId)
-        return nil, nil, nil, nil, nil
+        return nil, nil, nil, nil, nil, err
     }
-    slog.Info("Cohorts retrieved", "count", len(rawCohortData))
+    return PreComputedFromRows(rows, week)
+}
 
+// builds the data the solver runs on from the rows of a university, without its pins. returns it
+// with the maps of cohort, venue, lecturer and course ids to idxs. the rows are sorted in place
+func PreComputedFromRows(rows UniversityRows, week TeachingWeek) (*PreComputed, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int, map[uuid.UUID]int, error) {
     // Create mapping indexes
-    slog.Info("=== STEP 7: Creating mapping indexes ===")
-    cohortMap := MapCohortIdToIdx(rawCohortData)
-    venueMap := MapVanueIdToIdx(rawVenuesData)
-    lecturerMap := MapLecturerIdToIdx(rawLecturersData)
-    coursesMap := MapCoursesIdtoIdx(rawCoursesData)
+    sortByUUID(rows.Cohorts, func(c sqlc.Cohort) uuid.UUID { return c.CohortID })
+    sortByUUID(rows.Venues, func(v sqlc.RetrieveAllVenuesRow) uuid.UUID { return v.VenueID })
+    sortByUUID(rows.Lecturers, func(l sqlc.RetrieveTotalLecturersRow) uuid.UUID { return l.LecturerID })
+    sortByUUID(rows.Courses, func(c sqlc.RetrieveCoursesForSemesterRow) uuid.UUID { return c.CourseID })
+    // sorted by venue then course so every course keeps its venues and cohorts in the same order
+    sortByUUID(rows.CoursesAndVenues, func(r sqlc.RetrieveAllCoursesAndTheirVenueIdsRow) uuid.UUID { return r.VenueID.UUID })
+    sortByUUID(rows.CoursesAndVenues, func(r sqlc.RetrieveAllCoursesAndTheirVenueIdsRow) uuid.UUID { return r.CourseID })
+    sortByUUID(rows.CohortsForCourses, func(r sqlc.RetrieveCohortsForAllCoursesRow) uuid.UUID { return r.CohortID })
+    sortByUUID(rows.CohortsForCourses, func(r sqlc.RetrieveCohortsForAllCoursesRow) uuid.UUID { return r.CourseID })
+    cohortMap := MapCohortIdToIdx(rows.Cohorts)
+    venueMap := MapVanueIdToIdx(rows.Venues)
+    lecturerMap := MapLecturerIdToIdx(rows.Lecturers)
+    coursesMap := MapCoursesIdtoIdx(rows.Courses)
 
-    // Log mapping statistics for debugging
     slog.Info("Mapping statistics",
         "cohorts", len(cohortMap),
         "venues", len(venueMap),
         "lecturers", len(lecturerMap),
         "courses", len(coursesMap))
 
-    // Check if target lecturer exists in lecturerMap
-    if lecturerIdx, exists := lecturerMap[targetLecturerId]; exists {
-        slog.Info("✅ TARGET LECTURER FOUND in lecturerMap", "lecturerId", targetLecturerId, "index", lecturerIdx)
-    } else {
-        slog.Error("❌ TARGET LECTURER NOT FOUND in lecturerMap", "lecturerId", targetLecturerId)
-        slog.Info("Available lecturers in map:")
-        for lid, idx := range lecturerMap {
-            slog.Info("Available lecturer", "lecturerId", lid, "index", idx)
-        }
-    }
-
-    // Check if target course exists in coursesMap
-    if courseIdx, exists := coursesMap[targetCourseId]; exists {
-        slog.Info("✅ TARGET COURSE FOUND in coursesMap", "courseId", targetCourseId, "index", courseIdx)
-    } else {
-        slog.Error("❌ TARGET COURSE NOT FOUND in coursesMap", "courseId", targetCourseId)
-    }
-
-    // 8. DEBUG: Process course data
-    slog.Info("=== STEP 8: Processing course data ===")
-    courseData := ModifyCourseData(rawCourseAndVenueData)
-    slog.Info("Processed course data", "count", len(courseData))
-    
-    foundProcessedCourse := false
-    for i, course := range courseData {
-        slog.Info("Processed course", 
-            "index", i,
-            "courseId", course.CourseId,
-            "courseTitle", course.CourseTitle,
-            "hasLecturer", course.LecturerId.Valid,
-            "lecturerId", course.LecturerId.UUID,
-            "sessionsPerWeek", course.SessionsPerWeek,
-            "duration", course.CourseDuration,
-            "possibleVenues", len(course.PossibleVenues))
-        
-        if course.CourseId == targetCourseId {
-            foundProcessedCourse = true
-            slog.Info("✅ TARGET COURSE FOUND in processed data", 
-                "courseId", course.CourseId,
-                "lecturerValid", course.LecturerId.Valid,
-                "lecturerUUID", course.LecturerId.UUID)
-            
-            if !course.LecturerId.Valid {
-                slog.Error("❌ PROBLEM: LecturerId.Valid is FALSE in processed data!")
-            }
-            if course.LecturerId.UUID == targetLecturerId {
-                slog.Info("✅ Lecturer UUID matches target")
-            } else {
-                slog.Error("❌ Lecturer UUID mismatch", "expected", targetLecturerId, "actual", course.LecturerId.UUID)
-            }
-        }
-    }
-    if !foundProcessedCourse {
-        slog.Error("❌ TARGET COURSE NOT FOUND in processed course data", "courseId", targetCourseId)
-    }
+    courseData := AttachCourseComponents(AttachCourseLecturers(ModifyCourseData(rows.CoursesAndVenues), rows.CourseLecturers), rows.CourseComponents)
+    courseData = AttachRequiredFeatures(courseData, rows.RequiredFeatures)
+    cohortCourseData := ModifyCohortCourseData(rows.CohortsForCourses)
 
-    // 9. DEBUG: Process cohort data
-    slog.Info("=== STEP 9: Processing cohort data ===")
-    cohortCourseData := ModifyCohortCourseData(rawCohortCourseData)
-    slog.Info("Cohort course data", "coursesWithCohorts", len(cohortCourseData))
-    if cohorts, exists := cohortCourseData[targetCourseId]; exists {
-        slog.Info("✅ TARGET COURSE HAS COHORTS in processed data", "courseId", targetCourseId, "cohortCount", len(cohorts))
-        for _ , cohortId := range cohorts {
-            if cohortIdx, exists := cohortMap[cohortId]; exists {
-                slog.Info("Cohort found in cohortMap", "cohortId", cohortId, "index", cohortIdx)
-            } else {
-                slog.Warn("Cohort not found in cohortMap", "cohortId", cohortId)
-            }
-        }
-    } else {
-        slog.Error("❌ TARGET COURSE HAS NO COHORTS in processed data", "courseId", targetCourseId)
+    cohortSizes := ComputeCohortSizes(rows.Cohorts, rows.CohortStudentCounts, cohortMap)
+    courseSizes := ComputeCourseSizes(rows.CourseStudentCounts, coursesMap)
+    venueCapacities := ComputeVenueCapacities(rows.Venues, venueMap)
+    venueInfo := ComputeVenueInfo(rows.Venues, rows.VenueFeatures, rows.VenueOwners, venueMap)
+    sessionAtoms, err := CreateSessionAtoms(lecturerMap, venueMap, coursesMap, cohortMap, courseData, cohortCourseData, cohortSizes, courseSizes, venueCapacities, venueInfo, week)
+    if err != nil {
+        return nil, nil, nil, nil, nil, err
     }
-
-    // 10. DEBUG: Create session atoms
-    slog.Info("=== STEP 10: Creating session atoms ===")
-    sessionAtoms := CreateSessionAtoms(lecturerMap, venueMap, coursesMap, cohortMap, courseData, cohortCourseData)
-    slog.Info("Session atoms created", "count", len(sessionAtoms))
-
-    // If no session atoms, debug why
     if len(sessionAtoms) == 0 {
-        slog.Error("❌ CRITICAL: NO SESSION ATOMS CREATED")
-        slog.Info("Debugging why session atoms are not created...")
-        
-        // Manually check each condition that would skip the course
-        for _, course := range courseData {
-            if course.CourseId == targetCourseId {
-                slog.Info("=== MANUAL DEBUG FOR TARGET COURSE ===")
-                
-                // Check lecturer
-                if !course.LecturerId.Valid {
-                    slog.Error("❌ Skip reason: LecturerId.Valid = false")
-                } else {
-                    validLecturerId := course.LecturerId.UUID
-                    if _, exists := lecturerMap[validLecturerId]; !exists {
-                        slog.Error("❌ Skip reason: Lecturer not found in lecturerMap", "lecturerId", validLecturerId)
-                    } else {
-                        slog.Info("✅ Lecturer check PASSED")
-                    }
-                }
-                
-                // Check course in courseMap
-                if _, exists := coursesMap[course.CourseId]; !exists {
-                    slog.Error("❌ Skip reason: Course not found in courseMap")
-                } else {
-                    slog.Info("✅ Course map check PASSED")
-                }
-                
-                // Check cohorts
-                cohorts, hasCohorts := cohortCourseData[course.CourseId]
-                if !hasCohorts || len(cohorts) == 0 {
-                    slog.Error("❌ Skip reason: No cohorts")
-                } else {
-                    slog.Info("✅ Cohorts check PASSED", "cohortCount", len(cohorts))
-                }
-                
-                // Check venues
-                if len(course.PossibleVenues) == 0 {
-                    slog.Error("❌ Skip reason: No possible venues")
-                } else {
-                    slog.Info("✅ Venues check PASSED", "venueCount", len(course.PossibleVenues))
-                }
-                
-                // Check sessions per week
-                if course.SessionsPerWeek <= 0 {
-                    slog.Error("❌ Skip reason: Invalid sessions per week", "sessions", course.SessionsPerWeek)
-                } else {
-                    slog.Info("✅ Sessions per week check PASSED", "sessions", course.SessionsPerWeek)
-                }
-                
-                // Check duration
-                if course.CourseDuration <= 0 {
-                    slog.Error("❌ Skip reason: Invalid duration", "duration", course.CourseDuration)
-                } else {
-                    slog.Info("✅ Duration check PASSED", "duration", course.CourseDuration)
-                }
-            }
-        }
-        
-        return nil, nil, nil, nil, nil
+        slog.Error("❌ No session atoms created", "courses", len(courseData), "cohortCourses", len(cohortCourseData))
+        return nil, nil, nil, nil, nil, fmt.Errorf("no sessions could be created for this university, check that courses have lecturers, cohorts and venues")
     }
 
-    // [Rest of your function remains the same...]
-    totalSlots := slotsPerDay * len(days)
+    totalSlots := week.TotalSlots()
     numVenues := len(venueMap)
     numLecturers := len(lecturerMap)
     numCohorts := len(cohortMap)
     numCourses := len(coursesMap)
 
-    // Retrieve availability data (with error handling)
-    rawLecturerUnavailability, err := c.timetableRepository.RetrieveTotalLecturerUnavailability(ctx, utils.UuidToNullUUID(uniId))
-    if err != nil {
-        slog.Warn("Failed to retrieve lecturer unavailability, using empty data", "error", err)
-        rawLecturerUnavailability = []sqlc.RetrieveTotalLecturerUnavailabilityRow{}
-    }
+    // Compute availability matrices
+    venueUnavailability := ComputeVenueUnavaibility(rows.VenueUnavailability, venueMap, week)
+    lecturerUnavailability := ComputeLecturerUnavailability(rows.LecturerUnavailability, lecturerMap, week)
 
-    rawVenueUnavailability, err := c.timetableRepository.RetrieveTotalVenueUnavailability(ctx, uniId)
-    if err != nil {
-        slog.Warn("Failed to retrieve venue unavailability, using empty data", "error", err)
-        rawVenueUnavailability = []sqlc.RetrieveTotalVenueUnavailabilityRow{}
+    courseCodes := make([]string, numCourses)
+    for _, course := range rows.Courses {
+        if idx, ok := coursesMap[course.CourseID]; ok {
+            courseCodes[idx] = course.CourseCode
+        }
+    }
+    venueNames := make([]string, numVenues)
+    for _, venue := range rows.Venues {
+        if idx, ok := venueMap[venue.VenueID]; ok {
+            venueNames[idx] = venue.VenueName
+        }
+    }
+    lecturerNames := make([]string, numLecturers)
+    for _, lecturer := range rows.Lecturers {
+        if idx, ok := lecturerMap[lecturer.LecturerID]; ok {
+            lecturerNames[idx] = strings.TrimSpace(lecturer.LecturerFirstName + " " + lecturer.LecturerLastName)
+        }
+    }
+    cohortNames := make([]string, numCohorts)
+    for _, cohort := range rows.Cohorts {
+        if idx, ok := cohortMap[cohort.CohortID]; ok {
+            cohortNames[idx] = cohort.CohortName
+        }
     }
-
-    // Compute availability matrices
-    venueUnavailability := ComputeVenueUnavaibility(rawVenueUnavailability, venueMap, days, slotDuration, startOfDay, slotsPerDay)
-    lecturerUnavailability := ComputeLecturerUnavailability(rawLecturerUnavailability, lecturerMap, days, slotDuration, startOfDay, slotsPerDay)
 
     // Create and validate PreComputed structure
     pre := &PreComputed{
         TotalSlots:          totalSlots,
-        SlotsPerDay:         slotsPerDay,
+        SlotsPerDay:         week.SlotsPerDay(),
         NumVenues:           numVenues,
         NumLecturers:        numLecturers,
         NumCohorts:          numCohorts,
@@ -701,7 +837,18 @@ func (c *Computed) ComputePreComputed(ctx context.Context, uniId uuid.UUID, slot
         SessionAtoms:        sessionAtoms,
         LecturerUnavailable: lecturerUnavailability,
         VenueUnavailable:    venueUnavailability,
-    }
+        VenueCapacities:     venueCapacities,
+        BlockedSlots:        week.BlockedSlots(),
+        SlotMinutes:         week.SlotMinutes,
+        DayStartMinutes:     week.GridStartMinutes(),
+        CourseCodes:         courseCodes,
+        VenueNames:          venueNames,
+        LecturerNames:       lecturerNames,
+        CohortNames:         cohortNames,
+        TravelMinutes:       ComputeTravelMinutes(rows.Venues, rows.TravelTimes, venueMap),
+    }
+    groups, sharedStudents := electivesFromRows(rows.ElectiveGroups, rows.ElectiveOverlaps, cohortMap, coursesMap)
+    AssignCohortKeys(pre, groups, sharedStudents)
 
     slog.Info("✅ PreComputed data successfully created", 
         "totalSlots", pre.TotalSlots,
@@ -711,6 +858,5 @@ func (c *Computed) ComputePreComputed(ctx context.Context, uniId uuid.UUID, slot
         "cohorts", pre.NumCohorts,
         "courses", pre.NumCourses)
 
-    slog.Info("=== END ComputePreComputed DEBUG ===")
-    return pre, cohortMap, venueMap, lecturerMap, coursesMap
+    return pre, cohortMap, venueMap, lecturerMap, coursesMap, nil
 }
\ No newline at end of file
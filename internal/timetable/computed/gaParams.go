@@ -0,0 +1,75 @@
+package computed
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// the knobs of the genetic algorithm for a single run
+type GAParams struct {
+	PopulationSize  int
+	Generations     int
+	MutationRate    float64 // chance of a placement being moved in a child e.g 0.05 for 5%
+	TournamentSize  int     // number of challengers a parent has to beat during selection
+	ElitismFraction float64 // fraction of the best candidates copied as is into the next generation
+	SampleK         int     // pick from the top k feasible pairs when building a candidate
+	Seed            int64   // every rand source of a run is derived from this, so it is not defaulted
+	Workers         int     // children built at the same time, 0 for one per core. a run is only repeatable with the same number
+	// when to stop before every generation is built, the zero values never stop a run early
+	StallGenerations int           // generations in a row without a better best fitness
+	TimeBudget       time.Duration // how long the run may take, a run stopped by it is not repeatable
+	StopWhenFeasible bool          // stop once the best candidate breaks no hard constraint
+}
+
+// the values the genetic algorithm used before they could be configured.
+// the seed is left for the caller to pick
+func DefaultGAParams() GAParams {
+	return GAParams{
+		PopulationSize:  100,
+		Generations:     100,
+		MutationRate:    0.05,
+		TournamentSize:  10,
+		ElitismFraction: 0.1,
+		SampleK:         5,
+	}
+}
+
+func (p GAParams) Validate() error {
+	if p.PopulationSize < 2 {
+		return fmt.Errorf("population size must be at least 2, got %d", p.PopulationSize)
+	}
+	if p.Generations < 1 {
+		return fmt.Errorf("generations must be at least 1, got %d", p.Generations)
+	}
+	if p.MutationRate < 0 || p.MutationRate > 1 {
+		return fmt.Errorf("mutation rate must be between 0 and 1, got %v", p.MutationRate)
+	}
+	if p.TournamentSize < 1 {
+		return fmt.Errorf("tournament size must be at least 1, got %d", p.TournamentSize)
+	}
+	if p.ElitismFraction < 0 || p.ElitismFraction >= 1 {
+		return fmt.Errorf("elitism fraction must be between 0 and 1 (exclusive), got %v", p.ElitismFraction)
+	}
+	if p.SampleK < 1 {
+		return fmt.Errorf("sample k must be at least 1, got %d", p.SampleK)
+	}
+	if p.Workers < 0 {
+		return fmt.Errorf("workers must not be negative, got %d", p.Workers)
+	}
+	if p.StallGenerations < 0 {
+		return fmt.Errorf("stall generations must not be negative, got %d", p.StallGenerations)
+	}
+	if p.TimeBudget < 0 {
+		return fmt.Errorf("time budget must not be negative, got %v", p.TimeBudget)
+	}
+	return nil
+}
+
+// the number of workers a run uses, every core when Workers is 0
+func (p GAParams) WorkerCount() int {
+	if p.Workers > 0 {
+		return p.Workers
+	}
+	return runtime.NumCPU()
+}
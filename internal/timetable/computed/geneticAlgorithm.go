@@ -1,10 +1,12 @@
 package computed
 
 import (
+	"context"
 	"log/slog"
 	"math"
 	"math/rand"
 	"sort"
+	"sync"
 	"time"
 )
 
@@ -21,10 +23,20 @@ type SessionPlacement struct {
 type SessionAtom struct {
 	SessionIdx       int
 	CourseIdx        int
-	LecturerIdx      int
+	LecturerIdxs     []int // every lecturer needed in the room for this session
 	CohortIdxs       []int
-	SessionDuration  int // how long for each session e.g 2 for 2 hours
-	AllowedVenuesIdx []int
+	CohortKeys       []int // rows of the cohort occupancy the session books, see AssignCohortKeys
+	ClashKeys        []int // rows of the cohort occupancy that must be free where the session goes
+	SessionType      string // LECTURE, LAB or TUTORIAL, see CourseComponent
+	SessionDuration  int // how many slots each session takes e.g 2 for 2 hours of hourly slots
+	AllowedVenuesIdx []int // only venues the session fits in, the course's own first then smallest first
+	VenueRanks       []int // VenueRanks[i] is the rank of AllowedVenuesIdx[i] for the course, see VenueRankOwnDepartment
+	Headcount        int   // students of all the cohorts in the session, only the group's when it is for a group
+	GroupIdx         int   // the group of the course's students the session is for, see groupHeadcount
+	GroupCount       int   // how many parallel groups the course's students are split into, 0 or 1 when not split
+	Pinned           bool  // fixed by a HOD or kept from the published timetable, the solver never moves it
+	PinnedSlotIdx    int
+	PinnedVenueIdx   int
 }
 
 // shows all the necessary things i need to compute before starting the computation
@@ -34,10 +46,26 @@ type PreComputed struct {
 	NumVenues           int
 	NumLecturers        int
 	NumCohorts          int
+	NumCohortKeys       int // rows of the cohort occupancy, more than NumCohorts when cohorts have elective groups
 	NumCourses          int
 	SessionAtoms        []SessionAtom
 	LecturerUnavailable [][]bool // LecturerUnavailable[lecturerIdx][slot] static forbidden mask (true = unavailable)
 	VenueUnavailable    [][]bool // VenueUnavailable[venueIdx][slot] static forbidden mask (true = unavailable)
+	VenueCapacities     []int        // VenueCapacities[venueIdx] number of seats
+	BlockedSlots        []bool       // BlockedSlots[slot] true when the slot is outside the hours of its day
+	SlotMinutes         int          // length of one slot in minutes
+	DayStartMinutes     int          // minutes after midnight the first slot of a day starts
+	Constraints         []Constraint // soft constraints the candidates are scored against, enabled ones only
+	CourseCodes         []string     // CourseCodes[courseIdx] used in messages
+	VenueNames          []string     // VenueNames[venueIdx] used in messages
+	LecturerNames       []string     // LecturerNames[lecturerIdx] used in messages
+	CohortNames         []string     // CohortNames[cohortIdx] used in messages
+	TravelMinutes       [][]int      // TravelMinutes[fromVenueIdx][toVenueIdx] walking minutes, nil when unknown
+	ElectiveGroups      []ElectiveGroup // what AssignCohortKeys was given, kept so a problem file can list them
+	SharedStudents      [][2]int
+
+	masksOnce sync.Once
+	static    *staticMasks // bitsets of what never changes, see masks
 }
 
 type FeasiblePair struct {
@@ -48,8 +76,13 @@ type FeasiblePair struct {
 }
 
 type Candidate struct {
-	Placements []SessionPlacement
-	Fitness    float64 // the higher the better
+	Placements  []SessionPlacement
+	Fitness     float64 // the higher the better
+	HardPenalty float64 // clashes the placements could not avoid and breaches of hard constraints
+	SoftPenalty float64 // weighted sum of the soft constraints
+	// how the genetic run that produced the candidate went, empty for any other candidate
+	History    []GenerationStats // the initial population first, then every generation built
+	StopReason string
 }
 
 // k here refers to the number of pairs to choose from so if k = 3, it means choose one random from the top 3
@@ -66,204 +99,164 @@ func ChooseTopSampleK(topPairs []FeasiblePair, k int, r *rand.Rand) FeasiblePair
 	return topPairs[r.Intn(k)]
 }
 
-// next function is to compute top feasible pairs
-func ComputeFeasiblePairs(pre *PreComputed, session *SessionAtom, venueOccupied [][]bool, lecturerOccupied [][]bool, cohortOccupied [][]bool) []FeasiblePair {
-    totalSlots := pre.TotalSlots
-    feasible := make([]FeasiblePair, 0, totalSlots)
-
-    // Add bounds checking for session indices
-    if session.LecturerIdx < 0 || session.LecturerIdx >= len(pre.LecturerUnavailable) {
-        slog.Error("Invalid lecturer index", "lecturerIdx", session.LecturerIdx, "max", len(pre.LecturerUnavailable))
-        return feasible // Return empty if invalid
+// a session needs at least one lecturer and all of them must be known
+func validLecturerIdxs(pre *PreComputed, session *SessionAtom) bool {
+    if len(session.LecturerIdxs) == 0 {
+        return false
     }
-
-    // iterates through all possible slots
-    for start := 0; start < totalSlots; start++ {
-        // prevents cross day boundary i.e a session crossing a day
-        startDaySlot := (start / pre.SlotsPerDay) * pre.SlotsPerDay
-        EndDaySlot := startDaySlot + pre.SlotsPerDay
-
-        if start+session.SessionDuration > EndDaySlot {
-            continue
+    for _, lecturerIdx := range session.LecturerIdxs {
+        if lecturerIdx < 0 || lecturerIdx >= len(pre.LecturerUnavailable) {
+            return false
         }
+    }
+    return true
+}
 
-        // prevents slotting in periods of unavailable lecturers for the session
-        lectOk := true
-        s := start + session.SessionDuration
-        
-        // Add bounds checking for lecturer unavailable
-        for si := start; si < s; si++ {
-            if si >= totalSlots {
-                lectOk = false
-                break
-            }
-            // Check if lecturer unavailable array has enough length
-            if si >= len(pre.LecturerUnavailable[session.LecturerIdx]) {
-                lectOk = false
-                break
-            }
-            if pre.LecturerUnavailable[session.LecturerIdx][si] || 
-               (si < len(lecturerOccupied[session.LecturerIdx]) && lecturerOccupied[session.LecturerIdx][si]) {
-                lectOk = false
-                break
-            }
-        }
-        if !lectOk {
-            continue
+// true if any slot the session would take is outside the hours of its day
+func isBlocked(pre *PreComputed, start int, duration int) bool {
+    for si := start; si < start+duration; si++ {
+        if si < len(pre.BlockedSlots) && pre.BlockedSlots[si] {
+            return true
         }
+    }
+    return false
+}
 
-        // now prevents cohorts conflict
-        cohortsConflict := false
-        for ci := start; ci < s; ci++ {
-            if ci >= totalSlots {
-                cohortsConflict = true
-                break
-            }
-            for _, c := range session.CohortIdxs {
-                // Add bounds checking for cohort index
-                if c < 0 || c >= len(cohortOccupied) {
-                    cohortsConflict = true
-                    break
-                }
-                if ci < len(cohortOccupied[c]) && cohortOccupied[c][ci] {
-                    cohortsConflict = true
-                    break
-                }
-            }
-            if cohortsConflict {
-                break
-            }
-        }
+// next function is to compute top feasible pairs
+func ComputeFeasiblePairs(pre *PreComputed, session *SessionAtom, venueOccupied *Occupancy, lecturerOccupied *Occupancy, cohortOccupied *Occupancy) []FeasiblePair {
+    return appendFeasiblePairs(pre, session, venueOccupied, lecturerOccupied, cohortOccupied, NewBitset(pre.TotalSlots), make([]FeasiblePair, 0, pre.TotalSlots), 0)
+}
 
-        if cohortsConflict {
-            continue
+// appends the feasible pairs of the session to feasible, pairs in the snuggest rooms come first then
+// the earliest slots. with a limit above 0 it stops once it has limit pairs, they are the same as the
+// first limit of the full list. busy is scratch space of at least pre.TotalSlots slots
+func appendFeasiblePairs(pre *PreComputed, session *SessionAtom, venueOccupied *Occupancy, lecturerOccupied *Occupancy, cohortOccupied *Occupancy, busy Bitset, feasible []FeasiblePair, limit int) []FeasiblePair {
+    // Add bounds checking for session indices
+    if !validLecturerIdxs(pre, session) {
+        slog.Error("Invalid lecturer index", "lecturerIdxs", session.LecturerIdxs, "max", len(pre.LecturerUnavailable))
+        return feasible // Return empty if invalid
+    }
+    masks := pre.masks()
+    duration := session.SessionDuration
+    found := 0
+
+    // the lecturers and cohorts of the session are taken at the same slots whatever the venue
+    busy.Reset()
+    for _, lecturerIdx := range session.LecturerIdxs {
+        busy.Or(masks.lecturerUnavailable.Row(lecturerIdx))
+        if lecturerIdx < lecturerOccupied.Rows() {
+            busy.Or(lecturerOccupied.Row(lecturerIdx))
+        }
+    }
+    for _, c := range session.clashCohortKeys() {
+        // an unknown cohort conflicts with every slot
+        if c < 0 || c >= cohortOccupied.Rows() {
+            return feasible
         }
+        busy.Or(cohortOccupied.Row(c))
+    }
 
-        // prevents venue conflict
-        for _, v := range session.AllowedVenuesIdx {
-            // Add bounds checking for venue index
-            if v < 0 || v >= len(venueOccupied) {
+    starts := masks.startsFor(duration)
+    for _, venues := range venueGroupsOf(masks, session) {
+        for _, start := range starts {
+            if busy.AnyInRange(start, duration) {
                 continue
             }
-            
-            venueOk := true
-            for vi := start; vi < s; vi++ {
-                if vi >= totalSlots {
-                    venueOk = false
-                    break
-                }
-                // Check venue unavailable bounds
-                if v < len(pre.VenueUnavailable) && vi < len(pre.VenueUnavailable[v]) && pre.VenueUnavailable[v][vi] {
-                    venueOk = false
-                    break
+            // prevents venue conflict
+            for _, v := range venues {
+                if masks.venueUnavailable.AnyInRange(v, start, duration) || venueOccupied.AnyInRange(v, start, duration) {
+                    continue
                 }
-                if vi < len(venueOccupied[v]) && venueOccupied[v][vi] {
-                    venueOk = false
-                    break
+                feasible = append(feasible, FeasiblePair{
+                    SlotIdx:  start,
+                    VenueIdx: v,
+                    Score:    wastedSeats(pre, session, v),
+                    Reasons:  "",
+                })
+                found++
+                if limit > 0 && found >= limit {
+                    return feasible
                 }
             }
-            if !venueOk {
-                continue
-            }
-            feasible = append(feasible, FeasiblePair{
-                SlotIdx:  start,
-                VenueIdx: v,
-                Score:    0.0,
-                Reasons:  "",
-            })
         }
     }
     return feasible
 }
-func ComputeLeastBadPair(pre *PreComputed, session *SessionAtom, venueOccupied [][]bool, lecturerOccupied [][]bool, cohortOccupied [][]bool) FeasiblePair {
-    totalSlots := pre.TotalSlots
 
+// the allowed venues of the session grouped by how many seats they waste, snuggest first
+func venueGroupsOf(masks *staticMasks, session *SessionAtom) [][]int {
+    if session.SessionIdx >= 0 && session.SessionIdx < len(masks.venueGroups) {
+        return masks.venueGroups[session.SessionIdx]
+    }
+    return nil
+}
+
+// fraction of the venue left empty by the session, 0 when the capacity is unknown
+func wastedSeats(pre *PreComputed, session *SessionAtom, venueIdx int) float64 {
+    if venueIdx >= len(pre.VenueCapacities) || pre.VenueCapacities[venueIdx] <= 0 {
+        return 0
+    }
+    capacity := float64(pre.VenueCapacities[venueIdx])
+    return (capacity - float64(session.Headcount)) / capacity
+}
+func ComputeLeastBadPair(pre *PreComputed, session *SessionAtom, venueOccupied *Occupancy, lecturerOccupied *Occupancy, cohortOccupied *Occupancy) FeasiblePair {
     bestPair := FeasiblePair{
         Score: math.MaxFloat64,
     }
 
     // Add bounds checking for session indices
-    if session.LecturerIdx < 0 || session.LecturerIdx >= len(pre.LecturerUnavailable) {
-        slog.Error("Invalid lecturer index in ComputeLeastBadPair", "lecturerIdx", session.LecturerIdx)
+    if !validLecturerIdxs(pre, session) {
+        slog.Error("Invalid lecturer index in ComputeLeastBadPair", "lecturerIdxs", session.LecturerIdxs)
         return bestPair
     }
-
-    for start := 0; start < totalSlots; start++ {
-        // prevents cross day boundary i.e a session crossing a day
-        startDaySlot := (start / pre.SlotsPerDay) * pre.SlotsPerDay
-        EndDaySlot := startDaySlot + pre.SlotsPerDay
-
-        if start+session.SessionDuration > EndDaySlot {
+    masks := pre.masks()
+    duration := session.SessionDuration
+
+    for _, start := range masks.startsFor(duration) {
+        // the lecturers and cohorts cost the same whatever the venue
+        sessionScore := 0.0
+        for _, lecturerIdx := range session.LecturerIdxs {
+            sessionScore += 10 * float64(masks.lecturerUnavailable.CountInRange(lecturerIdx, start, duration))
+            sessionScore += 1500 * float64(lecturerOccupied.CountInRange(lecturerIdx, start, duration))
+        }
+        for _, c := range session.clashCohortKeys() {
+            if c >= 0 && c < cohortOccupied.Rows() {
+                sessionScore += 500 * float64(cohortOccupied.CountInRange(c, start, duration))
+            } else {
+                sessionScore += 500 * float64(duration) // Penalty for invalid cohort index
+            }
+        }
+        // no venue can make this start better than the best so far
+        if sessionScore >= bestPair.Score {
             continue
         }
 
         for _, v := range session.AllowedVenuesIdx {
             // Skip invalid venue indices
-            if v < 0 || v >= len(venueOccupied) {
+            if v < 0 || v >= venueOccupied.Rows() {
                 continue
             }
+            conflictScore := sessionScore
+            conflictScore += 10 * float64(masks.venueUnavailable.CountInRange(v, start, duration))
+            conflictScore += 1500 * float64(venueOccupied.CountInRange(v, start, duration))
 
-            conflictScore := 0.0
-            s := start + session.SessionDuration
-
-            for si := start; si < s; si++ {
-                if si >= totalSlots {
-                    conflictScore += 1500 // Penalty for out of bounds
-                    continue
-                }
-
-                // Lecturer unavailable check with bounds
-                if si < len(pre.LecturerUnavailable[session.LecturerIdx]) {
-                    if pre.LecturerUnavailable[session.LecturerIdx][si] {
-                        conflictScore += 10
-                    }
-                }
-
-                // Lecturer occupied check with bounds
-                if session.LecturerIdx < len(lecturerOccupied) && si < len(lecturerOccupied[session.LecturerIdx]) {
-                    if lecturerOccupied[session.LecturerIdx][si] {
-                        conflictScore += 1500
-                    }
-                }
-
-                // Venue unavailable check with bounds
-                if v < len(pre.VenueUnavailable) && si < len(pre.VenueUnavailable[v]) {
-                    if pre.VenueUnavailable[v][si] {
-                        conflictScore += 10
-                    }
-                }
-
-                // Venue occupied check with bounds
-                if v < len(venueOccupied) && si < len(venueOccupied[v]) {
-                    if venueOccupied[v][si] {
-                        conflictScore += 1500
-                    }
-                }
-
-                // Cohort occupied check with bounds
-                for _, c := range session.CohortIdxs {
-                    if c >= 0 && c < len(cohortOccupied) && si < len(cohortOccupied[c]) {
-                        if cohortOccupied[c][si] {
-                            conflictScore += 500
-                        }
-                    } else {
-                        conflictScore += 500 // Penalty for invalid cohort index
-                    }
-                }
-            }
-            
             if conflictScore < bestPair.Score {
                 bestPair.Score = conflictScore
                 bestPair.VenueIdx = v
                 bestPair.SlotIdx = start
             }
         }
+        // nothing beats a pair without any conflict
+        if bestPair.Score == 0 {
+            break
+        }
     }
     return bestPair
 }
 
-// this function builds a candidate timetable
-func BuildOneCandidate(r *rand.Rand, pre *PreComputed, k int) *Candidate {
+// this function builds a candidate timetable. buf is reused as scratch space, fresh buffers are
+// used when it is nil
+func BuildOneCandidate(r *rand.Rand, pre *PreComputed, k int, buf *SearchBuffers) *Candidate {
 	 if pre == nil {
         slog.Error("PreComputed is nil")
         return &Candidate{Placements: []SessionPlacement{}}
@@ -280,62 +273,34 @@ func BuildOneCandidate(r *rand.Rand, pre *PreComputed, k int) *Candidate {
         "totalLecturers", pre.NumLecturers,
         "totalSlots", pre.TotalSlots,
         "totalCohorts", pre.NumCohorts)
+	if buf == nil {
+		buf = NewSearchBuffers(pre)
+	}
 	totalSessions := len(pre.SessionAtoms)
-	totalVenues := pre.NumVenues
-	totalLecturers := pre.NumLecturers
-	totalSlots := pre.TotalSlots
-	totalCohorts := pre.NumCohorts
 
 	placements := make([]SessionPlacement, totalSessions)
 
-	// create an order slice to be used to order session placement
-	order := make([]int, totalSessions)
-
-	// input appropriate matching indexes into order array
-	for i := 0; i < totalSessions; i++ {
-		order[i] = i
-	}
-
-	// sort the order array according giving preference to sessions with less allowed venues and longer durations
-	sort.Slice(order, func(i, j int) bool {
-		a := pre.SessionAtoms[order[i]]
-		b := pre.SessionAtoms[order[j]]
-		scoreA := float64(len(a.AllowedVenuesIdx))*0.5 + float64(a.SessionDuration)*1.0
-		scoreB := float64(len(b.AllowedVenuesIdx))*0.5 + float64(b.SessionDuration)*1.0
-		return scoreA < scoreB // less allowed venues = harder -> come earlier
-	})
-
-	// compute venueOccupied
-	venueOcc := make([][]bool, totalVenues)
-	for i := range venueOcc {
-		venueOcc[i] = make([]bool, totalSlots)
-	}
-
-	// compute lecturerOccupied
-	lecturerOcc := make([][]bool, totalLecturers)
-	for i := range lecturerOcc {
-		lecturerOcc[i] = make([]bool, totalSlots)
-	}
-
-	// compute cohortsOccupied
-	cohortOcc := make([][]bool, totalCohorts)
-	for i := range cohortOcc {
-		cohortOcc[i] = make([]bool, totalSlots)
+	// pinned sessions go in first so everything else is placed around them
+	for sessionIdx := range pre.SessionAtoms {
+		if pre.SessionAtoms[sessionIdx].Pinned {
+			placements[sessionIdx] = pinnedPlacement(&pre.SessionAtoms[sessionIdx])
+		}
 	}
+	buf.reset(pre)
 
-	// placement of sessions into appropriate slots and venue in order
-	for _, sessionIdx := range order {
-		if sessionIdx < 0 || sessionIdx >= len(pre.SessionAtoms) {
+	// placement of sessions into appropriate slots and venue in order, the hardest first
+	for _, sessionIdx := range pre.masks().placementOrder {
+		session := &pre.SessionAtoms[sessionIdx]
+		if session.Pinned {
 			continue
 		}
 
-		session := &pre.SessionAtoms[sessionIdx]
-
-		feasible := ComputeFeasiblePairs(pre, session, venueOcc, lecturerOcc, cohortOcc)
+		// only the top k are ever sampled from
+		buf.feasible = appendFeasiblePairs(pre, session, buf.VenueOcc, buf.LecturerOcc, buf.CohortOcc, buf.busy, buf.feasible[:0], max(k, 1))
 
 		// if there are feasible pairs
-		if len(feasible) > 0 {
-			chosen := ChooseTopSampleK(feasible, k, r)
+		if len(buf.feasible) > 0 {
+			chosen := ChooseTopSampleK(buf.feasible, k, r)
 			placements[sessionIdx] = SessionPlacement{
 				SessionIdx: sessionIdx,
 				CourseIdx:  session.CourseIdx,
@@ -344,29 +309,9 @@ func BuildOneCandidate(r *rand.Rand, pre *PreComputed, k int) *Candidate {
 				Conflict:   false,
 				Score:      0.0,
 			}
-
-			// mark occupancy
-			for d := 0; d < session.SessionDuration; d++ {
-				// si means slot index
-				si := chosen.SlotIdx + d
-				if si >= totalSlots {
-					break
-				}
-				if chosen.VenueIdx < len(venueOcc) && si < len(venueOcc[chosen.VenueIdx]) {
-					venueOcc[chosen.VenueIdx][si] = true
-				}
-				if session.LecturerIdx < len(lecturerOcc) && si < len(lecturerOcc[session.LecturerIdx]) {
-					lecturerOcc[session.LecturerIdx][si] = true
-				}
-				for _, c := range session.CohortIdxs {
-					if c < len(cohortOcc) && si < len(cohortOcc[c]) {
-						cohortOcc[c][si] = true
-					}
-				}
-			}
 		} else {
 			// fallback: selects least bad pair
-			best := ComputeLeastBadPair(pre, session, venueOcc, lecturerOcc, cohortOcc)
+			best := ComputeLeastBadPair(pre, session, buf.VenueOcc, buf.LecturerOcc, buf.CohortOcc)
 			placements[sessionIdx] = SessionPlacement{
 				SessionIdx: sessionIdx,
 				CourseIdx:  session.CourseIdx,
@@ -375,27 +320,9 @@ func BuildOneCandidate(r *rand.Rand, pre *PreComputed, k int) *Candidate {
 				Conflict:   true,
 				Score:      best.Score,
 			}
-
-			// mark tempoary occupancy
-			for d := 0; d < session.SessionDuration; d++ {
-				// si means slot index
-				si := best.SlotIdx + d
-				if si >= totalSlots {
-					break
-				}
-				if best.VenueIdx < len(venueOcc) && si < len(venueOcc[best.VenueIdx]) {
-					venueOcc[best.VenueIdx][si] = true
-				}
-				if session.LecturerIdx < len(lecturerOcc) && si < len(lecturerOcc[session.LecturerIdx]) {
-					lecturerOcc[session.LecturerIdx][si] = true
-				}
-				for _, c := range session.CohortIdxs {
-					if c < len(cohortOcc) && si < len(cohortOcc[c]) {
-						cohortOcc[c][si] = true
-					}
-				}
-			}
 		}
+		// mark occupancy
+		bookSession(session, placements[sessionIdx].SlotIdx, placements[sessionIdx].VenueIdx, buf.VenueOcc, buf.LecturerOcc, buf.CohortOcc)
 	}
 
 	return &Candidate{
@@ -403,42 +330,76 @@ func BuildOneCandidate(r *rand.Rand, pre *PreComputed, k int) *Candidate {
 	}
 }
 
-// calculate and return the fitness for a candidate timetable.. the lower the better the timetable
-func ComputeCandidateFitness(candidate *Candidate) float64 {
-	fitnessScore := 0.0
+// calculate and return the total penalty for a candidate timetable.. the lower the better the timetable.
+// buf is the scratch space of the worker, nil scores in buffers of its own
+func ComputeCandidateFitness(pre *PreComputed, candidate *Candidate, buf *SearchBuffers) float64 {
+	hardPenalty := 0.0
 
 	for sessIdx := 0; sessIdx < len(candidate.Placements); sessIdx++ {
-		fitnessScore += candidate.Placements[sessIdx].Score
+		hardPenalty += candidate.Placements[sessIdx].Score
+		// a mutation can drop a session outside the hours of its day
+		placement := candidate.Placements[sessIdx]
+		if placement.SessionIdx >= 0 && placement.SessionIdx < len(pre.SessionAtoms) && isBlocked(pre, placement.SlotIdx, pre.SessionAtoms[placement.SessionIdx].SessionDuration) {
+			hardPenalty += 1500
+		}
 	}
+	constraintHard, softPenalty := ComputeConstraintPenalties(pre, candidate, buf)
+	hardPenalty += constraintHard
+	fitnessScore := hardPenalty + softPenalty
 
 	// adds the fitness to the candidate object directly
+	candidate.HardPenalty = hardPenalty
+	candidate.SoftPenalty = softPenalty
 	candidate.Fitness = 1.0 / (1 + fitnessScore)
 
 	return fitnessScore
 }
 
-func BuildPopulation(pre *PreComputed, seed int64, populationSize int, K int) []*Candidate {
+// builds the population on one worker per buffers. every candidate gets its own seed up front
+// so the population is the same whatever the number of workers
+func BuildPopulation(pre *PreComputed, seed int64, populationSize int, K int, buffers []*SearchBuffers) []*Candidate {
 	r := rand.New(rand.NewSource(seed))
-	pop := make([]*Candidate, 0, populationSize)
-
-	for i := 0; i < populationSize; i++ {
-		c := BuildOneCandidate(rand.New(rand.NewSource(r.Int63())), pre, K)
-		ComputeCandidateFitness(c)
-		pop = append(pop, c)
+	seeds := make([]int64, populationSize)
+	for i := range seeds {
+		seeds[i] = r.Int63()
 	}
 
+	pop := make([]*Candidate, populationSize)
+	runOnWorkers(populationSize, len(buffers), func(worker int, i int) {
+		c := BuildOneCandidate(rand.New(rand.NewSource(seeds[i])), pre, K, buffers[worker])
+		ComputeCandidateFitness(pre, c, buffers[worker])
+		pop[i] = c
+	})
 	return pop
 }
 
+// calls work for every index below n on a bounded pool of workers. worker w takes the indexes
+// w, w+workers, w+2*workers... in that order so a worker's own rand source is always used in the
+// same order
+func runOnWorkers(n int, workers int, work func(worker int, i int)) {
+	workers = max(min(workers, n), 1)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := worker; i < n; i += workers {
+				work(worker, i)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
 // basically returns a best fit candidate out of a K options
-func Selection(pop []*Candidate, tournamentSize int) *Candidate {
+func Selection(pop []*Candidate, tournamentSize int, r *rand.Rand) *Candidate {
 	if len(pop) == 0 {
 		return nil
 	}
-	best := pop[rand.Intn(len(pop))]
+	best := pop[r.Intn(len(pop))]
 
 	for c := 0; c < tournamentSize; c++ {
-		challenger := pop[rand.Intn(len(pop))]
+		challenger := pop[r.Intn(len(pop))]
 
 		if challenger.Fitness > best.Fitness {
 			best = challenger
@@ -476,9 +437,9 @@ func ComputeCourseSessions(pre *PreComputed) [][]SessionAtom {
 }
 
 // returns 2 good fit parents
-func SelectParents(pop []*Candidate, tournamentSize int) (*Candidate, *Candidate) {
-	parent1 := Selection(pop, tournamentSize)
-	parent2 := Selection(pop, tournamentSize)
+func SelectParents(pop []*Candidate, tournamentSize int, r *rand.Rand) (*Candidate, *Candidate) {
+	parent1 := Selection(pop, tournamentSize, r)
+	parent2 := Selection(pop, tournamentSize, r)
 	
 	// Ensure we don't return nil parents
 	if parent1 == nil || parent2 == nil {
@@ -495,148 +456,98 @@ func SelectParents(pop []*Candidate, tournamentSize int) (*Candidate, *Candidate
 	return parent1, parent2
 }
 
-func DetermineBestParent(pre *PreComputed, parent1 *Candidate, parent2 *Candidate, CourseIdx int, lecturerOcc [][]bool, venueOcc [][]bool, cohortOcc [][]bool) []SessionPlacement {
-	parent1ConflictScore := 0
-	parent1SessionPlacements := make([]SessionPlacement, 0)
-
-	parent2ConflictScore := 0
-	parent2SessionPlacements := make([]SessionPlacement, 0)
+// the positions of the placements of every course in placements, reusing the slices of positions
+func groupPlacementsByCourse(placements []SessionPlacement, numCourses int, positions [][]int) [][]int {
+	if cap(positions) < numCourses {
+		positions = append(positions[:cap(positions)], make([][]int, numCourses-cap(positions))...)
+	}
+	positions = positions[:numCourses]
+	for i := range positions {
+		positions[i] = positions[i][:0]
+	}
+	for i, placement := range placements {
+		if placement.CourseIdx >= 0 && placement.CourseIdx < numCourses {
+			positions[placement.CourseIdx] = append(positions[placement.CourseIdx], i)
+		}
+	}
+	return positions
+}
 
-	for _, session := range parent1.Placements {
+// appends the placements of a parent at positions to chosen and scores how badly they clash with
+// what is already marked
+func parentCoursePlacements(pre *PreComputed, parent *Candidate, positions []int, chosen []SessionPlacement, lecturerOcc *Occupancy, venueOcc *Occupancy, cohortOcc *Occupancy) (int, []SessionPlacement) {
+	conflictScore := 0
+	for _, pos := range positions {
+		session := parent.Placements[pos]
 		// Add bounds checking for session.SessionIdx
 		if session.SessionIdx < 0 || session.SessionIdx >= len(pre.SessionAtoms) {
 			continue
 		}
-		
-		hasConflict := false
-		lecturerIdx := pre.SessionAtoms[session.SessionIdx].LecturerIdx
-		cohortIdxs := pre.SessionAtoms[session.SessionIdx].CohortIdxs
-		
-		if session.CourseIdx == CourseIdx {
-			// Check lecturer bounds
-			if lecturerIdx >= 0 && lecturerIdx < len(lecturerOcc) && session.SlotIdx < len(lecturerOcc[lecturerIdx]) {
-				if lecturerOcc[lecturerIdx][session.SlotIdx] {
-					parent1ConflictScore += 1500
-					hasConflict = true
-				}
-			} else {
-				// If bounds are invalid, count as conflict
-				parent1ConflictScore += 1500
-				hasConflict = true
-			}
-
-			// Check venue bounds
-			if session.VenueIdx >= 0 && session.VenueIdx < len(venueOcc) && session.SlotIdx < len(venueOcc[session.VenueIdx]) {
-				if venueOcc[session.VenueIdx][session.SlotIdx] {
-					parent1ConflictScore += 1500
-					hasConflict = true
-				}
-			} else {
-				parent1ConflictScore += 1500
-				hasConflict = true
-			}
-
-			// Check cohort bounds
-			for _, cohortIdx := range cohortIdxs {
-				if cohortIdx >= 0 && cohortIdx < len(cohortOcc) && session.SlotIdx < len(cohortOcc[cohortIdx]) {
-					if cohortOcc[cohortIdx][session.SlotIdx] {
-						parent1ConflictScore += 500
-						hasConflict = true
-					}
-				} else {
-					parent1ConflictScore += 500
-					hasConflict = true
-				}
-			}
-			
-			session.Conflict = hasConflict
-			parent1SessionPlacements = append(parent1SessionPlacements, session)
-		}
-	}
+		sessionAtom := &pre.SessionAtoms[session.SessionIdx]
 
-	// Apply the same bounds checking for parent2
-	for _, session := range parent2.Placements {
-		if session.SessionIdx < 0 || session.SessionIdx >= len(pre.SessionAtoms) {
+		// a pinned session is already marked and never moves
+		if sessionAtom.Pinned {
+			chosen = append(chosen, pinnedPlacement(sessionAtom))
 			continue
 		}
-		
+
 		hasConflict := false
-		lecturerIdx := pre.SessionAtoms[session.SessionIdx].LecturerIdx
-		cohortIdxs := pre.SessionAtoms[session.SessionIdx].CohortIdxs
-		
-		if session.CourseIdx == CourseIdx {
-			// Check lecturer bounds for parent2
-			if lecturerIdx >= 0 && lecturerIdx < len(lecturerOcc) && session.SlotIdx < len(lecturerOcc[lecturerIdx]) {
-				if lecturerOcc[lecturerIdx][session.SlotIdx] {
-					parent2ConflictScore += 1500
-					hasConflict = true
-				}
-			} else {
-				parent2ConflictScore += 1500
+		// Check lecturer bounds of every lecturer of the session, invalid bounds count as a conflict
+		for _, lecturerIdx := range sessionAtom.LecturerIdxs {
+			if !lecturerOcc.Contains(lecturerIdx, session.SlotIdx) || lecturerOcc.Test(lecturerIdx, session.SlotIdx) {
+				conflictScore += 1500
 				hasConflict = true
 			}
-
-			// Check venue bounds for parent2
-			if session.VenueIdx >= 0 && session.VenueIdx < len(venueOcc) && session.SlotIdx < len(venueOcc[session.VenueIdx]) {
-				if venueOcc[session.VenueIdx][session.SlotIdx] {
-					parent2ConflictScore += 1500
-					hasConflict = true
-				}
-			} else {
-				parent2ConflictScore += 1500
+		}
+		// Check venue bounds
+		if !venueOcc.Contains(session.VenueIdx, session.SlotIdx) || venueOcc.Test(session.VenueIdx, session.SlotIdx) {
+			conflictScore += 1500
+			hasConflict = true
+		}
+		// Check cohort bounds
+		for _, cohortIdx := range sessionAtom.clashCohortKeys() {
+			if !cohortOcc.Contains(cohortIdx, session.SlotIdx) || cohortOcc.Test(cohortIdx, session.SlotIdx) {
+				conflictScore += 500
 				hasConflict = true
 			}
-
-			// Check cohort bounds for parent2
-			for _, cohortIdx := range cohortIdxs {
-				if cohortIdx >= 0 && cohortIdx < len(cohortOcc) && session.SlotIdx < len(cohortOcc[cohortIdx]) {
-					if cohortOcc[cohortIdx][session.SlotIdx] {
-						parent2ConflictScore += 500
-						hasConflict = true
-					}
-				} else {
-					parent2ConflictScore += 500
-					hasConflict = true
-				}
-			}
-			
-			session.Conflict = hasConflict
-			parent2SessionPlacements = append(parent2SessionPlacements, session)
 		}
+
+		session.Conflict = hasConflict
+		chosen = append(chosen, session)
 	}
+	return conflictScore, chosen
+}
+
+// the placements of the course from whichever parent clashes less with what is already marked in
+// buf. the course positions of both parents must have been grouped in buf, the result is only valid
+// until the next call
+func DetermineBestParent(pre *PreComputed, parent1 *Candidate, parent2 *Candidate, CourseIdx int, buf *SearchBuffers) []SessionPlacement {
+	var parent1ConflictScore, parent2ConflictScore int
+	parent1ConflictScore, buf.chosen1 = parentCoursePlacements(pre, parent1, buf.courses1[CourseIdx], buf.chosen1[:0], buf.LecturerOcc, buf.VenueOcc, buf.CohortOcc)
+	parent2ConflictScore, buf.chosen2 = parentCoursePlacements(pre, parent2, buf.courses2[CourseIdx], buf.chosen2[:0], buf.LecturerOcc, buf.VenueOcc, buf.CohortOcc)
 
 	if parent1ConflictScore < parent2ConflictScore {
-		return parent1SessionPlacements
+		return buf.chosen1
 	} else {
-		return parent2SessionPlacements
+		return buf.chosen2
 	}
 }
 
-func RepairChildCandidate(pre *PreComputed, childCandidate []SessionPlacement, lecOcc [][]bool, venueOcc [][]bool, cohortOcc [][]bool) {
+func RepairChildCandidate(pre *PreComputed, childCandidate []SessionPlacement, lecOcc *Occupancy, venueOcc *Occupancy, cohortOcc *Occupancy) {
 	for idx := 0; idx < len(childCandidate); idx++ {
 		if childCandidate[idx].SessionIdx < 0 || childCandidate[idx].SessionIdx >= len(pre.SessionAtoms) {
 			continue
 		}
 		
-		sessionAtom := pre.SessionAtoms[childCandidate[idx].SessionIdx]
+		sessionAtom := &pre.SessionAtoms[childCandidate[idx].SessionIdx]
+		if sessionAtom.Pinned {
+			continue
+		}
 		if childCandidate[idx].Conflict {
-			leastBadPair := ComputeLeastBadPair(pre, &sessionAtom, venueOcc, lecOcc, cohortOcc)
+			leastBadPair := ComputeLeastBadPair(pre, sessionAtom, venueOcc, lecOcc, cohortOcc)
 
-			for d := 0; d < sessionAtom.SessionDuration; d++ {
-				si := childCandidate[idx].SlotIdx + d
-				// remove old occupancy with bounds checking
-				if sessionAtom.LecturerIdx < len(lecOcc) && si < len(lecOcc[sessionAtom.LecturerIdx]) {
-					lecOcc[sessionAtom.LecturerIdx][si] = false
-				}
-				if childCandidate[idx].VenueIdx < len(venueOcc) && si < len(venueOcc[childCandidate[idx].VenueIdx]) {
-					venueOcc[childCandidate[idx].VenueIdx][si] = false
-				}
-				for _, cohortIdx := range sessionAtom.CohortIdxs {
-					if cohortIdx < len(cohortOcc) && si < len(cohortOcc[cohortIdx]) {
-						cohortOcc[cohortIdx][si] = false
-					}
-				}
-			}
+			// remove old occupancy
+			unbookSession(sessionAtom, childCandidate[idx].SlotIdx, childCandidate[idx].VenueIdx, venueOcc, lecOcc, cohortOcc)
 
 			childCandidate[idx].SlotIdx = leastBadPair.SlotIdx
 			childCandidate[idx].VenueIdx = leastBadPair.VenueIdx
@@ -644,49 +555,22 @@ func RepairChildCandidate(pre *PreComputed, childCandidate []SessionPlacement, l
 
 			// modify the childCandidate[idx] with the least bad pair
 			childCandidate[idx].Conflict = (leastBadPair.Score > 0.0)
-			for d := 0; d < sessionAtom.SessionDuration; d++ {
-				si := childCandidate[idx].SlotIdx + d
-				if si >= pre.TotalSlots {
-					break
-				}
-				// update occupancy with bounds checking
-				if sessionAtom.LecturerIdx < len(lecOcc) && si < len(lecOcc[sessionAtom.LecturerIdx]) {
-					lecOcc[sessionAtom.LecturerIdx][si] = true
-				}
-				if childCandidate[idx].VenueIdx < len(venueOcc) && si < len(venueOcc[childCandidate[idx].VenueIdx]) {
-					venueOcc[childCandidate[idx].VenueIdx][si] = true
-				}
-				for _, cohortIdx := range sessionAtom.CohortIdxs {
-					if cohortIdx < len(cohortOcc) && si < len(cohortOcc[cohortIdx]) {
-						cohortOcc[cohortIdx][si] = true
-					}
-				}
-			}
+			bookSession(sessionAtom, childCandidate[idx].SlotIdx, childCandidate[idx].VenueIdx, venueOcc, lecOcc, cohortOcc)
 		}
 	}
 }
 
-func Crossover(pre *PreComputed, parent1 *Candidate, parent2 *Candidate, CourseSessions [][]SessionAtom) (*Candidate, [][]bool, [][]bool, [][]bool) {
-	totalSlots := pre.TotalSlots
-	childCandidate := make([]SessionPlacement, 0, totalSlots)
+// builds a child from the two parents course by course. the occupancy of the child is left in buf
+// for Mutation
+func Crossover(pre *PreComputed, parent1 *Candidate, parent2 *Candidate, CourseSessions [][]SessionAtom, buf *SearchBuffers) *Candidate {
+	childCandidate := make([]SessionPlacement, 0, len(pre.SessionAtoms))
 
-	venueOccupied := make([][]bool, pre.NumVenues)
-	for i := range venueOccupied {
-		venueOccupied[i] = make([]bool, totalSlots)
-	}
-
-	lecturerOccupied := make([][]bool, pre.NumLecturers)
-	for i := range lecturerOccupied {
-		lecturerOccupied[i] = make([]bool, totalSlots)
-	}
-
-	cohortOccupied := make([][]bool, pre.NumCohorts)
-	for i := range cohortOccupied {
-		cohortOccupied[i] = make([]bool, totalSlots)
-	}
+	buf.reset(pre)
+	buf.courses1 = groupPlacementsByCourse(parent1.Placements, len(CourseSessions), buf.courses1)
+	buf.courses2 = groupPlacementsByCourse(parent2.Placements, len(CourseSessions), buf.courses2)
 
 	for courseIdx := range CourseSessions {
-		placements := DetermineBestParent(pre, parent1, parent2, courseIdx, lecturerOccupied, venueOccupied, cohortOccupied)
+		placements := DetermineBestParent(pre, parent1, parent2, courseIdx, buf)
 		for _, placement := range placements {
 			childCandidate = append(childCandidate, placement)
 			
@@ -694,75 +578,43 @@ func Crossover(pre *PreComputed, parent1 *Candidate, parent2 *Candidate, CourseS
 			if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) {
 				continue
 			}
-			
-			lecturerIdx := pre.SessionAtoms[placement.SessionIdx].LecturerIdx
-			cohortIdxs := pre.SessionAtoms[placement.SessionIdx].CohortIdxs
-
-			sessionAtom := pre.SessionAtoms[placement.SessionIdx]
-			for d := 0; d < sessionAtom.SessionDuration; d++ {
-				si := d + placement.SlotIdx
-				if si >= pre.TotalSlots {
-					break
-				}
-				// mark occupancy for lecturer venue and cohorts with bounds checking
-				if lecturerIdx < len(lecturerOccupied) && si < len(lecturerOccupied[lecturerIdx]) {
-					lecturerOccupied[lecturerIdx][si] = true
-				}
-				if placement.VenueIdx < len(venueOccupied) && si < len(venueOccupied[placement.VenueIdx]) {
-					venueOccupied[placement.VenueIdx][si] = true
-				}
-				for _, cohortidx := range cohortIdxs {
-					if cohortidx < len(cohortOccupied) && si < len(cohortOccupied[cohortidx]) {
-						cohortOccupied[cohortidx][si] = true
-					}
-				}
+
+			sessionAtom := &pre.SessionAtoms[placement.SessionIdx]
+			// pinned occupancy was marked before any parent was looked at
+			if sessionAtom.Pinned {
+				continue
 			}
+			// mark occupancy for lecturer venue and cohorts
+			bookSession(sessionAtom, placement.SlotIdx, placement.VenueIdx, buf.VenueOcc, buf.LecturerOcc, buf.CohortOcc)
 		}
 	}
 
 	// then i would run a repair function on the child and return the child timetable
-	RepairChildCandidate(pre, childCandidate, lecturerOccupied, venueOccupied, cohortOccupied)
+	RepairChildCandidate(pre, childCandidate, buf.LecturerOcc, buf.VenueOcc, buf.CohortOcc)
 
 	// calculate fitness of child candidate
 	actualChildCandidate := &Candidate{
 		Placements: childCandidate,
 	}
-	return actualChildCandidate, lecturerOccupied, venueOccupied, cohortOccupied
+	return actualChildCandidate
 }
 
-func Mutation(pre *PreComputed, childCandidate *Candidate, r *rand.Rand, lecOcc [][]bool, venueOcc [][]bool, cohortOcc [][]bool) {
-	mutationRate := 0.05
-
+// moves some placements of the child, buf must hold the occupancy of the child as Crossover left it
+func Mutation(pre *PreComputed, childCandidate *Candidate, r *rand.Rand, mutationRate float64, buf *SearchBuffers) {
+	lecOcc, venueOcc, cohortOcc := buf.LecturerOcc, buf.VenueOcc, buf.CohortOcc
 	for placementIdx, placement := range childCandidate.Placements {
 		if r.Float64() < mutationRate {
-			// Add bounds checking
-			if placementIdx < 0 || placementIdx >= len(childCandidate.Placements) {
-				continue
-			}
 			if childCandidate.Placements[placementIdx].SessionIdx < 0 || childCandidate.Placements[placementIdx].SessionIdx >= len(pre.SessionAtoms) {
 				continue
 			}
 			
-			sessionAtom := pre.SessionAtoms[childCandidate.Placements[placementIdx].SessionIdx]
+			sessionAtom := &pre.SessionAtoms[childCandidate.Placements[placementIdx].SessionIdx]
+			if sessionAtom.Pinned {
+				continue
+			}
 
 			// remove occupancy
-			for d := 0; d < sessionAtom.SessionDuration; d++ {
-				si := placement.SlotIdx + d
-				if si >= pre.TotalSlots {
-					break
-				}
-				if sessionAtom.LecturerIdx < len(lecOcc) && si < len(lecOcc[sessionAtom.LecturerIdx]) {
-					lecOcc[sessionAtom.LecturerIdx][si] = false
-				}
-				if placement.VenueIdx < len(venueOcc) && si < len(venueOcc[placement.VenueIdx]) {
-					venueOcc[placement.VenueIdx][si] = false
-				}
-				for _, cohortidx := range sessionAtom.CohortIdxs {
-					if cohortidx < len(cohortOcc) && si < len(cohortOcc[cohortidx]) {
-						cohortOcc[cohortidx][si] = false
-					}
-				}
-			}
+			unbookSession(sessionAtom, placement.SlotIdx, placement.VenueIdx, venueOcc, lecOcc, cohortOcc)
 
 			//  should run and add some variety for 30% of the chosen placements
 			if r.Float64() > 0.3 {
@@ -781,7 +633,7 @@ func Mutation(pre *PreComputed, childCandidate *Candidate, r *rand.Rand, lecOcc
 				}
 			} else {
 				// for the other 70%
-				leastBadPair := ComputeLeastBadPair(pre, &sessionAtom, venueOcc, lecOcc, cohortOcc)
+				leastBadPair := ComputeLeastBadPair(pre, sessionAtom, venueOcc, lecOcc, cohortOcc)
 				childCandidate.Placements[placementIdx].SlotIdx = leastBadPair.SlotIdx
 				childCandidate.Placements[placementIdx].VenueIdx = leastBadPair.VenueIdx
 				childCandidate.Placements[placementIdx].Score = leastBadPair.Score
@@ -789,23 +641,7 @@ func Mutation(pre *PreComputed, childCandidate *Candidate, r *rand.Rand, lecOcc
 			}
 
 			// reoccupy occupancy
-			for d := 0; d < sessionAtom.SessionDuration; d++ {
-				si := childCandidate.Placements[placementIdx].SlotIdx + d
-				if si >= pre.TotalSlots {
-					break
-				}
-				if sessionAtom.LecturerIdx < len(lecOcc) && si < len(lecOcc[sessionAtom.LecturerIdx]) {
-					lecOcc[sessionAtom.LecturerIdx][si] = true
-				}
-				if childCandidate.Placements[placementIdx].VenueIdx < len(venueOcc) && si < len(venueOcc[childCandidate.Placements[placementIdx].VenueIdx]) {
-					venueOcc[childCandidate.Placements[placementIdx].VenueIdx][si] = true
-				}
-				for _, cohortidx := range sessionAtom.CohortIdxs {
-					if cohortidx < len(cohortOcc) && si < len(cohortOcc[cohortidx]) {
-						cohortOcc[cohortidx][si] = true
-					}
-				}
-			}
+			bookSession(sessionAtom, childCandidate.Placements[placementIdx].SlotIdx, childCandidate.Placements[placementIdx].VenueIdx, venueOcc, lecOcc, cohortOcc)
 		}
 	}
 }
@@ -816,36 +652,39 @@ func SortPopulation(pop []*Candidate) {
 	})
 }
 
+// keeps the elites and breeds the rest of the next generation on one worker per rand source, each
+// worker with its own buffers. parents are only read so the children can be built at the same time,
+// and the next generation is the same for the same rand sources
 func BuildNextGeneration(pre *PreComputed,
 	previousPopulation []*Candidate,
 	courseSessions [][]SessionAtom,
-	r *rand.Rand,
+	rngs []*rand.Rand,
+	buffers []*SearchBuffers,
+	params GAParams,
 ) []*Candidate {
 	if len(previousPopulation) == 0 {
 		return previousPopulation
 	}
 	
-	topK := int(0.1 * float64(len(previousPopulation)))
+	topK := int(params.ElitismFraction * float64(len(previousPopulation)))
 	if topK < 1 {
 		topK = 1
 	}
 
-	newPopulation := make([]*Candidate, 0)
-
 	SortPopulation(previousPopulation)
 
-	for i := 0; i < len(previousPopulation); i++ {
-		// choose the top k in previous population
-		if i < topK {
-			newPopulation = append(newPopulation, previousPopulation[i])
-		} else {
-			parent1, parent2 := SelectParents(previousPopulation, topK)
-			candidate, lecOcc, venueOcc, cohortOcc := Crossover(pre, parent1, parent2, courseSessions)
-			Mutation(pre, candidate, r, lecOcc, venueOcc, cohortOcc)
-			ComputeCandidateFitness(candidate)
-			newPopulation = append(newPopulation, candidate)
-		}
-	}
+	// choose the top k in previous population
+	newPopulation := make([]*Candidate, len(previousPopulation))
+	copy(newPopulation, previousPopulation[:topK])
+
+	runOnWorkers(len(previousPopulation)-topK, len(rngs), func(worker int, i int) {
+		r := rngs[worker]
+		parent1, parent2 := SelectParents(previousPopulation, params.TournamentSize, r)
+		candidate := Crossover(pre, parent1, parent2, courseSessions, buffers[worker])
+		Mutation(pre, candidate, r, params.MutationRate, buffers[worker])
+		ComputeCandidateFitness(pre, candidate, buffers[worker])
+		newPopulation[topK+i] = candidate
+	})
 	return newPopulation
 }
 
@@ -866,17 +705,124 @@ func SelectBestCandidateFromPopulation(pop []*Candidate) *Candidate {
 	return pop[bestIdx]
 }
 
-func (c *Computed) GeneticAlgorithm(pre *PreComputed) *Candidate {
-	seed := int64(50)
-	populationSize := 100
-	k := 5
-	numberOfGeneration := 100
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	population := BuildPopulation(pre, seed, populationSize, k)
+// reports how far a solver has gone, generations for the genetic algorithm and iterations for a local search
+type GenerationProgress struct {
+	Generation  int
+	Generations int
+	BestFitness float64
+}
+
+// returns the highest fitness in a population without logging the whole population
+func BestFitnessInPopulation(pop []*Candidate) float64 {
+	best := math.Inf(-1)
+	for _, cand := range pop {
+		if cand.Fitness > best {
+			best = cand.Fitness
+		}
+	}
+	return best
+}
+
+// why a genetic run stopped
+const (
+	StopCompleted  = "COMPLETED" // every generation was built
+	StopFeasible   = "FEASIBLE"  // the best candidate broke no hard constraint
+	StopStalled    = "STALLED"   // the best fitness did not improve for StallGenerations
+	StopTimeBudget = "TIME_BUDGET"
+)
+
+// the fitness of a population after a generation, generation 0 is the initial population
+type GenerationStats struct {
+	Generation  int
+	BestFitness float64
+	MeanFitness float64
+}
+
+// the stats of a population and its best candidate
+func populationStats(generation int, pop []*Candidate) (GenerationStats, *Candidate) {
+	stats := GenerationStats{Generation: generation, BestFitness: math.Inf(-1)}
+	var best *Candidate
+	for _, cand := range pop {
+		stats.MeanFitness += cand.Fitness
+		if cand.Fitness > stats.BestFitness {
+			stats.BestFitness = cand.Fitness
+			best = cand
+		}
+	}
+	if len(pop) > 0 {
+		stats.MeanFitness /= float64(len(pop))
+	}
+	return stats, best
+}
+
+// runs the genetic algorithm until all generations are built, a stop condition of params is met or
+// ctx is cancelled. the same params (seed and workers included) on the same pre always give the same
+// candidate unless the time budget stops the run. onProgress (if not nil) is called after every
+// generation. the candidate carries the fitness history of the run and why it stopped
+func GeneticAlgorithm(ctx context.Context, pre *PreComputed, params GAParams, onProgress func(GenerationProgress)) (*Candidate, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	started := time.Now()
+	numberOfGeneration := params.Generations
+	workers := params.WorkerCount()
+	r := rand.New(rand.NewSource(params.Seed))
+	// every worker reuses its own occupancy grids for every candidate it builds
+	buffers := newWorkerBuffers(pre, workers)
+	population := BuildPopulation(pre, r.Int63(), params.PopulationSize, params.SampleK, buffers)
 	courseSessions := ComputeCourseSessions(pre)
 
+	// one rand source per worker for the whole run
+	rngs := make([]*rand.Rand, workers)
+	for w := range rngs {
+		rngs[w] = rand.New(rand.NewSource(r.Int63()))
+	}
+
+	stats, best := populationStats(0, population)
+	history := make([]GenerationStats, 0, numberOfGeneration+1)
+	history = append(history, stats)
+	bestFitness := stats.BestFitness
+	stalled := 0
+
+	stopReason := StopCompleted
 	for i := 0; i < numberOfGeneration; i++ {
-		population = BuildNextGeneration(pre, population, courseSessions, r)
+		// stop as soon as the job has been cancelled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// hard penalties of mutated placements can be stale so feasibility is checked from scratch
+		if params.StopWhenFeasible && len(FindHardViolations(pre, best)) == 0 {
+			stopReason = StopFeasible
+			break
+		}
+		if params.StallGenerations > 0 && stalled >= params.StallGenerations {
+			stopReason = StopStalled
+			break
+		}
+		if params.TimeBudget > 0 && time.Since(started) >= params.TimeBudget {
+			stopReason = StopTimeBudget
+			break
+		}
+
+		population = BuildNextGeneration(pre, population, courseSessions, rngs, buffers, params)
+		stats, best = populationStats(i+1, population)
+		history = append(history, stats)
+		if stats.BestFitness > bestFitness {
+			bestFitness = stats.BestFitness
+			stalled = 0
+		} else {
+			stalled++
+		}
+		if onProgress != nil {
+			onProgress(GenerationProgress{
+				Generation:  i + 1,
+				Generations: numberOfGeneration,
+				BestFitness: stats.BestFitness,
+			})
+		}
 	}
-	return SelectBestCandidateFromPopulation(population)
-}
\ No newline at end of file
+	best = SelectBestCandidateFromPopulation(population)
+	best.History = history
+	best.StopReason = stopReason
+	return best, nil
+}
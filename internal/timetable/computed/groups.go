@@ -0,0 +1,96 @@
+package computed
+
+// how many parallel groups a class of headcount students is split into so no group is bigger
+// than maxGroupSize, 1 when maxGroupSize is 0 or the class already fits
+func groupCount(headcount int, maxGroupSize int) int {
+	if maxGroupSize <= 0 || headcount <= maxGroupSize {
+		return 1
+	}
+	return (headcount + maxGroupSize - 1) / maxGroupSize
+}
+
+// the students of group g of a class split into groups. the headcount is the students enrolled in
+// the course and they are dealt out in turn by their place among them, see
+// GetStudentTimetableSessions, so the first headcount % groups groups get one more
+func groupHeadcount(headcount int, groups int, g int) int {
+	size := headcount / groups
+	if g < headcount%groups {
+		size++
+	}
+	return size
+}
+
+// the session is for one group of the course's students rather than all of them
+func (s *SessionAtom) isGroup() bool {
+	return s.GroupCount > 1
+}
+
+// the courses of the groups that book a row of the cohort occupancy, one course for a group row
+func groupKeyCourses(pre *PreComputed, key int) []int {
+	courses := make([]int, 0, 1)
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		if session.isGroup() && sharesAny(session.bookedCohortKeys(), []int{key}) {
+			courses = appendUnique(courses, session.CourseIdx)
+		}
+	}
+	return courses
+}
+
+// lets the groups of a split course share a slot, since no student is in two of them. every
+// group books a row of its own instead of the rows the whole course would book. a session that
+// clashes with the whole course clashes with every one of its groups, so do the groups of two
+// different splits, e.g the labs and the tutorials of a course, since a student may be in both.
+// returns the next free row
+func assignGroupKeys(pre *PreComputed, next int) int {
+	type split struct {
+		courseIdx  int
+		groupCount int
+	}
+	groupKeys := make(map[split][]int)
+	splits := make([]split, 0)
+	courseKeys := make(map[int][]int) // what a session of the whole course books
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		if !session.isGroup() {
+			continue
+		}
+		s := split{session.CourseIdx, session.GroupCount}
+		if _, ok := groupKeys[s]; ok {
+			continue
+		}
+		keys := make([]int, s.groupCount)
+		for g := range keys {
+			keys[g] = next
+			next++
+		}
+		groupKeys[s] = keys
+		splits = append(splits, s)
+		courseKeys[s.courseIdx] = session.bookedCohortKeys()
+	}
+	if len(splits) == 0 {
+		return next
+	}
+
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		clash := session.clashCohortKeys()
+		own := split{session.CourseIdx, session.GroupCount}
+		extra := make([]int, 0)
+		for _, s := range splits {
+			if session.isGroup() && s == own {
+				continue
+			}
+			if sharesAny(clash, courseKeys[s.courseIdx]) {
+				extra = append(extra, groupKeys[s]...)
+			}
+		}
+		if session.isGroup() {
+			key := groupKeys[own][session.GroupIdx]
+			session.CohortKeys = []int{key}
+			extra = append(extra, key)
+		}
+		session.ClashKeys = appendUnique(append([]int(nil), clash...), extra...)
+	}
+	return next
+}
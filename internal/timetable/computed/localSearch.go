@@ -0,0 +1,367 @@
+package computed
+
+import (
+	"context"
+	"math"
+	"math/rand"
+)
+
+// per slot counts of what is booked, unlike the occupancy grids of the GA a local search move can
+// double book a slot for a while so the clashes have to be counted
+type bookingCounts struct {
+	venues    [][]int
+	lecturers [][]int
+	cohorts   [][]int
+}
+
+func countGrid(rows int, cols int) [][]int {
+	grid := make([][]int, rows)
+	for i := range grid {
+		grid[i] = make([]int, cols)
+	}
+	return grid
+}
+
+func newBookingCounts(pre *PreComputed) bookingCounts {
+	return bookingCounts{
+		venues:    countGrid(pre.NumVenues, pre.TotalSlots),
+		lecturers: countGrid(pre.NumLecturers, pre.TotalSlots),
+		cohorts:   countGrid(cohortKeyRows(pre), pre.TotalSlots),
+	}
+}
+
+// adds delta to every slot the session takes when it starts at slotIdx in venueIdx
+func (b bookingCounts) book(pre *PreComputed, session *SessionAtom, slotIdx int, venueIdx int, delta int) {
+	for si := max(slotIdx, 0); si < slotIdx+session.SessionDuration && si < pre.TotalSlots; si++ {
+		if venueIdx >= 0 && venueIdx < len(b.venues) {
+			b.venues[venueIdx][si] += delta
+		}
+		for _, lecturerIdx := range session.LecturerIdxs {
+			if lecturerIdx >= 0 && lecturerIdx < len(b.lecturers) {
+				b.lecturers[lecturerIdx][si] += delta
+			}
+		}
+		for _, cohortIdx := range session.bookedCohortKeys() {
+			if cohortIdx >= 0 && cohortIdx < len(b.cohorts) {
+				b.cohorts[cohortIdx][si] += delta
+			}
+		}
+	}
+}
+
+// the clashes and unavailability of the session at slotIdx in venueIdx against everything booked,
+// weighted the same as ComputeLeastBadPair. the session itself must not be booked
+func (b bookingCounts) penalty(pre *PreComputed, session *SessionAtom, slotIdx int, venueIdx int) float64 {
+	penalty := 0.0
+	for si := slotIdx; si < slotIdx+session.SessionDuration; si++ {
+		if si < 0 || si >= pre.TotalSlots {
+			penalty += 1500
+			continue
+		}
+		for _, lecturerIdx := range session.LecturerIdxs {
+			if lecturerIdx < 0 || lecturerIdx >= len(b.lecturers) {
+				continue
+			}
+			if lecturerIdx < len(pre.LecturerUnavailable) && si < len(pre.LecturerUnavailable[lecturerIdx]) && pre.LecturerUnavailable[lecturerIdx][si] {
+				penalty += 10
+			}
+			penalty += 1500 * float64(b.lecturers[lecturerIdx][si])
+		}
+		if venueIdx >= 0 && venueIdx < len(b.venues) {
+			if venueIdx < len(pre.VenueUnavailable) && si < len(pre.VenueUnavailable[venueIdx]) && pre.VenueUnavailable[venueIdx][si] {
+				penalty += 10
+			}
+			penalty += 1500 * float64(b.venues[venueIdx][si])
+		}
+		for _, cohortIdx := range session.clashCohortKeys() {
+			if cohortIdx >= 0 && cohortIdx < len(b.cohorts) {
+				penalty += 500 * float64(b.cohorts[cohortIdx][si])
+			}
+		}
+	}
+	return penalty
+}
+
+// the state annealing and tabu search move placements around in. hard is kept up to date on every
+// move so only the soft constraints are evaluated again from scratch
+type localSearch struct {
+	pre        *PreComputed
+	placements []SessionPlacement
+	counts     bookingCounts
+	hard       float64       // the hard penalty ComputeCandidateFitness would give the placements
+	movable    []int         // placements that are not pinned and have somewhere to go
+	starts     map[int][]int // the slots a session of a given duration can start on
+	buf        *SearchBuffers
+	scored     Candidate // the placements as the candidate the constraints score
+}
+
+func newLocalSearch(pre *PreComputed, cand *Candidate) *localSearch {
+	ls := &localSearch{
+		pre:        pre,
+		placements: append([]SessionPlacement(nil), cand.Placements...),
+		counts:     newBookingCounts(pre),
+		starts:     make(map[int][]int),
+		buf:        newScoringSearchBuffers(pre),
+	}
+	ls.rescore()
+	for i, placement := range ls.placements {
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		if session.Pinned || len(session.AllowedVenuesIdx) == 0 || len(ls.startsFor(session.SessionDuration)) == 0 {
+			continue
+		}
+		ls.movable = append(ls.movable, i)
+	}
+	return ls
+}
+
+func (ls *localSearch) startsFor(duration int) []int {
+	if starts, ok := ls.starts[duration]; ok {
+		return starts
+	}
+	starts := make([]int, 0)
+	for start := 0; start+duration <= ls.pre.TotalSlots; start++ {
+		if ls.pre.SlotsPerDay > 0 && start%ls.pre.SlotsPerDay+duration > ls.pre.SlotsPerDay {
+			continue
+		}
+		if isBlocked(ls.pre, start, duration) {
+			continue
+		}
+		starts = append(starts, start)
+	}
+	ls.starts[duration] = starts
+	return starts
+}
+
+// the 1500 ComputeCandidateFitness adds for a session outside the hours of its day
+func (ls *localSearch) blockedPenalty(session *SessionAtom, slotIdx int) float64 {
+	if isBlocked(ls.pre, slotIdx, session.SessionDuration) {
+		return 1500
+	}
+	return 0
+}
+
+// books every placement again in order and gives each the penalty against the ones before it,
+// so every clash is counted once
+func (ls *localSearch) rescore() {
+	ls.counts = newBookingCounts(ls.pre)
+	ls.hard = 0
+	for i := range ls.placements {
+		placement := &ls.placements[i]
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(ls.pre.SessionAtoms) {
+			continue
+		}
+		session := &ls.pre.SessionAtoms[placement.SessionIdx]
+		placement.Score = ls.counts.penalty(ls.pre, session, placement.SlotIdx, placement.VenueIdx)
+		placement.Conflict = placement.Score > 0
+		ls.hard += placement.Score + ls.blockedPenalty(session, placement.SlotIdx)
+		ls.counts.book(ls.pre, session, placement.SlotIdx, placement.VenueIdx, 1)
+	}
+}
+
+// moves a placement and updates the hard penalty with the difference, clashes are symmetric so
+// the difference of the moved session against everything else is the whole difference
+func (ls *localSearch) relocate(pos int, slotIdx int, venueIdx int) {
+	placement := &ls.placements[pos]
+	session := &ls.pre.SessionAtoms[placement.SessionIdx]
+	ls.counts.book(ls.pre, session, placement.SlotIdx, placement.VenueIdx, -1)
+	before := ls.counts.penalty(ls.pre, session, placement.SlotIdx, placement.VenueIdx) + ls.blockedPenalty(session, placement.SlotIdx)
+	after := ls.counts.penalty(ls.pre, session, slotIdx, venueIdx) + ls.blockedPenalty(session, slotIdx)
+	placement.SlotIdx = slotIdx
+	placement.VenueIdx = venueIdx
+	ls.counts.book(ls.pre, session, slotIdx, venueIdx, 1)
+	ls.hard += after - before
+}
+
+// the penalty the placement currently has against every other placement
+func (ls *localSearch) placementPenalty(pos int) float64 {
+	placement := &ls.placements[pos]
+	session := &ls.pre.SessionAtoms[placement.SessionIdx]
+	ls.counts.book(ls.pre, session, placement.SlotIdx, placement.VenueIdx, -1)
+	penalty := ls.counts.penalty(ls.pre, session, placement.SlotIdx, placement.VenueIdx)
+	ls.counts.book(ls.pre, session, placement.SlotIdx, placement.VenueIdx, 1)
+	return penalty
+}
+
+// movable placements that clash or sit in an unavailable slot
+func (ls *localSearch) conflicted() []int {
+	conflicted := make([]int, 0)
+	for _, pos := range ls.movable {
+		if ls.placementPenalty(pos) > 0 {
+			conflicted = append(conflicted, pos)
+		}
+	}
+	return conflicted
+}
+
+// the penalty ComputeCandidateFitness would give the placements, the lower the better
+func (ls *localSearch) cost() float64 {
+	ls.scored.Placements = ls.placements
+	hard, soft := ComputeConstraintPenalties(ls.pre, &ls.scored, ls.buf)
+	return ls.hard + hard + soft
+}
+
+// a random slot and allowed venue for the placement
+func (ls *localSearch) randomTarget(r *rand.Rand, pos int) (int, int) {
+	session := &ls.pre.SessionAtoms[ls.placements[pos].SessionIdx]
+	starts := ls.startsFor(session.SessionDuration)
+	return starts[r.Intn(len(starts))], session.AllowedVenuesIdx[r.Intn(len(session.AllowedVenuesIdx))]
+}
+
+func (ls *localSearch) snapshot() []SessionPlacement {
+	return append([]SessionPlacement(nil), ls.placements...)
+}
+
+// turns the given placements into a scored candidate
+func (ls *localSearch) finish(placements []SessionPlacement) *Candidate {
+	ls.placements = placements
+	ls.rescore()
+	cand := &Candidate{Placements: ls.snapshot()}
+	ComputeCandidateFitness(ls.pre, cand, ls.buf)
+	return cand
+}
+
+// a copy of the candidate with every placement scored against the others again, so candidates from
+// different solvers are scored the same way whatever their placements were last scored against
+func RescoreCandidate(pre *PreComputed, cand *Candidate) *Candidate {
+	ls := newLocalSearch(pre, cand)
+	return ls.finish(ls.snapshot())
+}
+
+// how often a local search reports progress, about a hundred times a run
+func progressInterval(iterations int) int {
+	return max(iterations/100, 1)
+}
+
+// starts from a greedy candidate and moves one session at a time to a random slot and venue.
+// a worse move is kept with a chance that shrinks as the temperature cools, so the search can
+// climb out of a local minimum early on and settles down towards the end
+func SimulatedAnnealing(ctx context.Context, pre *PreComputed, params AnnealingParams, onProgress func(GenerationProgress)) (*Candidate, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(params.Seed))
+	ls := newLocalSearch(pre, BuildOneCandidate(rand.New(rand.NewSource(r.Int63())), pre, params.SampleK, nil))
+	cost := ls.cost()
+	bestCost, best := cost, ls.snapshot()
+	if len(ls.movable) == 0 {
+		return ls.finish(best), nil
+	}
+
+	temperature := params.InitialTemperature
+	cooling := math.Pow(params.FinalTemperature/params.InitialTemperature, 1/float64(params.Iterations))
+	every := progressInterval(params.Iterations)
+	for i := 0; i < params.Iterations; i++ {
+		if i%every == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		pos := ls.movable[r.Intn(len(ls.movable))]
+		slotIdx, venueIdx := ls.randomTarget(r, pos)
+		prevSlot, prevVenue := ls.placements[pos].SlotIdx, ls.placements[pos].VenueIdx
+		if slotIdx != prevSlot || venueIdx != prevVenue {
+			ls.relocate(pos, slotIdx, venueIdx)
+			next := ls.cost()
+			delta := next - cost
+			if delta <= 0 || r.Float64() < math.Exp(-delta/temperature) {
+				cost = next
+				if cost < bestCost {
+					bestCost, best = cost, ls.snapshot()
+				}
+			} else {
+				ls.relocate(pos, prevSlot, prevVenue)
+			}
+		}
+		temperature *= cooling
+
+		if onProgress != nil && ((i+1)%every == 0 || i+1 == params.Iterations) {
+			onProgress(GenerationProgress{
+				Generation:  i + 1,
+				Generations: params.Iterations,
+				BestFitness: 1.0 / (1 + bestCost),
+			})
+		}
+	}
+	return ls.finish(best), nil
+}
+
+// a session may not go back to where it just left for a few iterations
+type tabuMove struct {
+	sessionIdx int
+	slotIdx    int
+	venueIdx   int
+}
+
+// polishes a candidate by taking the best of a sample of single session moves every iteration,
+// even when it is worse, while moves back to a recently left slot and venue are forbidden unless
+// they beat the best cost seen. the result is never worse than cand
+func TabuSearch(ctx context.Context, pre *PreComputed, cand *Candidate, params TabuParams, onProgress func(GenerationProgress)) (*Candidate, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(params.Seed))
+	ls := newLocalSearch(pre, cand)
+	cost := ls.cost()
+	bestCost, best := cost, ls.snapshot()
+	if len(ls.movable) == 0 {
+		return ls.finish(best), nil
+	}
+
+	tabu := make(map[tabuMove]int)
+	every := progressInterval(params.Iterations)
+	for it := 0; it < params.Iterations && bestCost > 0; it++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// half of the sampled moves go to sessions that clash, they are the ones worth moving
+		conflicted := ls.conflicted()
+		movePos, moveSlot, moveVenue := -1, 0, 0
+		moveCost := math.Inf(1)
+		for n := 0; n < params.Neighbours; n++ {
+			pos := ls.movable[r.Intn(len(ls.movable))]
+			if len(conflicted) > 0 && r.Intn(2) == 0 {
+				pos = conflicted[r.Intn(len(conflicted))]
+			}
+			slotIdx, venueIdx := ls.randomTarget(r, pos)
+			prevSlot, prevVenue := ls.placements[pos].SlotIdx, ls.placements[pos].VenueIdx
+			if slotIdx == prevSlot && venueIdx == prevVenue {
+				continue
+			}
+			ls.relocate(pos, slotIdx, venueIdx)
+			next := ls.cost()
+			ls.relocate(pos, prevSlot, prevVenue)
+
+			until, isTabu := tabu[tabuMove{sessionIdx: ls.placements[pos].SessionIdx, slotIdx: slotIdx, venueIdx: venueIdx}]
+			if isTabu && until > it && next >= bestCost {
+				continue
+			}
+			if next < moveCost {
+				movePos, moveSlot, moveVenue, moveCost = pos, slotIdx, venueIdx, next
+			}
+		}
+
+		if movePos >= 0 {
+			left := ls.placements[movePos]
+			tabu[tabuMove{sessionIdx: left.SessionIdx, slotIdx: left.SlotIdx, venueIdx: left.VenueIdx}] = it + params.Tenure
+			ls.relocate(movePos, moveSlot, moveVenue)
+			cost = moveCost
+			if cost < bestCost {
+				bestCost, best = cost, ls.snapshot()
+			}
+		}
+
+		if onProgress != nil && ((it+1)%every == 0 || it+1 == params.Iterations) {
+			onProgress(GenerationProgress{
+				Generation:  it + 1,
+				Generations: params.Iterations,
+				BestFitness: 1.0 / (1 + bestCost),
+			})
+		}
+	}
+	return ls.finish(best), nil
+}
@@ -0,0 +1,373 @@
+package computed
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// a fixed number of slots packed 64 to a word, bit i is slot i
+type Bitset []uint64
+
+func wordsFor(n int) int {
+	return (n + 63) >> 6
+}
+
+func NewBitset(n int) Bitset {
+	return make(Bitset, wordsFor(n))
+}
+
+func (b Bitset) Len() int {
+	return len(b) << 6
+}
+
+func (b Bitset) Test(i int) bool {
+	if i < 0 || i >= b.Len() {
+		return false
+	}
+	return b[i>>6]&(1<<(uint(i)&63)) != 0
+}
+
+func (b Bitset) Set(i int) {
+	b[i>>6] |= 1 << (uint(i) & 63)
+}
+
+// calls fn with the word index and the mask of the bits of [start, start+n) in that word,
+// the range is clipped to the set
+func (b Bitset) eachWord(start int, n int, fn func(w int, mask uint64) bool) {
+	end := min(start+n, b.Len())
+	start = max(start, 0)
+	if start >= end {
+		return
+	}
+	last := (end - 1) >> 6
+	for w := start >> 6; w <= last; w++ {
+		mask := ^uint64(0)
+		if w == start>>6 {
+			mask &= ^uint64(0) << (uint(start) & 63)
+		}
+		if w == last {
+			mask &= ^uint64(0) >> (63 - (uint(end-1) & 63))
+		}
+		if !fn(w, mask) {
+			return
+		}
+	}
+}
+
+func (b Bitset) SetRange(start int, n int) {
+	b.eachWord(start, n, func(w int, mask uint64) bool {
+		b[w] |= mask
+		return true
+	})
+}
+
+func (b Bitset) ClearRange(start int, n int) {
+	b.eachWord(start, n, func(w int, mask uint64) bool {
+		b[w] &^= mask
+		return true
+	})
+}
+
+// true if any slot of [start, start+n) is set
+func (b Bitset) AnyInRange(start int, n int) bool {
+	// a session never spans more than one word boundary so this is the common case
+	if start >= 0 && n > 0 && start+n <= b.Len() && start>>6 == (start+n-1)>>6 {
+		mask := (^uint64(0) >> (64 - uint(n))) << (uint(start) & 63)
+		return b[start>>6]&mask != 0
+	}
+	found := false
+	b.eachWord(start, n, func(w int, mask uint64) bool {
+		found = b[w]&mask != 0
+		return !found
+	})
+	return found
+}
+
+// the number of slots of [start, start+n) that are set
+func (b Bitset) CountInRange(start int, n int) int {
+	if start >= 0 && n > 0 && start+n <= b.Len() && start>>6 == (start+n-1)>>6 {
+		mask := (^uint64(0) >> (64 - uint(n))) << (uint(start) & 63)
+		return bits.OnesCount64(b[start>>6] & mask)
+	}
+	count := 0
+	b.eachWord(start, n, func(w int, mask uint64) bool {
+		count += bits.OnesCount64(b[w] & mask)
+		return true
+	})
+	return count
+}
+
+// sets every slot set in other
+func (b Bitset) Or(other Bitset) {
+	for w := range b {
+		if w < len(other) {
+			b[w] |= other[w]
+		}
+	}
+}
+
+func (b Bitset) Reset() {
+	clear(b)
+}
+
+// one Bitset of slots per venue, lecturer or cohort, all in a single allocation
+type Occupancy struct {
+	rows  int
+	slots int
+	words int
+	bits  []uint64
+}
+
+func NewOccupancy(rows int, slots int) *Occupancy {
+	words := wordsFor(slots)
+	return &Occupancy{rows: rows, slots: slots, words: words, bits: make([]uint64, rows*words)}
+}
+
+// an occupancy with the slots that are true in mask set, rows and slots missing from mask stay free
+func OccupancyFromMask(mask [][]bool, rows int, slots int) *Occupancy {
+	occ := NewOccupancy(rows, slots)
+	for row := 0; row < rows && row < len(mask); row++ {
+		bitset := occ.Row(row)
+		for slot := 0; slot < slots && slot < len(mask[row]); slot++ {
+			if mask[row][slot] {
+				bitset.Set(slot)
+			}
+		}
+	}
+	return occ
+}
+
+func (o *Occupancy) Rows() int {
+	return o.rows
+}
+
+func (o *Occupancy) Slots() int {
+	return o.slots
+}
+
+// the slots of a row, shares memory with the occupancy
+func (o *Occupancy) Row(row int) Bitset {
+	return o.bits[row*o.words : (row+1)*o.words : (row+1)*o.words]
+}
+
+// true if row and slot are inside the occupancy
+func (o *Occupancy) Contains(row int, slot int) bool {
+	return row >= 0 && row < o.rows && slot >= 0 && slot < o.slots
+}
+
+func (o *Occupancy) Test(row int, slot int) bool {
+	return o.Contains(row, slot) && o.Row(row).Test(slot)
+}
+
+// marks [start, start+n) of row as taken, anything outside the occupancy is ignored
+func (o *Occupancy) SetRange(row int, start int, n int) {
+	if row >= 0 && row < o.rows {
+		o.Row(row).SetRange(start, min(n, o.slots-start))
+	}
+}
+
+// frees [start, start+n) of row, anything outside the occupancy is ignored
+func (o *Occupancy) ClearRange(row int, start int, n int) {
+	if row >= 0 && row < o.rows {
+		o.Row(row).ClearRange(start, min(n, o.slots-start))
+	}
+}
+
+func (o *Occupancy) AnyInRange(row int, start int, n int) bool {
+	if row < 0 || row >= o.rows {
+		return false
+	}
+	return o.Row(row).AnyInRange(start, min(n, o.slots-start))
+}
+
+func (o *Occupancy) CountInRange(row int, start int, n int) int {
+	if row < 0 || row >= o.rows {
+		return 0
+	}
+	return o.Row(row).CountInRange(start, min(n, o.slots-start))
+}
+
+func (o *Occupancy) Reset() {
+	clear(o.bits)
+}
+
+// what never changes during a run, built from pre the first time a search needs it.
+// pre must not be changed after that
+type staticMasks struct {
+	lecturerUnavailable *Occupancy
+	venueUnavailable    *Occupancy
+	starts              [][]int   // starts[duration] slots a session of that many slots may start at
+	venueGroups         [][][]int // venueGroups[sessionIdx] allowed venues grouped by equal rank and wasted seats, the course's own and snuggest first
+	placementOrder      []int     // the order BuildOneCandidate places sessions in
+}
+
+func (pre *PreComputed) masks() *staticMasks {
+	pre.masksOnce.Do(func() {
+		pre.static = buildStaticMasks(pre)
+	})
+	return pre.static
+}
+
+func buildStaticMasks(pre *PreComputed) *staticMasks {
+	m := &staticMasks{
+		lecturerUnavailable: OccupancyFromMask(pre.LecturerUnavailable, len(pre.LecturerUnavailable), pre.TotalSlots),
+		venueUnavailable:    OccupancyFromMask(pre.VenueUnavailable, len(pre.VenueUnavailable), pre.TotalSlots),
+		venueGroups:         make([][][]int, len(pre.SessionAtoms)),
+	}
+
+	longest := 0
+	for i := range pre.SessionAtoms {
+		longest = max(longest, pre.SessionAtoms[i].SessionDuration)
+	}
+	m.starts = make([][]int, longest+1)
+	for duration := 1; duration <= longest; duration++ {
+		m.starts[duration] = make([]int, 0)
+		if pre.SlotsPerDay <= 0 {
+			continue
+		}
+		for start := 0; start+duration <= pre.TotalSlots; start++ {
+			// prevents cross day boundary and slotting outside the hours of the day
+			dayEnd := (start/pre.SlotsPerDay + 1) * pre.SlotsPerDay
+			if start+duration > dayEnd || isBlocked(pre, start, duration) {
+				continue
+			}
+			m.starts[duration] = append(m.starts[duration], start)
+		}
+	}
+
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		venues := make([]int, 0, len(session.AllowedVenuesIdx))
+		for _, v := range session.AllowedVenuesIdx {
+			if v >= 0 && v < pre.NumVenues {
+				venues = append(venues, v)
+			}
+		}
+		sort.SliceStable(venues, func(a, b int) bool {
+			rankA, rankB := venueRank(session, venues[a]), venueRank(session, venues[b])
+			if rankA != rankB {
+				return rankA < rankB
+			}
+			return wastedSeats(pre, session, venues[a]) < wastedSeats(pre, session, venues[b])
+		})
+		groups := make([][]int, 0)
+		for j, v := range venues {
+			if j == 0 || venueRank(session, v) != venueRank(session, venues[j-1]) || wastedSeats(pre, session, v) != wastedSeats(pre, session, venues[j-1]) {
+				groups = append(groups, make([]int, 0, 1))
+			}
+			groups[len(groups)-1] = append(groups[len(groups)-1], v)
+		}
+		m.venueGroups[i] = groups
+	}
+
+	m.placementOrder = make([]int, len(pre.SessionAtoms))
+	for i := range m.placementOrder {
+		m.placementOrder[i] = i
+	}
+	// sort the order array according giving preference to sessions with less allowed venues and longer durations
+	sort.Slice(m.placementOrder, func(i, j int) bool {
+		a := pre.SessionAtoms[m.placementOrder[i]]
+		b := pre.SessionAtoms[m.placementOrder[j]]
+		scoreA := float64(len(a.AllowedVenuesIdx))*0.5 + float64(a.SessionDuration)*1.0
+		scoreB := float64(len(b.AllowedVenuesIdx))*0.5 + float64(b.SessionDuration)*1.0
+		return scoreA < scoreB // less allowed venues = harder -> come earlier
+	})
+	return m
+}
+
+// the starts a session of duration may take, nil when the duration is not one of pre
+func (m *staticMasks) startsFor(duration int) []int {
+	if duration < 0 || duration >= len(m.starts) {
+		return nil
+	}
+	return m.starts[duration]
+}
+
+// the scratch space a worker reuses for every candidate it builds instead of allocating it again
+type SearchBuffers struct {
+	VenueOcc    *Occupancy
+	LecturerOcc *Occupancy
+	CohortOcc   *Occupancy
+	busy        Bitset // the slots the lecturers and cohorts of the session being placed are taken
+	feasible    []FeasiblePair
+	// the positions of the placements of each course in the two parents of a crossover
+	courses1 [][]int
+	courses2 [][]int
+	chosen1  []SessionPlacement
+	chosen2  []SessionPlacement
+	scoringBuffers
+}
+
+// the scratch space the constraints score a candidate in
+type scoringBuffers struct {
+	cohortBusy    *Occupancy
+	lecturerBusy  *Occupancy
+	courseDays    []int                // the sessions of every course and group of a course on every day, see spreadRow
+	cohortStops   [][]SessionPlacement // the placements of every row of the cohort occupancy
+	lecturerStops [][]SessionPlacement
+}
+
+func NewSearchBuffers(pre *PreComputed) *SearchBuffers {
+	return &SearchBuffers{
+		VenueOcc:       NewOccupancy(pre.NumVenues, pre.TotalSlots),
+		LecturerOcc:    NewOccupancy(pre.NumLecturers, pre.TotalSlots),
+		CohortOcc:      NewOccupancy(cohortKeyRows(pre), pre.TotalSlots),
+		busy:           NewBitset(pre.TotalSlots),
+		feasible:       make([]FeasiblePair, 0, pre.TotalSlots),
+		scoringBuffers: newScoringBuffers(pre),
+	}
+}
+
+func newScoringBuffers(pre *PreComputed) scoringBuffers {
+	return scoringBuffers{
+		cohortBusy:    NewOccupancy(cohortKeyRows(pre), pre.TotalSlots),
+		lecturerBusy:  NewOccupancy(pre.NumLecturers, pre.TotalSlots),
+		courseDays:    make([]int, (pre.NumCourses+cohortKeyRows(pre))*numDays(pre)),
+		cohortStops:   make([][]SessionPlacement, cohortKeyRows(pre)),
+		lecturerStops: make([][]SessionPlacement, pre.NumLecturers),
+	}
+}
+
+// buffers that only score candidates, for callers outside of a search
+func newScoringSearchBuffers(pre *PreComputed) *SearchBuffers {
+	return &SearchBuffers{scoringBuffers: newScoringBuffers(pre)}
+}
+
+// frees every slot and marks the pinned sessions again
+func (b *SearchBuffers) reset(pre *PreComputed) {
+	b.VenueOcc.Reset()
+	b.LecturerOcc.Reset()
+	b.CohortOcc.Reset()
+	markPinnedOccupancy(pre, b.VenueOcc, b.LecturerOcc, b.CohortOcc)
+}
+
+// one set of buffers per worker of a run
+func newWorkerBuffers(pre *PreComputed, workers int) []*SearchBuffers {
+	buffers := make([]*SearchBuffers, workers)
+	for w := range buffers {
+		buffers[w] = NewSearchBuffers(pre)
+	}
+	return buffers
+}
+
+// marks the slots the session takes from slotIdx in its venue, lecturers and cohorts
+func bookSession(session *SessionAtom, slotIdx int, venueIdx int, venueOcc *Occupancy, lecturerOcc *Occupancy, cohortOcc *Occupancy) {
+	venueOcc.SetRange(venueIdx, slotIdx, session.SessionDuration)
+	for _, lecturerIdx := range session.LecturerIdxs {
+		lecturerOcc.SetRange(lecturerIdx, slotIdx, session.SessionDuration)
+	}
+	for _, cohortIdx := range session.bookedCohortKeys() {
+		cohortOcc.SetRange(cohortIdx, slotIdx, session.SessionDuration)
+	}
+}
+
+// frees the slots the session takes from slotIdx in its venue, lecturers and cohorts
+func unbookSession(session *SessionAtom, slotIdx int, venueIdx int, venueOcc *Occupancy, lecturerOcc *Occupancy, cohortOcc *Occupancy) {
+	venueOcc.ClearRange(venueIdx, slotIdx, session.SessionDuration)
+	for _, lecturerIdx := range session.LecturerIdxs {
+		lecturerOcc.ClearRange(lecturerIdx, slotIdx, session.SessionDuration)
+	}
+	for _, cohortIdx := range session.bookedCohortKeys() {
+		cohortOcc.ClearRange(cohortIdx, slotIdx, session.SessionDuration)
+	}
+}
@@ -0,0 +1,144 @@
+package computed_test
+
+import (
+	"io"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	"github.com/Cxons/unischedulebackend/internal/timetable/synthetic"
+)
+
+// the bitsets have to be at least this many times faster than the bool grids in every case
+const minSpeedup = 5
+
+const (
+	benchSeed       = 1
+	benchPopulation = 20
+)
+
+// the large synthetic university every benchmark runs on, generated once per run
+var benchUniversity = sync.OnceValues(func() (*computed.PreComputed, error) {
+	// the solver logs every candidate it builds
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	uni, err := synthetic.Generate(synthetic.Presets["large"](), benchSeed)
+	if err != nil {
+		return nil, err
+	}
+	problem, err := uni.Problem()
+	if err != nil {
+		return nil, err
+	}
+	pre, _, err := problem.PreComputed()
+	return pre, err
+})
+
+// the university and the parents the crossover benchmarks breed from
+type benchSetup struct {
+	pre            *computed.PreComputed
+	params         computed.GAParams
+	courseSessions [][]computed.SessionAtom
+	buf            *computed.SearchBuffers
+	parents        []*computed.Candidate
+}
+
+func newBenchSetup(b *testing.B) *benchSetup {
+	b.Helper()
+	pre, err := benchUniversity()
+	if err != nil {
+		b.Fatal(err)
+	}
+	params := computed.DefaultGAParams()
+	buf := computed.NewSearchBuffers(pre)
+	return &benchSetup{
+		pre:            pre,
+		params:         params,
+		courseSessions: computed.ComputeCourseSessions(pre),
+		buf:            buf,
+		parents:        computed.BuildPopulation(pre, benchSeed, benchPopulation, params.SampleK, []*computed.SearchBuffers{buf}),
+	}
+}
+
+// runs op on the bool grids and on the bitsets as sub-benchmarks of b and fails b when the
+// bitsets are less than minSpeedup times faster
+func compareOccupancy(b *testing.B, boolGrids func(s *benchSetup, i int), bitsets func(s *benchSetup, i int)) {
+	s := newBenchSetup(b)
+	perOp := make(map[string]time.Duration, 2)
+	for _, impl := range []struct {
+		name string
+		op   func(s *benchSetup, i int)
+	}{{"boolgrids", boolGrids}, {"bitsets", bitsets}} {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				impl.op(s, i)
+			}
+			// the last call has the most ops and is the one reported
+			perOp[impl.name] = b.Elapsed() / time.Duration(b.N)
+		})
+	}
+	if perOp["boolgrids"] == 0 || perOp["bitsets"] == 0 {
+		// filtered out by -bench
+		return
+	}
+	speedup := float64(perOp["boolgrids"]) / float64(perOp["bitsets"])
+	b.Logf("%d sessions: bitsets are %.1fx faster than bool grids", len(s.pre.SessionAtoms), speedup)
+	if speedup < minSpeedup {
+		b.Fatalf("bitsets are %.1fx faster than bool grids, want at least %dx", speedup, minSpeedup)
+	}
+}
+
+func BenchmarkBuildOneCandidate(b *testing.B) {
+	compareOccupancy(b,
+		func(s *benchSetup, i int) {
+			legacyBuildOneCandidate(rand.New(rand.NewSource(int64(i))), s.pre, s.params.SampleK)
+		},
+		func(s *benchSetup, i int) {
+			computed.BuildOneCandidate(rand.New(rand.NewSource(int64(i))), s.pre, s.params.SampleK, s.buf)
+		})
+}
+
+func BenchmarkCrossoverAndMutation(b *testing.B) {
+	compareOccupancy(b,
+		func(s *benchSetup, i int) {
+			r := rand.New(rand.NewSource(int64(i)))
+			parent1, parent2 := computed.SelectParents(s.parents, s.params.TournamentSize, r)
+			child, lecOcc, venueOcc, cohortOcc := legacyCrossover(s.pre, parent1, parent2, s.courseSessions)
+			legacyMutation(s.pre, child, r, s.params.MutationRate, lecOcc, venueOcc, cohortOcc)
+		},
+		func(s *benchSetup, i int) {
+			r := rand.New(rand.NewSource(int64(i)))
+			parent1, parent2 := computed.SelectParents(s.parents, s.params.TournamentSize, r)
+			child := computed.Crossover(s.pre, parent1, parent2, s.courseSessions, s.buf)
+			computed.Mutation(s.pre, child, r, s.params.MutationRate, s.buf)
+		})
+}
+
+// a whole generation on one worker, fitness and soft constraints included
+func BenchmarkNextGeneration(b *testing.B) {
+	compareOccupancy(b,
+		func(s *benchSetup, i int) {
+			previous := append([]*computed.Candidate(nil), s.parents...)
+			r := rand.New(rand.NewSource(int64(i)))
+			topK := max(int(s.params.ElitismFraction*float64(len(previous))), 1)
+			computed.SortPopulation(previous)
+			next := append([]*computed.Candidate(nil), previous[:topK]...)
+			for len(next) < len(previous) {
+				parent1, parent2 := computed.SelectParents(previous, s.params.TournamentSize, r)
+				child, lecOcc, venueOcc, cohortOcc := legacyCrossover(s.pre, parent1, parent2, s.courseSessions)
+				legacyMutation(s.pre, child, r, s.params.MutationRate, lecOcc, venueOcc, cohortOcc)
+				computed.ComputeCandidateFitness(s.pre, child, nil)
+				next = append(next, child)
+			}
+		},
+		func(s *benchSetup, i int) {
+			previous := append([]*computed.Candidate(nil), s.parents...)
+			rngs := []*rand.Rand{rand.New(rand.NewSource(int64(i)))}
+			computed.BuildNextGeneration(s.pre, previous, s.courseSessions, rngs, []*computed.SearchBuffers{s.buf}, s.params)
+		})
+}
@@ -0,0 +1,247 @@
+package computed
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidPin = errors.New("invalid pinned session")
+
+// a course session a HOD has fixed to a day, time and venue
+type SessionPin struct {
+	CourseId      uuid.UUID
+	SessionNumber int // 1 for the first session of the course in the week
+	Day           string
+	StartMinutes  int
+	VenueId       uuid.UUID
+}
+
+// converts the stored pins of a university, start times become minutes after midnight
+func SessionPinsFromRows(rows []sqlc.GetSessionPinsRow) ([]SessionPin, error) {
+	pins := make([]SessionPin, 0, len(rows))
+	for _, row := range rows {
+		startMinutes, err := ParseMinutes(row.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("pinned session %s %d has an invalid start time: %v", row.CourseCode, row.SessionNumber, err)
+		}
+		pins = append(pins, SessionPin{
+			CourseId:      row.CourseID,
+			SessionNumber: int(row.SessionNumber),
+			Day:           row.Day,
+			StartMinutes:  startMinutes,
+			VenueId:       row.VenueID,
+		})
+	}
+	return pins, nil
+}
+
+// the pins of the courses of the semester, every pin when it is empty
+func PinsForSemester(rows []sqlc.GetSessionPinsRow, semester string) []sqlc.GetSessionPinsRow {
+	if semester == "" {
+		return rows
+	}
+	kept := make([]sqlc.GetSessionPinsRow, 0, len(rows))
+	for _, row := range rows {
+		if row.Semester == semester {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// the placement of a pinned session, never changes between candidates
+func pinnedPlacement(session *SessionAtom) SessionPlacement {
+	return SessionPlacement{
+		SessionIdx: session.SessionIdx,
+		CourseIdx:  session.CourseIdx,
+		VenueIdx:   session.PinnedVenueIdx,
+		SlotIdx:    session.PinnedSlotIdx,
+		Conflict:   false,
+		Score:      0.0,
+	}
+}
+
+// marks the slots, venue, lecturers and cohorts of every pinned session as taken
+func markPinnedOccupancy(pre *PreComputed, venueOcc *Occupancy, lecturerOcc *Occupancy, cohortOcc *Occupancy) {
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		if session.Pinned {
+			bookSession(session, session.PinnedSlotIdx, session.PinnedVenueIdx, venueOcc, lecturerOcc, cohortOcc)
+		}
+	}
+}
+
+func courseCode(pre *PreComputed, courseIdx int) string {
+	if courseIdx >= 0 && courseIdx < len(pre.CourseCodes) {
+		return pre.CourseCodes[courseIdx]
+	}
+	return fmt.Sprintf("course %d", courseIdx)
+}
+
+func venueName(pre *PreComputed, venueIdx int) string {
+	if venueIdx >= 0 && venueIdx < len(pre.VenueNames) {
+		return pre.VenueNames[venueIdx]
+	}
+	return fmt.Sprintf("venue %d", venueIdx)
+}
+
+func lecturerName(pre *PreComputed, lecturerIdx int) string {
+	if lecturerIdx >= 0 && lecturerIdx < len(pre.LecturerNames) && pre.LecturerNames[lecturerIdx] != "" {
+		return pre.LecturerNames[lecturerIdx]
+	}
+	return fmt.Sprintf("lecturer %d", lecturerIdx)
+}
+
+func cohortName(pre *PreComputed, cohortIdx int) string {
+	if cohortIdx >= 0 && cohortIdx < len(pre.CohortNames) {
+		return pre.CohortNames[cohortIdx]
+	}
+	return fmt.Sprintf("cohort %d", cohortIdx)
+}
+
+func sharesAny(a []int, b []int) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fixes the pinned sessions in place. a pin outside the teaching week, on an unavailable
+// lecturer or venue, or clashing with another pin is rejected with every problem found
+func ApplySessionPins(pre *PreComputed, pins []SessionPin, week TeachingWeek, courseMap map[uuid.UUID]int, venueMap map[uuid.UUID]int) error {
+	slotsPerDay := week.SlotsPerDay()
+	gridStart := week.GridStartMinutes()
+	dayIndex := make(map[string]int)
+	for i, d := range week.DayNames() {
+		dayIndex[d] = i
+	}
+
+	// the sessions of every course in the order they were created
+	courseSessions := make(map[int][]int)
+	for i, session := range pre.SessionAtoms {
+		courseSessions[session.CourseIdx] = append(courseSessions[session.CourseIdx], i)
+	}
+
+	type appliedPin struct {
+		atomIdx int
+		label   string
+	}
+	applied := make([]appliedPin, 0, len(pins))
+	problems := make([]string, 0)
+
+	for _, pin := range pins {
+		courseIdx, ok := courseMap[pin.CourseId]
+		if !ok || len(courseSessions[courseIdx]) == 0 {
+			problems = append(problems, fmt.Sprintf("course %s is not being scheduled", pin.CourseId))
+			continue
+		}
+		label := fmt.Sprintf("%s session %d", courseCode(pre, courseIdx), pin.SessionNumber)
+		sessions := courseSessions[courseIdx]
+		if pin.SessionNumber < 1 || pin.SessionNumber > len(sessions) {
+			problems = append(problems, fmt.Sprintf("%s does not exist, the course has %d sessions a week", label, len(sessions)))
+			continue
+		}
+		atomIdx := sessions[pin.SessionNumber-1]
+		session := &pre.SessionAtoms[atomIdx]
+
+		dayIdx, ok := dayIndex[pin.Day]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is pinned to %s which is not a teaching day", label, pin.Day))
+			continue
+		}
+		offset := pin.StartMinutes - gridStart
+		if offset < 0 || offset%week.SlotMinutes != 0 || offset/week.SlotMinutes >= slotsPerDay {
+			problems = append(problems, fmt.Sprintf("%s is pinned to %s which is not the start of a slot", label, FormatMinutes(pin.StartMinutes)))
+			continue
+		}
+		slotInDay := offset / week.SlotMinutes
+		slotIdx := dayIdx*slotsPerDay + slotInDay
+		at := fmt.Sprintf("%s %s", pin.Day, FormatMinutes(pin.StartMinutes))
+		if slotInDay+session.SessionDuration > slotsPerDay || isBlocked(pre, slotIdx, session.SessionDuration) {
+			problems = append(problems, fmt.Sprintf("%s at %s runs outside the teaching hours of %s", label, at, pin.Day))
+			continue
+		}
+
+		venueIdx, ok := venueMap[pin.VenueId]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is pinned to venue %s which does not belong to the university", label, pin.VenueId))
+			continue
+		}
+		if venueIdx < len(pre.VenueCapacities) && pre.VenueCapacities[venueIdx] < session.Headcount {
+			problems = append(problems, fmt.Sprintf("%s has %d students but %s only holds %d", label, session.Headcount, venueName(pre, venueIdx), pre.VenueCapacities[venueIdx]))
+			continue
+		}
+
+		valid := true
+		for si := slotIdx; si < slotIdx+session.SessionDuration; si++ {
+			if venueIdx < len(pre.VenueUnavailable) && pre.VenueUnavailable[venueIdx][si] {
+				problems = append(problems, fmt.Sprintf("%s at %s is in %s while it is unavailable", label, at, venueName(pre, venueIdx)))
+				valid = false
+				break
+			}
+		}
+		for _, lecturerIdx := range session.LecturerIdxs {
+			if !valid {
+				break
+			}
+			for si := slotIdx; si < slotIdx+session.SessionDuration; si++ {
+				if lecturerIdx < len(pre.LecturerUnavailable) && pre.LecturerUnavailable[lecturerIdx][si] {
+					problems = append(problems, fmt.Sprintf("%s at %s falls in the unavailability of one of its lecturers", label, at))
+					valid = false
+					break
+				}
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		for _, other := range applied {
+			o := &pre.SessionAtoms[other.atomIdx]
+			if o.SessionIdx == session.SessionIdx {
+				problems = append(problems, fmt.Sprintf("%s is pinned more than once", label))
+				valid = false
+				break
+			}
+			overlaps := slotIdx < o.PinnedSlotIdx+o.SessionDuration && o.PinnedSlotIdx < slotIdx+session.SessionDuration
+			if !overlaps {
+				continue
+			}
+			switch {
+			case o.PinnedVenueIdx == venueIdx:
+				problems = append(problems, fmt.Sprintf("%s clashes with %s in %s", label, other.label, venueName(pre, venueIdx)))
+				valid = false
+			case sharesAny(o.LecturerIdxs, session.LecturerIdxs):
+				problems = append(problems, fmt.Sprintf("%s clashes with %s, they share a lecturer", label, other.label))
+				valid = false
+			case cohortsClash(o, session):
+				problems = append(problems, fmt.Sprintf("%s clashes with %s, they share a cohort or students", label, other.label))
+				valid = false
+			}
+			if !valid {
+				break
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		session.Pinned = true
+		session.PinnedSlotIdx = slotIdx
+		session.PinnedVenueIdx = venueIdx
+		applied = append(applied, appliedPin{atomIdx: atomIdx, label: label})
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPin, strings.Join(problems, "; "))
+	}
+	return nil
+}
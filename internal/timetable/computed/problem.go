@@ -0,0 +1,586 @@
+package computed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// the version of the problem file format, bumped whenever a field changes meaning
+const ProblemVersion = 1
+
+var ErrInvalidProblem = errors.New("invalid problem")
+
+// a problem instance the solvers run on without the database, the PreComputed of a university
+// with readable ids instead of idxs. venues, lecturers, cohorts and courses are listed in idx order
+// and everything else refers to them by id. slots are counted from the first slot of the first
+// teaching day, slotsPerDay of them a day, so slot 12 of a week with 10 slots a day is the third
+// slot of the second day. solver and params are what the university generates with, a solver run
+// on the file may override them
+//
+//	{
+//	  "version": 1,
+//	  "slotMinutes": 60,
+//	  "days": [{"day": "Monday", "start": "08:00", "end": "18:00"}],
+//	  "slotsPerDay": 10,
+//	  "totalSlots": 10,
+//	  "venues": [{"id": "LT1", "name": "Lecture Theatre 1", "capacity": 300, "unavailable": [0, 1]}],
+//	  "lecturers": [{"id": "L1", "name": "Ada Obi", "unavailable": []}],
+//	  "cohorts": [{"id": "CSC-100", "name": "CSC 100 level"}],
+//	  "courses": [{"id": "CSC101", "code": "CSC101"}],
+//	  "sessions": [{"course": "CSC101", "type": "LECTURE", "lecturers": ["L1"], "cohorts": ["CSC-100"], "duration": 2,
+//	                "allowedVenues": ["LT1"], "venueRanks": [0], "headcount": 250, "pin": {"slot": 2, "venue": "LT1"}}],
+//	  "constraints": [{"name": "NO_IDLE_GAPS", "enabled": true, "weight": 10, "limit": 0}],
+//	  "travel": [{"from": "LT1", "to": "LAB2", "minutes": 12}],
+//	  "electives": [{"cohort": "CSC-100", "courses": ["CSC151", "CSC153"]}],
+//	  "sharedStudents": [["CSC151", "CSC153"]],
+//	  "solver": "GENETIC",
+//	  "params": {"populationSize": 100, "generations": 100, "seed": 42}
+//	}
+type Problem struct {
+	Version     int                 `json:"version"`
+	SlotMinutes int                 `json:"slotMinutes"`
+	Days        []ProblemDay        `json:"days"`
+	SlotsPerDay int                 `json:"slotsPerDay"` // follows from days and slotMinutes, checked when read
+	TotalSlots  int                 `json:"totalSlots"`  // slotsPerDay times the number of days, checked when read
+	Venues      []ProblemVenue      `json:"venues"`
+	Lecturers   []ProblemLecturer   `json:"lecturers"`
+	Cohorts     []ProblemCohort     `json:"cohorts"`
+	Courses     []ProblemCourse     `json:"courses"`
+	Sessions    []ProblemSession    `json:"sessions"` // in the order the solver numbers them
+	Constraints []ProblemConstraint `json:"constraints"`
+	Travel      []ProblemTravel     `json:"travel,omitempty"` // pairs of venues not listed are 0 minutes apart
+	Electives   []ProblemElective   `json:"electives,omitempty"`
+	// pairs of courses of an elective group some student takes both of, they never share a slot
+	SharedStudents [][2]string    `json:"sharedStudents,omitempty"`
+	Solver         string         `json:"solver,omitempty"`
+	Params         *ProblemParams `json:"params,omitempty"`
+}
+
+// a teaching day, times are HH:MM
+type ProblemDay struct {
+	Day   string `json:"day"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type ProblemVenue struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Capacity    int    `json:"capacity"`
+	Unavailable []int  `json:"unavailable"` // slots the venue cannot be used in
+}
+
+type ProblemLecturer struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Unavailable []int  `json:"unavailable"` // slots the lecturer cannot teach in
+}
+
+type ProblemCohort struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ProblemCourse struct {
+	Id   string `json:"id"`
+	Code string `json:"code"`
+}
+
+type ProblemSession struct {
+	Course        string      `json:"course"`
+	Type          string      `json:"type,omitempty"` // LECTURE, LAB or TUTORIAL
+	Lecturers     []string    `json:"lecturers"`
+	Cohorts       []string    `json:"cohorts"`
+	Duration      int         `json:"duration"` // in slots
+	AllowedVenues []string    `json:"allowedVenues"`
+	VenueRanks    []int       `json:"venueRanks,omitempty"` // the rank of each allowed venue for the course, see VenueRankOwnDepartment
+	Headcount     int         `json:"headcount"`
+	Group         int         `json:"group,omitempty"`  // the group of the course's students the session is for
+	Groups        int         `json:"groups,omitempty"` // how many groups the course's students are split into, 0 when not split
+	Pin           *ProblemPin `json:"pin,omitempty"`    // where a HOD fixed the session, the solver never moves it
+}
+
+type ProblemPin struct {
+	Slot  int    `json:"slot"`
+	Venue string `json:"venue"`
+}
+
+type ProblemConstraint struct {
+	Name    string  `json:"name"`
+	Enabled bool    `json:"enabled"`
+	Weight  float64 `json:"weight"`
+	Limit   float64 `json:"limit"`
+	Hard    bool    `json:"hard,omitempty"`
+}
+
+// the walking minutes between two venues, the same both ways
+type ProblemTravel struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Minutes int    `json:"minutes"`
+}
+
+// courses a cohort takes exactly one of, their sessions may share a slot of the cohort
+type ProblemElective struct {
+	Cohort  string   `json:"cohort"`
+	Courses []string `json:"courses"`
+}
+
+// the GAParams of a problem, a field left out keeps its default
+type ProblemParams struct {
+	PopulationSize    int     `json:"populationSize,omitempty"`
+	Generations       int     `json:"generations,omitempty"`
+	MutationRate      float64 `json:"mutationRate,omitempty"`
+	TournamentSize    int     `json:"tournamentSize,omitempty"`
+	ElitismFraction   float64 `json:"elitismFraction,omitempty"`
+	SampleK           int     `json:"sampleK,omitempty"`
+	Seed              int64   `json:"seed,omitempty"`
+	Workers           int     `json:"workers,omitempty"`
+	StallGenerations  int     `json:"stallGenerations,omitempty"`
+	TimeBudgetSeconds int     `json:"timeBudgetSeconds,omitempty"`
+	StopWhenFeasible  bool    `json:"stopWhenFeasible,omitempty"`
+}
+
+func ToProblemParams(params GAParams) *ProblemParams {
+	return &ProblemParams{
+		PopulationSize:    params.PopulationSize,
+		Generations:       params.Generations,
+		MutationRate:      params.MutationRate,
+		TournamentSize:    params.TournamentSize,
+		ElitismFraction:   params.ElitismFraction,
+		SampleK:           params.SampleK,
+		Seed:              params.Seed,
+		Workers:           params.Workers,
+		StallGenerations:  params.StallGenerations,
+		TimeBudgetSeconds: int(params.TimeBudget / time.Second),
+		StopWhenFeasible:  params.StopWhenFeasible,
+	}
+}
+
+// the params with every field that is set applied over DefaultGAParams
+func (p *ProblemParams) GAParams() GAParams {
+	params := DefaultGAParams()
+	if p == nil {
+		return params
+	}
+	if p.PopulationSize != 0 {
+		params.PopulationSize = p.PopulationSize
+	}
+	if p.Generations != 0 {
+		params.Generations = p.Generations
+	}
+	if p.MutationRate != 0 {
+		params.MutationRate = p.MutationRate
+	}
+	if p.TournamentSize != 0 {
+		params.TournamentSize = p.TournamentSize
+	}
+	if p.ElitismFraction != 0 {
+		params.ElitismFraction = p.ElitismFraction
+	}
+	if p.SampleK != 0 {
+		params.SampleK = p.SampleK
+	}
+	params.Seed = p.Seed
+	params.Workers = p.Workers
+	params.StallGenerations = p.StallGenerations
+	params.TimeBudget = time.Duration(p.TimeBudgetSeconds) * time.Second
+	params.StopWhenFeasible = p.StopWhenFeasible
+	return params
+}
+
+// the ids of a map of ids to idxs listed by idx, an idx without an id gets its idx as id
+func IdsByIdx(idMap map[uuid.UUID]int, n int) []string {
+	ids := make([]string, n)
+	for id, idx := range idMap {
+		if idx >= 0 && idx < n {
+			ids[idx] = id.String()
+		}
+	}
+	for idx := range ids {
+		if ids[idx] == "" {
+			ids[idx] = fmt.Sprint(idx)
+		}
+	}
+	return ids
+}
+
+func nameAt(names []string, idx int) string {
+	if idx < len(names) {
+		return names[idx]
+	}
+	return ""
+}
+
+// the slots that are true in a row of an unavailability mask
+func unavailableSlots(mask [][]bool, row int) []int {
+	slots := make([]int, 0)
+	if row >= len(mask) {
+		return slots
+	}
+	for slot, unavailable := range mask[row] {
+		if unavailable {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+func idsOf(idxs []int, ids []string) []string {
+	out := make([]string, 0, len(idxs))
+	for _, idx := range idxs {
+		out = append(out, ids[idx])
+	}
+	return out
+}
+
+// the problem of pre. the ids are listed by idx, see IdsByIdx
+func NewProblem(pre *PreComputed, week TeachingWeek, constraints []ConstraintSetting, venueIds []string, lecturerIds []string, cohortIds []string, courseIds []string) (*Problem, error) {
+	if len(venueIds) != pre.NumVenues || len(lecturerIds) != pre.NumLecturers || len(cohortIds) != pre.NumCohorts || len(courseIds) != pre.NumCourses {
+		return nil, fmt.Errorf("%w: the ids do not match the venues, lecturers, cohorts and courses of the data", ErrInvalidProblem)
+	}
+	problem := &Problem{
+		Version:     ProblemVersion,
+		SlotMinutes: week.SlotMinutes,
+		Days:        make([]ProblemDay, 0, len(week.Days)),
+		SlotsPerDay: pre.SlotsPerDay,
+		TotalSlots:  pre.TotalSlots,
+		Venues:      make([]ProblemVenue, pre.NumVenues),
+		Lecturers:   make([]ProblemLecturer, pre.NumLecturers),
+		Cohorts:     make([]ProblemCohort, pre.NumCohorts),
+		Courses:     make([]ProblemCourse, pre.NumCourses),
+		Sessions:    make([]ProblemSession, 0, len(pre.SessionAtoms)),
+		Constraints: make([]ProblemConstraint, 0, len(constraints)),
+	}
+	for _, day := range week.Days {
+		problem.Days = append(problem.Days, ProblemDay{Day: day.Day, Start: FormatMinutes(day.StartMinutes), End: FormatMinutes(day.EndMinutes)})
+	}
+	for v := range problem.Venues {
+		problem.Venues[v] = ProblemVenue{
+			Id:          venueIds[v],
+			Name:        nameAt(pre.VenueNames, v),
+			Capacity:    pre.VenueCapacities[v],
+			Unavailable: unavailableSlots(pre.VenueUnavailable, v),
+		}
+	}
+	for l := range problem.Lecturers {
+		problem.Lecturers[l] = ProblemLecturer{
+			Id:          lecturerIds[l],
+			Name:        nameAt(pre.LecturerNames, l),
+			Unavailable: unavailableSlots(pre.LecturerUnavailable, l),
+		}
+	}
+	for c := range problem.Cohorts {
+		problem.Cohorts[c] = ProblemCohort{Id: cohortIds[c], Name: nameAt(pre.CohortNames, c)}
+	}
+	for c := range problem.Courses {
+		problem.Courses[c] = ProblemCourse{Id: courseIds[c], Code: nameAt(pre.CourseCodes, c)}
+	}
+	for _, session := range pre.SessionAtoms {
+		ps := ProblemSession{
+			Course:        courseIds[session.CourseIdx],
+			Type:          session.SessionType,
+			Lecturers:     idsOf(session.LecturerIdxs, lecturerIds),
+			Cohorts:       idsOf(session.CohortIdxs, cohortIds),
+			Duration:      session.SessionDuration,
+			AllowedVenues: idsOf(session.AllowedVenuesIdx, venueIds),
+			VenueRanks:    session.VenueRanks,
+			Headcount:     session.Headcount,
+		}
+		if session.isGroup() {
+			ps.Group, ps.Groups = session.GroupIdx, session.GroupCount
+		}
+		if session.Pinned {
+			ps.Pin = &ProblemPin{Slot: session.PinnedSlotIdx, Venue: venueIds[session.PinnedVenueIdx]}
+		}
+		problem.Sessions = append(problem.Sessions, ps)
+	}
+	for _, setting := range constraints {
+		problem.Constraints = append(problem.Constraints, ProblemConstraint{
+			Name:    setting.Name,
+			Enabled: setting.Enabled,
+			Weight:  setting.Weight,
+			Limit:   setting.LimitValue,
+			Hard:    setting.Hard,
+		})
+	}
+	sort.Slice(problem.Constraints, func(i, j int) bool {
+		return problem.Constraints[i].Name < problem.Constraints[j].Name
+	})
+	for from := range pre.TravelMinutes {
+		for to := from + 1; to < len(pre.TravelMinutes[from]); to++ {
+			if minutes := pre.TravelMinutes[from][to]; minutes > 0 {
+				problem.Travel = append(problem.Travel, ProblemTravel{From: venueIds[from], To: venueIds[to], Minutes: minutes})
+			}
+		}
+	}
+	for _, group := range pre.ElectiveGroups {
+		problem.Electives = append(problem.Electives, ProblemElective{Cohort: cohortIds[group.CohortIdx], Courses: idsOf(group.CourseIdxs, courseIds)})
+	}
+	for _, pair := range pre.SharedStudents {
+		problem.SharedStudents = append(problem.SharedStudents, [2]string{courseIds[pair[0]], courseIds[pair[1]]})
+	}
+	return problem, nil
+}
+
+// maps every id to its position in the list, repeated and empty ids are problems
+func indexIds(kind string, ids []string, problems *[]string) map[string]int {
+	idx := make(map[string]int, len(ids))
+	for i, id := range ids {
+		if id == "" {
+			*problems = append(*problems, fmt.Sprintf("%s %d has no id", kind, i))
+			continue
+		}
+		if _, ok := idx[id]; ok {
+			*problems = append(*problems, fmt.Sprintf("%s %s is listed more than once", kind, id))
+			continue
+		}
+		idx[id] = i
+	}
+	return idx
+}
+
+// the idxs of the ids, unknown ids are problems
+func lookupIds(label string, kind string, ids []string, idx map[string]int, problems *[]string) []int {
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		i, ok := idx[id]
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("%s refers to unknown %s %s", label, kind, id))
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// an unavailability mask row with the given slots set, slots outside the week are problems
+func unavailabilityRow(label string, slots []int, totalSlots int, problems *[]string) []bool {
+	row := make([]bool, totalSlots)
+	for _, slot := range slots {
+		if slot < 0 || slot >= totalSlots {
+			*problems = append(*problems, fmt.Sprintf("%s is unavailable in slot %d which is not in the week", label, slot))
+			continue
+		}
+		row[slot] = true
+	}
+	return row
+}
+
+// the teaching week of the problem
+func (p *Problem) TeachingWeek() (TeachingWeek, error) {
+	days := make([]TeachingDay, 0, len(p.Days))
+	for _, day := range p.Days {
+		start, err := ParseMinutes(day.Start)
+		if err != nil {
+			return TeachingWeek{}, fmt.Errorf("%w: %s starts at %q: %v", ErrInvalidProblem, day.Day, day.Start, err)
+		}
+		end, err := ParseMinutes(day.End)
+		if err != nil {
+			return TeachingWeek{}, fmt.Errorf("%w: %s ends at %q: %v", ErrInvalidProblem, day.Day, day.End, err)
+		}
+		days = append(days, TeachingDay{Day: day.Day, StartMinutes: start, EndMinutes: end})
+	}
+	week, err := NewTeachingWeek(days, p.SlotMinutes)
+	if err != nil {
+		return TeachingWeek{}, fmt.Errorf("%w: %v", ErrInvalidProblem, err)
+	}
+	return week, nil
+}
+
+// the PreComputed the solvers run on and the teaching week it is laid on. every reference is
+// checked and the problems found are returned together
+func (p *Problem) PreComputed() (*PreComputed, TeachingWeek, error) {
+	if p.Version != ProblemVersion {
+		return nil, TeachingWeek{}, fmt.Errorf("%w: version %d is not supported, expected %d", ErrInvalidProblem, p.Version, ProblemVersion)
+	}
+	week, err := p.TeachingWeek()
+	if err != nil {
+		return nil, TeachingWeek{}, err
+	}
+	problems := make([]string, 0)
+	if p.SlotsPerDay != week.SlotsPerDay() || p.TotalSlots != week.TotalSlots() {
+		problems = append(problems, fmt.Sprintf("the days have %d slots a day and %d in total, the file says %d and %d", week.SlotsPerDay(), week.TotalSlots(), p.SlotsPerDay, p.TotalSlots))
+	}
+	totalSlots := week.TotalSlots()
+
+	pre := &PreComputed{
+		TotalSlots:          totalSlots,
+		SlotsPerDay:         week.SlotsPerDay(),
+		NumVenues:           len(p.Venues),
+		NumLecturers:        len(p.Lecturers),
+		NumCohorts:          len(p.Cohorts),
+		NumCourses:          len(p.Courses),
+		SessionAtoms:        make([]SessionAtom, 0, len(p.Sessions)),
+		LecturerUnavailable: make([][]bool, len(p.Lecturers)),
+		VenueUnavailable:    make([][]bool, len(p.Venues)),
+		VenueCapacities:     make([]int, len(p.Venues)),
+		BlockedSlots:        week.BlockedSlots(),
+		SlotMinutes:         week.SlotMinutes,
+		DayStartMinutes:     week.GridStartMinutes(),
+		CourseCodes:         make([]string, len(p.Courses)),
+		VenueNames:          make([]string, len(p.Venues)),
+		LecturerNames:       make([]string, len(p.Lecturers)),
+		CohortNames:         make([]string, len(p.Cohorts)),
+	}
+
+	venueIds := make([]string, len(p.Venues))
+	for v, venue := range p.Venues {
+		venueIds[v] = venue.Id
+		pre.VenueNames[v] = venue.Name
+		pre.VenueCapacities[v] = venue.Capacity
+		pre.VenueUnavailable[v] = unavailabilityRow("venue "+venue.Id, venue.Unavailable, totalSlots, &problems)
+	}
+	lecturerIds := make([]string, len(p.Lecturers))
+	for l, lecturer := range p.Lecturers {
+		lecturerIds[l] = lecturer.Id
+		pre.LecturerNames[l] = lecturer.Name
+		pre.LecturerUnavailable[l] = unavailabilityRow("lecturer "+lecturer.Id, lecturer.Unavailable, totalSlots, &problems)
+	}
+	cohortIds := make([]string, len(p.Cohorts))
+	for c, cohort := range p.Cohorts {
+		cohortIds[c] = cohort.Id
+		pre.CohortNames[c] = cohort.Name
+	}
+	courseIds := make([]string, len(p.Courses))
+	for c, course := range p.Courses {
+		courseIds[c] = course.Id
+		pre.CourseCodes[c] = course.Code
+	}
+	venueIdx := indexIds("venue", venueIds, &problems)
+	lecturerIdx := indexIds("lecturer", lecturerIds, &problems)
+	cohortIdx := indexIds("cohort", cohortIds, &problems)
+	courseIdx := indexIds("course", courseIds, &problems)
+
+	for i, session := range p.Sessions {
+		label := fmt.Sprintf("session %d", i)
+		atom := SessionAtom{
+			SessionIdx:       i,
+			LecturerIdxs:     lookupIds(label, "lecturer", session.Lecturers, lecturerIdx, &problems),
+			CohortIdxs:       lookupIds(label, "cohort", session.Cohorts, cohortIdx, &problems),
+			SessionDuration:  session.Duration,
+			AllowedVenuesIdx: lookupIds(label, "venue", session.AllowedVenues, venueIdx, &problems),
+			VenueRanks:       session.VenueRanks,
+			Headcount:        session.Headcount,
+			SessionType:      session.Type,
+			GroupIdx:         session.Group,
+			GroupCount:       session.Groups,
+		}
+		if idx, ok := courseIdx[session.Course]; ok {
+			atom.CourseIdx = idx
+		} else {
+			problems = append(problems, fmt.Sprintf("%s refers to unknown course %s", label, session.Course))
+		}
+		if session.Duration < 1 || session.Duration > week.SlotsPerDay() {
+			problems = append(problems, fmt.Sprintf("%s lasts %d slots, it must be between 1 and the %d slots of a day", label, session.Duration, week.SlotsPerDay()))
+		}
+		if len(session.Lecturers) == 0 {
+			problems = append(problems, fmt.Sprintf("%s has no lecturers", label))
+		}
+		if len(session.VenueRanks) > 0 && len(session.VenueRanks) != len(session.AllowedVenues) {
+			problems = append(problems, fmt.Sprintf("%s has %d venue ranks for %d allowed venues", label, len(session.VenueRanks), len(session.AllowedVenues)))
+		}
+		if session.Groups < 0 || session.Group < 0 || (session.Group > 0 && session.Group >= session.Groups) {
+			problems = append(problems, fmt.Sprintf("%s is for group %d of %d groups", label, session.Group, session.Groups))
+		}
+		if session.Pin != nil {
+			if idx, ok := venueIdx[session.Pin.Venue]; ok {
+				atom.Pinned = true
+				atom.PinnedSlotIdx = session.Pin.Slot
+				atom.PinnedVenueIdx = idx
+			} else {
+				problems = append(problems, fmt.Sprintf("%s is pinned to unknown venue %s", label, session.Pin.Venue))
+			}
+			if session.Pin.Slot < 0 || session.Pin.Slot+session.Duration > totalSlots {
+				problems = append(problems, fmt.Sprintf("%s is pinned to slot %d which is not in the week", label, session.Pin.Slot))
+			}
+		}
+		pre.SessionAtoms = append(pre.SessionAtoms, atom)
+	}
+
+	settings := make([]ConstraintSetting, 0, len(p.Constraints))
+	for _, constraint := range p.Constraints {
+		settings = append(settings, ConstraintSetting{
+			Name:       constraint.Name,
+			Enabled:    constraint.Enabled,
+			Weight:     constraint.Weight,
+			LimitValue: constraint.Limit,
+			Hard:       constraint.Hard,
+		})
+	}
+	constraints, err := BuildConstraints(settings)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	pre.Constraints = constraints
+
+	if len(p.Travel) > 0 {
+		pre.TravelMinutes = make([][]int, len(p.Venues))
+		for v := range pre.TravelMinutes {
+			pre.TravelMinutes[v] = make([]int, len(p.Venues))
+		}
+	}
+	for _, travel := range p.Travel {
+		from, fromOk := venueIdx[travel.From]
+		to, toOk := venueIdx[travel.To]
+		if !fromOk || !toOk {
+			problems = append(problems, fmt.Sprintf("the travel time from %s to %s refers to an unknown venue", travel.From, travel.To))
+			continue
+		}
+		if travel.Minutes < 0 {
+			problems = append(problems, fmt.Sprintf("the travel time from %s to %s is negative", travel.From, travel.To))
+			continue
+		}
+		pre.TravelMinutes[from][to] = travel.Minutes
+		pre.TravelMinutes[to][from] = travel.Minutes
+	}
+
+	groups := make([]ElectiveGroup, 0, len(p.Electives))
+	for i, elective := range p.Electives {
+		label := fmt.Sprintf("elective group %d", i)
+		group := ElectiveGroup{CourseIdxs: lookupIds(label, "course", elective.Courses, courseIdx, &problems)}
+		if idx, ok := cohortIdx[elective.Cohort]; ok {
+			group.CohortIdx = idx
+		} else {
+			problems = append(problems, fmt.Sprintf("%s refers to unknown cohort %s", label, elective.Cohort))
+		}
+		groups = append(groups, group)
+	}
+	sharedStudents := make([][2]int, 0, len(p.SharedStudents))
+	for _, pair := range p.SharedStudents {
+		idxs := lookupIds("a pair of shared students", "course", pair[:], courseIdx, &problems)
+		if len(idxs) == 2 {
+			sharedStudents = append(sharedStudents, [2]int{idxs[0], idxs[1]})
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, TeachingWeek{}, fmt.Errorf("%w: %s", ErrInvalidProblem, strings.Join(problems, "; "))
+	}
+	AssignCohortKeys(pre, groups, sharedStudents)
+	return pre, week, nil
+}
+
+// reads a problem file, fields the format does not know are rejected so a typo is not silently ignored
+func ReadProblem(r io.Reader) (*Problem, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var problem Problem
+	if err := decoder.Decode(&problem); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProblem, err)
+	}
+	return &problem, nil
+}
+
+func WriteProblem(w io.Writer, problem *Problem) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(problem)
+}
@@ -0,0 +1,274 @@
+package computed
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// a session of a saved timetable
+type ExistingPlacement struct {
+	CourseId     uuid.UUID
+	SessionIdx   int // as saved, only keeps the sessions of a course in order
+	VenueId      uuid.UUID
+	Day          string
+	StartMinutes int
+}
+
+// a session the repair had to move
+type MovedSession struct {
+	SessionIdx int                // into pre.SessionAtoms
+	Previous   *ExistingPlacement // nil when the session was not in the timetable
+	Placement  SessionPlacement
+	Reason     string
+}
+
+// the slot and venue a saved placement sits in, false if either is no longer part of the timetable
+func existingSlot(week TeachingWeek, dayIndex map[string]int, venueMap map[uuid.UUID]int, p ExistingPlacement) (int, int, bool) {
+	dayIdx, ok := dayIndex[p.Day]
+	if !ok {
+		return 0, 0, false
+	}
+	offset := p.StartMinutes - week.GridStartMinutes()
+	if offset < 0 || offset%week.SlotMinutes != 0 || offset/week.SlotMinutes >= week.SlotsPerDay() {
+		return 0, 0, false
+	}
+	venueIdx, ok := venueMap[p.VenueId]
+	if !ok {
+		return 0, 0, false
+	}
+	return dayIdx*week.SlotsPerDay() + offset/week.SlotMinutes, venueIdx, true
+}
+
+// why a session can no longer stay where it is, empty if it can
+func placementViolation(pre *PreComputed, session *SessionAtom, slotIdx int, venueIdx int) string {
+	if slotIdx%pre.SlotsPerDay+session.SessionDuration > pre.SlotsPerDay || isBlocked(pre, slotIdx, session.SessionDuration) {
+		return "it runs outside the teaching hours"
+	}
+	allowed := false
+	for _, v := range session.AllowedVenuesIdx {
+		if v == venueIdx {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "its venue can no longer hold the course"
+	}
+	for si := slotIdx; si < slotIdx+session.SessionDuration; si++ {
+		if venueIdx < len(pre.VenueUnavailable) && pre.VenueUnavailable[venueIdx][si] {
+			return "its venue has become unavailable"
+		}
+	}
+	for _, lecturerIdx := range session.LecturerIdxs {
+		for si := slotIdx; si < slotIdx+session.SessionDuration; si++ {
+			if pre.LecturerUnavailable[lecturerIdx][si] {
+				return "a lecturer has become unavailable"
+			}
+		}
+	}
+	return ""
+}
+
+// true if any slot of the session is already taken by its venue, a lecturer or a cohort
+func occupied(session *SessionAtom, slotIdx int, venueIdx int, venueOcc *Occupancy, lecturerOcc *Occupancy, cohortOcc *Occupancy) bool {
+	if venueOcc.AnyInRange(venueIdx, slotIdx, session.SessionDuration) {
+		return true
+	}
+	for _, lecturerIdx := range session.LecturerIdxs {
+		if lecturerOcc.AnyInRange(lecturerIdx, slotIdx, session.SessionDuration) {
+			return true
+		}
+	}
+	for _, cohortIdx := range session.clashCohortKeys() {
+		if cohortOcc.AnyInRange(cohortIdx, slotIdx, session.SessionDuration) {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// where a session of pre.SessionAtoms sat in a saved timetable
+type savedSlot struct {
+	previous *ExistingPlacement // nil when the session was not in the timetable
+	slotIdx  int
+	venueIdx int
+	ok       bool // false when it has no placement or its day, time or venue is no longer part of the timetable
+}
+
+// the nth session of a course takes the place of the nth saved session of the course
+func matchSavedPlacements(pre *PreComputed, week TeachingWeek, existing []ExistingPlacement, courseMap map[uuid.UUID]int, venueMap map[uuid.UUID]int) []savedSlot {
+	dayIndex := make(map[string]int)
+	for i, d := range week.DayNames() {
+		dayIndex[d] = i
+	}
+
+	// the saved sessions of every course in the order they were saved
+	saved := make(map[int][]ExistingPlacement)
+	for _, p := range existing {
+		if courseIdx, ok := courseMap[p.CourseId]; ok {
+			saved[courseIdx] = append(saved[courseIdx], p)
+		}
+	}
+	for _, list := range saved {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].SessionIdx < list[j].SessionIdx })
+	}
+
+	slots := make([]savedSlot, len(pre.SessionAtoms))
+	nth := make(map[int]int)
+	for i := range pre.SessionAtoms {
+		courseIdx := pre.SessionAtoms[i].CourseIdx
+		n := nth[courseIdx]
+		nth[courseIdx]++
+		if n >= len(saved[courseIdx]) {
+			continue
+		}
+		p := saved[courseIdx][n]
+		slots[i].previous = &p
+		slots[i].slotIdx, slots[i].venueIdx, slots[i].ok = existingSlot(week, dayIndex, venueMap, p)
+	}
+	return slots
+}
+
+// rebuilds a saved timetable on the current data without moving anything, the sessions that
+// cannot be put back where they were are returned as violations
+func CandidateFromPlacements(pre *PreComputed, week TeachingWeek, existing []ExistingPlacement, courseMap map[uuid.UUID]int, venueMap map[uuid.UUID]int) (*Candidate, []Violation) {
+	placements := make([]SessionPlacement, 0, len(pre.SessionAtoms))
+	missing := make([]Violation, 0)
+	for i, slot := range matchSavedPlacements(pre, week, existing, courseMap, venueMap) {
+		session := &pre.SessionAtoms[i]
+		if !slot.ok {
+			message := fmt.Sprintf("%s has a session that is not in the timetable", courseCode(pre, session.CourseIdx))
+			if slot.previous != nil {
+				message = fmt.Sprintf("%s has a session whose day, time or venue is no longer part of the timetable", courseCode(pre, session.CourseIdx))
+			}
+			missing = append(missing, Violation{
+				Type:         NotPlaced,
+				Hard:         true,
+				SlotIdx:      -1,
+				SessionIdxs:  []int{i},
+				CourseIdxs:   []int{session.CourseIdx},
+				LecturerIdxs: session.LecturerIdxs,
+				CohortIdxs:   session.CohortIdxs,
+				Message:      message,
+			})
+			continue
+		}
+		placements = append(placements, SessionPlacement{SessionIdx: i, CourseIdx: session.CourseIdx, VenueIdx: slot.venueIdx, SlotIdx: slot.slotIdx})
+	}
+	return &Candidate{Placements: placements}, missing
+}
+
+// starts from the saved placements and moves only the sessions that now break a hard
+// constraint, every other session keeps its slot and venue
+func RepairPlacements(pre *PreComputed, week TeachingWeek, existing []ExistingPlacement, courseMap map[uuid.UUID]int, venueMap map[uuid.UUID]int) (*Candidate, []MovedSession) {
+	venueOcc := NewOccupancy(pre.NumVenues, pre.TotalSlots)
+	lecturerOcc := NewOccupancy(pre.NumLecturers, pre.TotalSlots)
+	cohortOcc := NewOccupancy(cohortKeyRows(pre), pre.TotalSlots)
+	markPinnedOccupancy(pre, venueOcc, lecturerOcc, cohortOcc)
+
+	totalSessions := len(pre.SessionAtoms)
+	placements := make([]SessionPlacement, totalSessions)
+	previous := make([]*ExistingPlacement, totalSessions)
+	reasons := make([]string, totalSessions)
+	fromSlot := make([]int, totalSessions) // -1 when the session had no usable slot
+
+	for i, slot := range matchSavedPlacements(pre, week, existing, courseMap, venueMap) {
+		session := &pre.SessionAtoms[i]
+		previous[i] = slot.previous
+		slotIdx, venueIdx, ok := slot.slotIdx, slot.venueIdx, slot.ok
+
+		fromSlot[i] = -1
+		switch {
+		case ok:
+			fromSlot[i] = slotIdx
+		case slot.previous != nil:
+			reasons[i] = "its day, time or venue is no longer part of the timetable"
+		default:
+			reasons[i] = "it was not in the timetable"
+		}
+
+		if session.Pinned {
+			placements[i] = pinnedPlacement(session)
+			if ok && (slotIdx != session.PinnedSlotIdx || venueIdx != session.PinnedVenueIdx) {
+				reasons[i] = "it has been pinned"
+			} else if ok {
+				reasons[i] = ""
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		placements[i] = SessionPlacement{SessionIdx: i, CourseIdx: session.CourseIdx, VenueIdx: venueIdx, SlotIdx: slotIdx}
+		reasons[i] = placementViolation(pre, session, slotIdx, venueIdx)
+	}
+
+	// of two sessions that clash the one that comes first stays
+	for i := range pre.SessionAtoms {
+		session := &pre.SessionAtoms[i]
+		if session.Pinned || reasons[i] != "" {
+			continue
+		}
+		if occupied(session, placements[i].SlotIdx, placements[i].VenueIdx, venueOcc, lecturerOcc, cohortOcc) {
+			reasons[i] = "it clashes with another session"
+			continue
+		}
+		bookSession(session, placements[i].SlotIdx, placements[i].VenueIdx, venueOcc, lecturerOcc, cohortOcc)
+	}
+
+	toMove := make([]int, 0)
+	for i := range pre.SessionAtoms {
+		if reasons[i] != "" && !pre.SessionAtoms[i].Pinned {
+			toMove = append(toMove, i)
+		}
+	}
+	// the hardest sessions to place go first like in BuildOneCandidate
+	sort.SliceStable(toMove, func(i, j int) bool {
+		a := pre.SessionAtoms[toMove[i]]
+		b := pre.SessionAtoms[toMove[j]]
+		scoreA := float64(len(a.AllowedVenuesIdx))*0.5 + float64(a.SessionDuration)*1.0
+		scoreB := float64(len(b.AllowedVenuesIdx))*0.5 + float64(b.SessionDuration)*1.0
+		return scoreA < scoreB
+	})
+
+	for _, i := range toMove {
+		session := &pre.SessionAtoms[i]
+		feasible := ComputeFeasiblePairs(pre, session, venueOcc, lecturerOcc, cohortOcc)
+		if len(feasible) > 0 {
+			// the snuggest room first, then the slot nearest to where the session was
+			chosen := feasible[0]
+			if from := fromSlot[i]; from >= 0 {
+				for _, pair := range feasible {
+					if pair.Score > chosen.Score {
+						break
+					}
+					if abs(pair.SlotIdx-from) < abs(chosen.SlotIdx-from) {
+						chosen = pair
+					}
+				}
+			}
+			placements[i] = SessionPlacement{SessionIdx: i, CourseIdx: session.CourseIdx, VenueIdx: chosen.VenueIdx, SlotIdx: chosen.SlotIdx}
+		} else {
+			best := ComputeLeastBadPair(pre, session, venueOcc, lecturerOcc, cohortOcc)
+			placements[i] = SessionPlacement{SessionIdx: i, CourseIdx: session.CourseIdx, VenueIdx: best.VenueIdx, SlotIdx: best.SlotIdx, Conflict: true, Score: best.Score}
+		}
+		bookSession(session, placements[i].SlotIdx, placements[i].VenueIdx, venueOcc, lecturerOcc, cohortOcc)
+	}
+
+	moved := make([]MovedSession, 0)
+	for i := range pre.SessionAtoms {
+		if reasons[i] != "" {
+			moved = append(moved, MovedSession{SessionIdx: i, Previous: previous[i], Placement: placements[i], Reason: reasons[i]})
+		}
+	}
+	return &Candidate{Placements: placements}, moved
+}
@@ -0,0 +1,77 @@
+package computed
+
+import (
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+// how much of the timetable a generation rebuilds
+const (
+	ScopeUniversity = "UNIVERSITY"
+	ScopeFaculty    = "FACULTY"
+	ScopeDepartment = "DEPARTMENT"
+)
+
+// the part of the timetable a generation places, the sessions outside it keep their published
+// placements. Id is the faculty or department and nil for the whole university
+type GenerationScope struct {
+	Kind string
+	Id   uuid.UUID
+}
+
+func (s GenerationScope) IsWholeUniversity() bool {
+	return s.Kind == "" || s.Kind == ScopeUniversity
+}
+
+// true if the course belongs to the scope, a course shared with other departments belongs to
+// the department that owns it
+func (s GenerationScope) Includes(course sqlc.GetCourseDepartmentsRow) bool {
+	switch s.Kind {
+	case ScopeFaculty:
+		return course.FacultyID == s.Id
+	case ScopeDepartment:
+		return course.DepartmentID == s.Id
+	}
+	return true
+}
+
+// inScope[courseIdx] is true for the courses the generation places
+func (s GenerationScope) CoursesInScope(courses []sqlc.GetCourseDepartmentsRow, courseMap map[uuid.UUID]int, numCourses int) []bool {
+	inScope := make([]bool, numCourses)
+	if s.IsWholeUniversity() {
+		for i := range inScope {
+			inScope[i] = true
+		}
+		return inScope
+	}
+	for _, course := range courses {
+		if courseIdx, ok := courseMap[course.CourseID]; ok && courseIdx < numCourses {
+			inScope[courseIdx] = s.Includes(course)
+		}
+	}
+	return inScope
+}
+
+// fixes every session outside the scope to where the published timetable has it, the same way a
+// pin is fixed, so the solver only places the sessions in scope around them. a session outside
+// the scope that the published timetable does not have, or whose placement no longer holds, is
+// left to the solver and returned with the number of sessions fixed
+func FixOutOfScopeSessions(pre *PreComputed, week TeachingWeek, published []ExistingPlacement, inScope []bool, courseMap map[uuid.UUID]int, venueMap map[uuid.UUID]int) (int, []int) {
+	fixed := 0
+	unplaced := make([]int, 0)
+	for i, slot := range matchSavedPlacements(pre, week, published, courseMap, venueMap) {
+		session := &pre.SessionAtoms[i]
+		if session.Pinned || (session.CourseIdx < len(inScope) && inScope[session.CourseIdx]) {
+			continue
+		}
+		if !slot.ok || placementViolation(pre, session, slot.slotIdx, slot.venueIdx) != "" {
+			unplaced = append(unplaced, i)
+			continue
+		}
+		session.Pinned = true
+		session.PinnedSlotIdx = slot.slotIdx
+		session.PinnedVenueIdx = slot.venueIdx
+		fixed++
+	}
+	return fixed, unplaced
+}
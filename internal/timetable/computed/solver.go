@@ -0,0 +1,204 @@
+package computed
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// the solvers a timetable can be generated with
+const (
+	SolverGenetic     = "GENETIC"
+	SolverAnnealing   = "ANNEALING"
+	SolverGeneticTabu = "GENETIC_TABU" // the genetic algorithm with its best candidate polished by tabu search
+)
+
+// anything that can turn a PreComputed into a candidate timetable.
+// onProgress (if not nil) is called as the solver goes
+type Solver interface {
+	Name() string
+	Solve(ctx context.Context, pre *PreComputed, onProgress func(GenerationProgress)) (*Candidate, error)
+}
+
+func IsKnownSolver(name string) bool {
+	switch name {
+	case SolverGenetic, SolverAnnealing, SolverGeneticTabu:
+		return true
+	}
+	return false
+}
+
+// the knobs of simulated annealing for a single run
+type AnnealingParams struct {
+	Iterations         int
+	InitialTemperature float64 // a move that is worse by this much is kept about a third of the time at the start
+	FinalTemperature   float64 // the temperature is cooled geometrically down to this by the last iteration
+	SampleK            int     // pick from the top k feasible pairs when building the starting candidate
+	Seed               int64
+}
+
+func DefaultAnnealingParams() AnnealingParams {
+	return AnnealingParams{
+		Iterations:         20000,
+		InitialTemperature: 2000,
+		FinalTemperature:   0.5,
+		SampleK:            5,
+	}
+}
+
+func (p AnnealingParams) Validate() error {
+	if p.Iterations < 1 {
+		return fmt.Errorf("iterations must be at least 1, got %d", p.Iterations)
+	}
+	if p.InitialTemperature <= 0 {
+		return fmt.Errorf("initial temperature must be above 0, got %v", p.InitialTemperature)
+	}
+	if p.FinalTemperature <= 0 || p.FinalTemperature > p.InitialTemperature {
+		return fmt.Errorf("final temperature must be above 0 and at most the initial temperature, got %v", p.FinalTemperature)
+	}
+	if p.SampleK < 1 {
+		return fmt.Errorf("sample k must be at least 1, got %d", p.SampleK)
+	}
+	return nil
+}
+
+// the knobs of tabu search for a single run
+type TabuParams struct {
+	Iterations int
+	Tenure     int // iterations a session may not move back to the slot and venue it left
+	Neighbours int // moves sampled every iteration
+	Seed       int64
+}
+
+func DefaultTabuParams() TabuParams {
+	return TabuParams{
+		Iterations: 300,
+		Tenure:     15,
+		Neighbours: 30,
+	}
+}
+
+func (p TabuParams) Validate() error {
+	if p.Iterations < 1 {
+		return fmt.Errorf("iterations must be at least 1, got %d", p.Iterations)
+	}
+	if p.Tenure < 1 {
+		return fmt.Errorf("tenure must be at least 1, got %d", p.Tenure)
+	}
+	if p.Neighbours < 1 {
+		return fmt.Errorf("neighbours must be at least 1, got %d", p.Neighbours)
+	}
+	return nil
+}
+
+type GeneticSolver struct {
+	Params GAParams
+}
+
+func (s GeneticSolver) Name() string { return SolverGenetic }
+
+func (s GeneticSolver) Solve(ctx context.Context, pre *PreComputed, onProgress func(GenerationProgress)) (*Candidate, error) {
+	return GeneticAlgorithm(ctx, pre, s.Params, onProgress)
+}
+
+type AnnealingSolver struct {
+	Params AnnealingParams
+}
+
+func (s AnnealingSolver) Name() string { return SolverAnnealing }
+
+func (s AnnealingSolver) Solve(ctx context.Context, pre *PreComputed, onProgress func(GenerationProgress)) (*Candidate, error) {
+	return SimulatedAnnealing(ctx, pre, s.Params, onProgress)
+}
+
+type GeneticTabuSolver struct {
+	GA   GAParams
+	Tabu TabuParams
+}
+
+func (s GeneticTabuSolver) Name() string { return SolverGeneticTabu }
+
+// progress counts the generations and then the tabu iterations as one run, the history and stop
+// reason are the ones of the genetic run
+func (s GeneticTabuSolver) Solve(ctx context.Context, pre *PreComputed, onProgress func(GenerationProgress)) (*Candidate, error) {
+	total := s.GA.Generations + s.Tabu.Iterations
+	report := func(offset int) func(GenerationProgress) {
+		if onProgress == nil {
+			return nil
+		}
+		return func(progress GenerationProgress) {
+			onProgress(GenerationProgress{
+				Generation:  offset + progress.Generation,
+				Generations: total,
+				BestFitness: progress.BestFitness,
+			})
+		}
+	}
+
+	best, err := GeneticAlgorithm(ctx, pre, s.GA, report(0))
+	if err != nil {
+		return nil, err
+	}
+	// the tabu iterations carry on from wherever the genetic run stopped
+	polished, err := TabuSearch(ctx, pre, best, s.Tabu, report(len(best.History)-1))
+	if err != nil {
+		return nil, err
+	}
+	polished.History = best.History
+	polished.StopReason = best.StopReason
+	return polished, nil
+}
+
+// the solver with the given name. the genetic algorithm runs on params, the local searches run on
+// their defaults with the seed of params so a stored seed replays any of them
+func NewSolver(name string, params GAParams) (Solver, error) {
+	switch name {
+	case SolverGenetic, "":
+		return GeneticSolver{Params: params}, nil
+	case SolverAnnealing:
+		annealing := DefaultAnnealingParams()
+		annealing.SampleK = params.SampleK
+		annealing.Seed = params.Seed
+		return AnnealingSolver{Params: annealing}, nil
+	case SolverGeneticTabu:
+		tabu := DefaultTabuParams()
+		tabu.Seed = params.Seed
+		return GeneticTabuSolver{GA: params, Tabu: tabu}, nil
+	}
+	return nil, fmt.Errorf("unknown solver %q", name)
+}
+
+// how one solver did on a PreComputed, the penalties are rescored with RescoreCandidate
+type SolverResult struct {
+	Solver         string
+	Candidate      *Candidate // as the solver returned it, nil when the solver failed
+	Fitness        float64
+	HardPenalty    float64
+	SoftPenalty    float64
+	HardViolations int
+	Duration       time.Duration
+	Err            error
+}
+
+// runs every solver on the same pre one after another, stops early if ctx is cancelled
+func BenchmarkSolvers(ctx context.Context, pre *PreComputed, solvers []Solver) []SolverResult {
+	results := make([]SolverResult, 0, len(solvers))
+	for _, solver := range solvers {
+		if ctx.Err() != nil {
+			break
+		}
+		start := time.Now()
+		cand, err := solver.Solve(ctx, pre, nil)
+		result := SolverResult{Solver: solver.Name(), Duration: time.Since(start), Err: err}
+		if err == nil {
+			scored := RescoreCandidate(pre, cand)
+			result.Candidate = cand
+			result.Fitness = scored.Fitness
+			result.HardPenalty = scored.HardPenalty
+			result.SoftPenalty = scored.SoftPenalty
+			result.HardViolations = len(FindHardViolations(pre, cand))
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,202 @@
+package computed
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// every day a university can teach on, in week order
+var WeekDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// a day the university teaches on, times are minutes after midnight
+type TeachingDay struct {
+	Day          string
+	StartMinutes int
+	EndMinutes   int
+}
+
+// the days a university teaches on, their hours and how long a slot is.
+// every day is laid on the same grid from the earliest start to the latest end,
+// slots outside a day's own hours are blocked e.g the afternoon of a short friday
+type TeachingWeek struct {
+	Days        []TeachingDay // in week order
+	SlotMinutes int
+}
+
+func IsValidSlotMinutes(slotMinutes int) bool {
+	return slotMinutes == 30 || slotMinutes == 60 || slotMinutes == 90
+}
+
+func dayOrder(day string) int {
+	for i, d := range WeekDays {
+		if d == day {
+			return i
+		}
+	}
+	return -1
+}
+
+// the week used by a university that has not configured one, monday to friday with hourly slots
+func DefaultTeachingWeek(startMinutes int, endMinutes int) TeachingWeek {
+	days := make([]TeachingDay, 0, 5)
+	for _, day := range WeekDays[:5] {
+		days = append(days, TeachingDay{Day: day, StartMinutes: startMinutes, EndMinutes: endMinutes})
+	}
+	return TeachingWeek{Days: days, SlotMinutes: 60}
+}
+
+// sorts the days into week order and validates the week
+func NewTeachingWeek(days []TeachingDay, slotMinutes int) (TeachingWeek, error) {
+	sorted := make([]TeachingDay, len(days))
+	copy(sorted, days)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return dayOrder(sorted[i].Day) < dayOrder(sorted[j].Day)
+	})
+	week := TeachingWeek{Days: sorted, SlotMinutes: slotMinutes}
+	return week, week.Validate()
+}
+
+func (w TeachingWeek) Validate() error {
+	if !IsValidSlotMinutes(w.SlotMinutes) {
+		return fmt.Errorf("slot length must be 30, 60 or 90 minutes, got %d", w.SlotMinutes)
+	}
+	if len(w.Days) == 0 {
+		return fmt.Errorf("at least one teaching day is required")
+	}
+	seen := make(map[string]bool)
+	for _, day := range w.Days {
+		if dayOrder(day.Day) == -1 {
+			return fmt.Errorf("unknown day %q", day.Day)
+		}
+		if seen[day.Day] {
+			return fmt.Errorf("%s is configured more than once", day.Day)
+		}
+		seen[day.Day] = true
+		if day.StartMinutes < 0 || day.EndMinutes > 24*60 || day.StartMinutes >= day.EndMinutes {
+			return fmt.Errorf("%s must start before it ends", day.Day)
+		}
+		if day.EndMinutes-day.StartMinutes < w.SlotMinutes {
+			return fmt.Errorf("%s is shorter than one slot", day.Day)
+		}
+	}
+	return nil
+}
+
+func (w TeachingWeek) DayNames() []string {
+	names := make([]string, 0, len(w.Days))
+	for _, day := range w.Days {
+		names = append(names, day.Day)
+	}
+	return names
+}
+
+// when the first slot of every day starts
+func (w TeachingWeek) GridStartMinutes() int {
+	start := 24 * 60
+	for _, day := range w.Days {
+		if day.StartMinutes < start {
+			start = day.StartMinutes
+		}
+	}
+	return start
+}
+
+// when the last day to finish finishes
+func (w TeachingWeek) GridEndMinutes() int {
+	end := 0
+	for _, day := range w.Days {
+		if day.EndMinutes > end {
+			end = day.EndMinutes
+		}
+	}
+	return end
+}
+
+func (w TeachingWeek) SlotsPerDay() int {
+	if w.SlotMinutes <= 0 {
+		return 0
+	}
+	return (w.GridEndMinutes() - w.GridStartMinutes()) / w.SlotMinutes
+}
+
+func (w TeachingWeek) TotalSlots() int {
+	return w.SlotsPerDay() * len(w.Days)
+}
+
+// minutes after midnight the given slot of a day starts
+func (w TeachingWeek) SlotStartMinutes(slotInDay int) int {
+	return w.GridStartMinutes() + slotInDay*w.SlotMinutes
+}
+
+// true when the whole slot is within the hours of the day
+func (w TeachingWeek) slotOpen(day TeachingDay, slotInDay int) bool {
+	start := w.SlotStartMinutes(slotInDay)
+	return start >= day.StartMinutes && start+w.SlotMinutes <= day.EndMinutes
+}
+
+// BlockedSlots[slot] is true when the slot falls outside the hours of its day
+func (w TeachingWeek) BlockedSlots() []bool {
+	slotsPerDay := w.SlotsPerDay()
+	blocked := make([]bool, slotsPerDay*len(w.Days))
+	for d, day := range w.Days {
+		for s := 0; s < slotsPerDay; s++ {
+			blocked[d*slotsPerDay+s] = !w.slotOpen(day, s)
+		}
+	}
+	return blocked
+}
+
+// the start of every slot a day teaches in, minutes after midnight
+func (w TeachingWeek) OpenSlotStarts(day TeachingDay) []int {
+	starts := make([]int, 0, w.SlotsPerDay())
+	for s := 0; s < w.SlotsPerDay(); s++ {
+		if w.slotOpen(day, s) {
+			starts = append(starts, w.SlotStartMinutes(s))
+		}
+	}
+	return starts
+}
+
+// the slots of a day that overlap [startMinutes, endMinutes), clamped to the grid
+func (w TeachingWeek) SlotRange(startMinutes int, endMinutes int) (int, int) {
+	gridStart := w.GridStartMinutes()
+	startSlot := (startMinutes - gridStart) / w.SlotMinutes
+	if startMinutes < gridStart {
+		startSlot = 0
+	}
+	// rounds up so a period that ends mid slot still takes the slot
+	endSlot := (endMinutes - gridStart + w.SlotMinutes - 1) / w.SlotMinutes
+	if endSlot > w.SlotsPerDay() {
+		endSlot = w.SlotsPerDay()
+	}
+	return startSlot, endSlot
+}
+
+// the number of slots a session of the given hours takes, never less than one
+func (w TeachingWeek) DurationSlots(hours int) int {
+	minutes := hours * 60
+	slots := (minutes + w.SlotMinutes - 1) / w.SlotMinutes
+	if slots < 1 {
+		slots = 1
+	}
+	return slots
+}
+
+// formats minutes after midnight as HH:MM
+func FormatMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func MinutesOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// parses a postgres TIME e.g 08:30:00 into minutes after midnight
+func ParseMinutes(timeStr string) (int, error) {
+	t, err := parseTimeFromString(timeStr)
+	if err != nil {
+		return 0, err
+	}
+	return MinutesOfDay(t), nil
+}
@@ -0,0 +1,198 @@
+package computed
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+const (
+	earthRadiusMetres      = 6371000
+	walkingMetresPerMinute = 75 // about 4.5km/h
+)
+
+// the distance in metres between two points on the earth
+func haversineMetres(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMetres * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// the minutes it takes to walk between two points, rounded up
+func WalkingMinutes(lat1 float64, lng1 float64, lat2 float64, lng2 float64) int {
+	return int(math.Ceil(haversineMetres(lat1, lng1, lat2, lng2) / walkingMetresPerMinute))
+}
+
+// the walking minutes between every pair of venues, from their coordinates or the time the
+// university set for the pair, which wins. a venue without coordinates is 0 minutes from the
+// venues without a set time. nil when no time is known at all
+func ComputeTravelMinutes(venues []sqlc.RetrieveAllVenuesRow, overrides []sqlc.GetVenueTravelTimesRow, venueMap map[uuid.UUID]int) [][]int {
+	travel := make([][]int, len(venueMap))
+	for i := range travel {
+		travel[i] = make([]int, len(venueMap))
+	}
+	known := false
+	for _, from := range venues {
+		fromIdx, ok := venueMap[from.VenueID]
+		if !ok || !from.VenueLatitude.Valid || !from.VenueLongitude.Valid {
+			continue
+		}
+		for _, to := range venues {
+			toIdx, ok := venueMap[to.VenueID]
+			if !ok || toIdx == fromIdx || !to.VenueLatitude.Valid || !to.VenueLongitude.Valid {
+				continue
+			}
+			travel[fromIdx][toIdx] = WalkingMinutes(from.VenueLatitude.Float64, from.VenueLongitude.Float64, to.VenueLatitude.Float64, to.VenueLongitude.Float64)
+			known = true
+		}
+	}
+	for _, override := range overrides {
+		fromIdx, fromOk := venueMap[override.FromVenueID]
+		toIdx, toOk := venueMap[override.ToVenueID]
+		if !fromOk || !toOk || fromIdx == toIdx {
+			continue
+		}
+		travel[fromIdx][toIdx] = int(override.Minutes)
+		travel[toIdx][fromIdx] = int(override.Minutes)
+		known = true
+	}
+	if !known {
+		return nil
+	}
+	return travel
+}
+
+// the walking minutes from one venue to another, 0 when they are not known
+func travelMinutes(pre *PreComputed, fromVenueIdx int, toVenueIdx int) int {
+	if fromVenueIdx < 0 || fromVenueIdx >= len(pre.TravelMinutes) || toVenueIdx < 0 || toVenueIdx >= len(pre.TravelMinutes[fromVenueIdx]) {
+		return 0
+	}
+	return pre.TravelMinutes[fromVenueIdx][toVenueIdx]
+}
+
+// a cohort or lecturer should have time to walk from one session to the next. sessions end
+// changeoverMinutes before their last slot does, so back to back sessions leave that long to move
+type travelTimeConstraint struct {
+	weight            float64
+	changeoverMinutes float64
+}
+
+func (c travelTimeConstraint) Name() string    { return TravelTime }
+func (c travelTimeConstraint) Weight() float64 { return c.weight }
+
+// every move between two sessions that is longer than the time between them counts once
+func (c travelTimeConstraint) Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64 {
+	var found breaches
+	c.visitCohorts(pre, cand, buf, &found)
+	c.visitLecturers(pre, cand, buf, &found)
+	return float64(found.total)
+}
+
+func (c travelTimeConstraint) Violations(pre *PreComputed, cand *Candidate) []Violation {
+	violations := collectBreaches(c, c.visitCohorts, pre, cand, func(b breach) Violation {
+		return Violation{
+			CohortIdxs: cohortKeyCohorts(pre, b.idx),
+			VenueIdxs:  b.venueIdxs(),
+			Message:    fmt.Sprintf("%s needs %d minutes to walk from %s to %s, more than the time between its sessions", cohortKeyName(pre, b.idx), b.count, venueName(pre, b.venues[0]), venueName(pre, b.venues[1])),
+		}
+	})
+	return append(violations, collectBreaches(c, c.visitLecturers, pre, cand, func(b breach) Violation {
+		return Violation{
+			LecturerIdxs: []int{b.idx},
+			VenueIdxs:    b.venueIdxs(),
+			Message:      fmt.Sprintf("%s needs %d minutes to walk from %s to %s, more than the time between its sessions", lecturerName(pre, b.idx), b.count, venueName(pre, b.venues[0]), venueName(pre, b.venues[1])),
+		}
+	})...)
+}
+
+// empties every list of stops keeping what it has room for
+func resetStops(stops [][]SessionPlacement) {
+	for i := range stops {
+		stops[i] = stops[i][:0]
+	}
+}
+
+func (c travelTimeConstraint) visitCohorts(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	if len(pre.TravelMinutes) == 0 {
+		return
+	}
+	// by row of the cohort occupancy, so the students of one elective or group never walk to
+	// the sessions of another
+	stops := buf.cohortStops
+	resetStops(stops)
+	for _, placement := range cand.Placements {
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+		for _, key := range pre.SessionAtoms[placement.SessionIdx].bookedCohortKeys() {
+			if key >= 0 && key < len(stops) {
+				stops[key] = append(stops[key], placement)
+			}
+		}
+	}
+	for key, placements := range stops {
+		c.visitStops(pre, placements, key, found)
+	}
+}
+
+func (c travelTimeConstraint) visitLecturers(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	if len(pre.TravelMinutes) == 0 {
+		return
+	}
+	stops := buf.lecturerStops
+	resetStops(stops)
+	for _, placement := range cand.Placements {
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+		for _, lecturerIdx := range pre.SessionAtoms[placement.SessionIdx].LecturerIdxs {
+			if lecturerIdx >= 0 && lecturerIdx < len(stops) {
+				stops[lecturerIdx] = append(stops[lecturerIdx], placement)
+			}
+		}
+	}
+	for lecturerIdx, placements := range stops {
+		c.visitStops(pre, placements, lecturerIdx, found)
+	}
+}
+
+// reports every session of a cohort or lecturer that starts too soon after the one before it on
+// the same day to walk between their venues. overlapping sessions are clashes, not breaches
+func (c travelTimeConstraint) visitStops(pre *PreComputed, placements []SessionPlacement, idx int, found *breaches) {
+	if len(placements) < 2 || pre.SlotsPerDay <= 0 {
+		return
+	}
+	slotMinutes := pre.SlotMinutes
+	if slotMinutes <= 0 {
+		slotMinutes = 60
+	}
+	slices.SortFunc(placements, func(a, b SessionPlacement) int {
+		if a.SlotIdx != b.SlotIdx {
+			return cmp.Compare(a.SlotIdx, b.SlotIdx)
+		}
+		return cmp.Compare(a.SessionIdx, b.SessionIdx)
+	})
+	for i := 1; i < len(placements); i++ {
+		prev, next := placements[i-1], placements[i]
+		if prev.SlotIdx/pre.SlotsPerDay != next.SlotIdx/pre.SlotsPerDay {
+			continue
+		}
+		prevEnd := prev.SlotIdx + pre.SessionAtoms[prev.SessionIdx].SessionDuration
+		gapSlots := next.SlotIdx - prevEnd
+		if gapSlots < 0 {
+			continue
+		}
+		walk := travelMinutes(pre, prev.VenueIdx, next.VenueIdx)
+		if float64(walk) <= float64(gapSlots*slotMinutes)+c.changeoverMinutes {
+			continue
+		}
+		nextEnd := next.SlotIdx + pre.SessionAtoms[next.SessionIdx].SessionDuration
+		found.report(breach{slotIdx: prev.SlotIdx, slots: nextEnd - prev.SlotIdx, amount: 1, count: walk, idx: idx, venues: [2]int{prev.VenueIdx, next.VenueIdx}, nVenues: 2})
+	}
+}
@@ -0,0 +1,258 @@
+package computed
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+// what the scheduler knows of a venue besides its capacity, by venue idx
+type VenueInfo struct {
+	Kind        string          // e.g HALL, LAB or CLASSROOM, empty for a general room
+	Features    map[string]bool // e.g PROJECTOR, COMPUTER_LAB or WHEELCHAIR_ACCESS
+	Faculties   []uuid.UUID     // the faculties that own the venue in faculty_venues
+	Departments []uuid.UUID     // the departments that own the venue in dept_venues
+	Inactive    bool
+	Restricted  bool // only for the departments and faculties that own it
+}
+
+// how far a venue is from the rooms of a course's department, the scheduler tries them in this order
+const (
+	VenueRankOwnDepartment = 0
+	VenueRankOwnFaculty    = 1
+	VenueRankShared        = 2 // nobody owns the venue
+	VenueRankOtherOwner    = 3 // another department or faculty owns the venue
+)
+
+// the kind, features and owners of every venue by idx
+func ComputeVenueInfo(venues []sqlc.RetrieveAllVenuesRow, features []sqlc.RetrieveVenueFeaturesForUniRow, owners []sqlc.RetrieveVenueOwnersForUniRow, venueMap map[uuid.UUID]int) []VenueInfo {
+	info := make([]VenueInfo, len(venueMap))
+	for i := range info {
+		info[i].Features = make(map[string]bool)
+	}
+	for _, venue := range venues {
+		if idx, ok := venueMap[venue.VenueID]; ok {
+			info[idx].Kind = venue.VenueKind.String
+			// is_active defaults to true, a null counts as active
+			info[idx].Inactive = venue.IsActive.Valid && !venue.IsActive.Bool
+			info[idx].Restricted = venue.IsRestricted
+		}
+	}
+	for _, row := range features {
+		if idx, ok := venueMap[row.VenueID]; ok {
+			info[idx].Features[row.Feature] = true
+		}
+	}
+	for _, row := range owners {
+		idx, ok := venueMap[row.VenueID]
+		if !ok {
+			continue
+		}
+		if row.FacultyID.Valid {
+			info[idx].Faculties = append(info[idx].Faculties, row.FacultyID.UUID)
+		}
+		if row.DepartmentID.Valid {
+			info[idx].Departments = append(info[idx].Departments, row.DepartmentID.UUID)
+		}
+	}
+	return info
+}
+
+// how far the venue is from the rooms of a course of the department, see VenueRankOwnDepartment
+func (v VenueInfo) rankFor(departmentId uuid.UUID, facultyId uuid.UUID) int {
+	if len(v.Faculties) == 0 && len(v.Departments) == 0 {
+		return VenueRankShared
+	}
+	for _, id := range v.Departments {
+		if id == departmentId {
+			return VenueRankOwnDepartment
+		}
+	}
+	for _, id := range v.Faculties {
+		if id == facultyId {
+			return VenueRankOwnFaculty
+		}
+	}
+	return VenueRankOtherOwner
+}
+
+// true if the venue is restricted to owners the department is not one of, it is never used for
+// the department's courses even when they list it
+func (v VenueInfo) barredFrom(departmentId uuid.UUID, facultyId uuid.UUID) bool {
+	return v.Restricted && v.rankFor(departmentId, facultyId) == VenueRankOtherOwner
+}
+
+// the venues a course without possible venues of its own may use, in idx order. every active
+// venue the course is not barred from, how far it is from the course's rooms is left to the
+// VENUE_OWNERSHIP constraint
+func derivedCourseVenues(course modifiedCourseAndVenueData, venues []VenueInfo) []int {
+	out := make([]int, 0)
+	for venueIdx, venue := range venues {
+		if !venue.Inactive && !venue.barredFrom(course.DepartmentId, course.FacultyId) {
+			out = append(out, venueIdx)
+		}
+	}
+	return out
+}
+
+// the rank of every venue for the course, see VenueRankOwnDepartment
+func courseVenueRanks(course modifiedCourseAndVenueData, venueIdxs []int, venues []VenueInfo) []int {
+	ranks := make([]int, len(venueIdxs))
+	for i, venueIdx := range venueIdxs {
+		if venueIdx < len(venues) {
+			ranks[i] = venues[venueIdx].rankFor(course.DepartmentId, course.FacultyId)
+		}
+	}
+	return ranks
+}
+
+// sets the features every component of a course needs, the ones set for the whole course and
+// the ones set for its session type
+func AttachRequiredFeatures(courseData []modifiedCourseAndVenueData, featureRows []sqlc.RetrieveCourseRequiredFeaturesForUniRow) []modifiedCourseAndVenueData {
+	byCourse := make(map[uuid.UUID][]sqlc.RetrieveCourseRequiredFeaturesForUniRow)
+	for _, row := range featureRows {
+		byCourse[row.CourseID] = append(byCourse[row.CourseID], row)
+	}
+	for i, course := range courseData {
+		rows, ok := byCourse[course.CourseId]
+		if !ok {
+			continue
+		}
+		for j, component := range course.Components {
+			seen := make(map[string]bool)
+			features := make([]string, 0)
+			for _, row := range rows {
+				if row.SessionType.Valid && row.SessionType.String != component.SessionType {
+					continue
+				}
+				if !seen[row.Feature] {
+					seen[row.Feature] = true
+					features = append(features, row.Feature)
+				}
+			}
+			sort.Strings(features)
+			courseData[i].Components[j].RequiredFeatures = features
+		}
+	}
+	return courseData
+}
+
+// the venues of the course the component can use, the ones of the kind it needs with every
+// feature it needs
+func componentVenues(venueIdxs []int, component CourseComponent, venues []VenueInfo) []int {
+	if component.VenueKind == "" && len(component.RequiredFeatures) == 0 {
+		return venueIdxs
+	}
+	out := make([]int, 0, len(venueIdxs))
+	for _, venueIdx := range venueIdxs {
+		if venueIdx >= len(venues) {
+			continue
+		}
+		venue := venues[venueIdx]
+		if component.VenueKind != "" && venue.Kind != component.VenueKind {
+			continue
+		}
+		hasAll := true
+		for _, feature := range component.RequiredFeatures {
+			if !venue.Features[feature] {
+				hasAll = false
+				break
+			}
+		}
+		if hasAll {
+			out = append(out, venueIdx)
+		}
+	}
+	return out
+}
+
+// what the component needs of a venue, for errors e.g "a LAB venue with COMPUTER_LAB, PROJECTOR"
+func componentNeeds(component CourseComponent) string {
+	need := "a venue"
+	if component.VenueKind != "" {
+		need = "a " + component.VenueKind + " venue"
+	}
+	if len(component.RequiredFeatures) > 0 {
+		need += " with " + strings.Join(component.RequiredFeatures, ", ")
+	}
+	return need
+}
+
+// the rank of the venue for the session, 0 when the session has no ranks
+func venueRank(session *SessionAtom, venueIdx int) int {
+	for i, allowed := range session.AllowedVenuesIdx {
+		if allowed == venueIdx && i < len(session.VenueRanks) {
+			return session.VenueRanks[i]
+		}
+	}
+	return 0
+}
+
+// the best rank any venue of the session has
+func bestVenueRank(session *SessionAtom) int {
+	if len(session.VenueRanks) == 0 {
+		return 0
+	}
+	best := session.VenueRanks[0]
+	for _, rank := range session.VenueRanks {
+		best = min(best, rank)
+	}
+	return best
+}
+
+func venueRankName(rank int) string {
+	switch rank {
+	case VenueRankOwnFaculty:
+		return "a venue of its faculty"
+	case VenueRankShared:
+		return "a shared venue"
+	case VenueRankOtherOwner:
+		return "a venue of another department"
+	}
+	return "a venue of its department"
+}
+
+// a course should be placed in its department's venues first, then its faculty's, then shared
+// ones and only then in the venues of others
+type venueOwnershipConstraint struct {
+	weight float64
+}
+
+func (c venueOwnershipConstraint) Name() string    { return VenueOwnership }
+func (c venueOwnershipConstraint) Weight() float64 { return c.weight }
+
+// every session counts how many ranks its venue is behind the best venue it could have had, so a
+// course with no venues of its own is not punished for using shared ones
+func (c venueOwnershipConstraint) Evaluate(pre *PreComputed, cand *Candidate, buf *SearchBuffers) float64 {
+	var found breaches
+	c.visit(pre, cand, buf, &found)
+	return float64(found.total)
+}
+
+func (c venueOwnershipConstraint) Violations(pre *PreComputed, cand *Candidate) []Violation {
+	return collectBreaches(c, c.visit, pre, cand, func(b breach) Violation {
+		session := &pre.SessionAtoms[b.idx]
+		return Violation{
+			SessionIdxs: []int{b.idx},
+			CourseIdxs:  []int{session.CourseIdx},
+			VenueIdxs:   b.venueIdxs(),
+			Message:     fmt.Sprintf("%s is in %s, %s", courseCode(pre, session.CourseIdx), venueName(pre, b.venues[0]), venueRankName(b.count)),
+		}
+	})
+}
+
+func (c venueOwnershipConstraint) visit(pre *PreComputed, cand *Candidate, buf *SearchBuffers, found *breaches) {
+	for _, placement := range cand.Placements {
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		rank := venueRank(session, placement.VenueIdx)
+		if behind := rank - bestVenueRank(session); behind > 0 {
+			found.report(breach{slotIdx: placement.SlotIdx, slots: session.SessionDuration, amount: behind, count: rank, idx: placement.SessionIdx, venues: [2]int{placement.VenueIdx}, nVenues: 1})
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package computed
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// the hard rules a candidate can break
+const (
+	LecturerDoubleBooked   = "LECTURER_DOUBLE_BOOKED"
+	VenueDoubleBooked      = "VENUE_DOUBLE_BOOKED"
+	CohortClash            = "COHORT_CLASH"
+	LecturerUnavailability = "LECTURER_UNAVAILABLE"
+	VenueUnavailability    = "VENUE_UNAVAILABLE"
+	OutsideTeachingHours   = "OUTSIDE_TEACHING_HOURS"
+	VenueTooSmall          = "VENUE_TOO_SMALL"
+	NotPlaced              = "NOT_PLACED" // a session a saved timetable has no usable placement for
+)
+
+// one place a candidate breaks a hard rule or a soft constraint
+type Violation struct {
+	Type         string // one of the hard rules above or the name of a soft constraint
+	Hard         bool
+	Amount       float64 // how badly a soft constraint is broken, see Constraint.Evaluate
+	Penalty      float64 // weight * Amount, 0 for hard violations
+	SlotIdx      int     // first slot the violation covers
+	Slots        int
+	SessionIdxs  []int // into pre.SessionAtoms
+	CourseIdxs   []int
+	LecturerIdxs []int
+	VenueIdxs    []int
+	CohortIdxs   []int
+	Message      string
+}
+
+// a violation with names and times instead of idxs
+type ViolationDetail struct {
+	Type      string
+	Hard      bool
+	Penalty   float64
+	Day       string
+	StartTime string
+	EndTime   string
+	Courses   []string
+	Lecturers []string
+	Venues    []string
+	Cohorts   []string
+	Message   string
+}
+
+func appendUnique(list []int, values ...int) []int {
+	for _, v := range values {
+		found := false
+		for _, x := range list {
+			if x == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func sharedIdxs(a []int, b []int) []int {
+	shared := make([]int, 0)
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				shared = appendUnique(shared, x)
+			}
+		}
+	}
+	return shared
+}
+
+// a violation about the sessions of the given placements, fills every idx list from them
+func placementsViolation(pre *PreComputed, kind string, slotIdx int, slots int, placements ...SessionPlacement) Violation {
+	v := Violation{Type: kind, Hard: true, SlotIdx: slotIdx, Slots: slots}
+	for _, placement := range placements {
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		v.SessionIdxs = appendUnique(v.SessionIdxs, placement.SessionIdx)
+		v.CourseIdxs = appendUnique(v.CourseIdxs, session.CourseIdx)
+		v.VenueIdxs = appendUnique(v.VenueIdxs, placement.VenueIdx)
+		v.LecturerIdxs = appendUnique(v.LecturerIdxs, session.LecturerIdxs...)
+		v.CohortIdxs = appendUnique(v.CohortIdxs, session.CohortIdxs...)
+	}
+	return v
+}
+
+func namesOf(idxs []int, name func(idx int) string) []string {
+	names := make([]string, 0, len(idxs))
+	for _, idx := range idxs {
+		names = append(names, name(idx))
+	}
+	return names
+}
+
+// every hard rule the candidate breaks: double booked lecturers, venues and cohorts, sessions in an
+// unavailability window, outside the teaching hours or in a venue that is too small
+func FindHardViolations(pre *PreComputed, cand *Candidate) []Violation {
+	violations := make([]Violation, 0)
+	placements := make([]SessionPlacement, 0, len(cand.Placements))
+	for _, placement := range cand.Placements {
+		if placement.SessionIdx >= 0 && placement.SessionIdx < len(pre.SessionAtoms) {
+			placements = append(placements, placement)
+		}
+	}
+
+	for _, placement := range placements {
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		start, end := placement.SlotIdx, placement.SlotIdx+session.SessionDuration
+		label := courseCode(pre, session.CourseIdx)
+
+		if pre.SlotsPerDay > 0 && (start%pre.SlotsPerDay+session.SessionDuration > pre.SlotsPerDay || isBlocked(pre, start, session.SessionDuration)) {
+			v := placementsViolation(pre, OutsideTeachingHours, start, session.SessionDuration, placement)
+			v.Message = fmt.Sprintf("%s runs outside the teaching hours of its day", label)
+			violations = append(violations, v)
+		}
+		if placement.VenueIdx >= 0 && placement.VenueIdx < len(pre.VenueCapacities) && pre.VenueCapacities[placement.VenueIdx] < session.Headcount {
+			v := placementsViolation(pre, VenueTooSmall, start, session.SessionDuration, placement)
+			v.Message = fmt.Sprintf("%s has %d students but %s only holds %d", label, session.Headcount, venueName(pre, placement.VenueIdx), pre.VenueCapacities[placement.VenueIdx])
+			violations = append(violations, v)
+		}
+		if placement.VenueIdx >= 0 && placement.VenueIdx < len(pre.VenueUnavailable) {
+			for si := start; si < end && si < pre.TotalSlots; si++ {
+				if pre.VenueUnavailable[placement.VenueIdx][si] {
+					v := placementsViolation(pre, VenueUnavailability, start, session.SessionDuration, placement)
+					v.Message = fmt.Sprintf("%s is in %s while it is unavailable", label, venueName(pre, placement.VenueIdx))
+					violations = append(violations, v)
+					break
+				}
+			}
+		}
+		for _, lecturerIdx := range session.LecturerIdxs {
+			if lecturerIdx < 0 || lecturerIdx >= len(pre.LecturerUnavailable) {
+				continue
+			}
+			for si := start; si < end && si < pre.TotalSlots; si++ {
+				if pre.LecturerUnavailable[lecturerIdx][si] {
+					v := placementsViolation(pre, LecturerUnavailability, start, session.SessionDuration, placement)
+					v.Message = fmt.Sprintf("%s is taught by %s while they are unavailable", label, lecturerName(pre, lecturerIdx))
+					violations = append(violations, v)
+					break
+				}
+			}
+		}
+	}
+
+	// every pair of sessions that overlap and share a venue, lecturer or cohort
+	for i := 0; i < len(placements); i++ {
+		a := placements[i]
+		sa := &pre.SessionAtoms[a.SessionIdx]
+		for j := i + 1; j < len(placements); j++ {
+			b := placements[j]
+			sb := &pre.SessionAtoms[b.SessionIdx]
+			start := max(a.SlotIdx, b.SlotIdx)
+			end := min(a.SlotIdx+sa.SessionDuration, b.SlotIdx+sb.SessionDuration)
+			if start >= end {
+				continue
+			}
+			pair := fmt.Sprintf("%s and %s", courseCode(pre, sa.CourseIdx), courseCode(pre, sb.CourseIdx))
+
+			if a.VenueIdx == b.VenueIdx {
+				v := placementsViolation(pre, VenueDoubleBooked, start, end-start, a, b)
+				v.Message = fmt.Sprintf("%s are both in %s", pair, venueName(pre, a.VenueIdx))
+				violations = append(violations, v)
+			}
+			if shared := sharedIdxs(sa.LecturerIdxs, sb.LecturerIdxs); len(shared) > 0 {
+				v := placementsViolation(pre, LecturerDoubleBooked, start, end-start, a, b)
+				v.Message = fmt.Sprintf("%s teaches %s at the same time", strings.Join(namesOf(shared, func(idx int) string { return lecturerName(pre, idx) }), ", "), pair)
+				violations = append(violations, v)
+			}
+			if cohortsClash(sa, sb) {
+				v := placementsViolation(pre, CohortClash, start, end-start, a, b)
+				if shared := sharedIdxs(sa.CohortIdxs, sb.CohortIdxs); len(shared) > 0 {
+					v.Message = fmt.Sprintf("%s has %s at the same time", strings.Join(namesOf(shared, func(idx int) string { return cohortName(pre, idx) }), ", "), pair)
+				} else {
+					v.Message = fmt.Sprintf("%s share students and are at the same time", pair)
+				}
+				violations = append(violations, v)
+			}
+		}
+	}
+
+	// the constraints a university made hard
+	for _, constraint := range pre.Constraints {
+		if !isHard(constraint) {
+			continue
+		}
+		for _, v := range constraint.Violations(pre, cand) {
+			v = attachSessions(pre, cand, v)
+			v.Hard = true
+			v.Penalty = 0
+			violations = append(violations, v)
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].SlotIdx < violations[j].SlotIdx
+	})
+	return violations
+}
+
+// every soft constraint the candidate breaks, with the sessions that take part in each
+func FindSoftViolations(pre *PreComputed, cand *Candidate) []Violation {
+	violations := make([]Violation, 0)
+	for _, constraint := range pre.Constraints {
+		if isHard(constraint) {
+			continue
+		}
+		for _, v := range constraint.Violations(pre, cand) {
+			violations = append(violations, attachSessions(pre, cand, v))
+		}
+	}
+	return violations
+}
+
+// adds the sessions in the window of a soft violation that belong to its cohort, lecturer or
+// course, and fills the idx lists the constraint left empty from them
+func attachSessions(pre *PreComputed, cand *Candidate, v Violation) Violation {
+	courses, lecturers, venues, cohorts := []int{}, []int{}, []int{}, []int{}
+	for _, placement := range cand.Placements {
+		if placement.SessionIdx < 0 || placement.SessionIdx >= len(pre.SessionAtoms) {
+			continue
+		}
+		session := &pre.SessionAtoms[placement.SessionIdx]
+		if placement.SlotIdx >= v.SlotIdx+v.Slots || placement.SlotIdx+session.SessionDuration <= v.SlotIdx {
+			continue
+		}
+		if !sharesAny(v.CohortIdxs, session.CohortIdxs) && !sharesAny(v.LecturerIdxs, session.LecturerIdxs) && !sharesAny(v.CourseIdxs, []int{session.CourseIdx}) {
+			continue
+		}
+		v.SessionIdxs = appendUnique(v.SessionIdxs, placement.SessionIdx)
+		courses = appendUnique(courses, session.CourseIdx)
+		lecturers = appendUnique(lecturers, session.LecturerIdxs...)
+		venues = appendUnique(venues, placement.VenueIdx)
+		cohorts = appendUnique(cohorts, session.CohortIdxs...)
+	}
+	if len(v.CourseIdxs) == 0 {
+		v.CourseIdxs = courses
+	}
+	if len(v.LecturerIdxs) == 0 {
+		v.LecturerIdxs = lecturers
+	}
+	if len(v.VenueIdxs) == 0 {
+		v.VenueIdxs = venues
+	}
+	if len(v.CohortIdxs) == 0 {
+		v.CohortIdxs = cohorts
+	}
+	return v
+}
+
+// sets the conflict flag of every placement that takes part in a hard violation and clears it on
+// the rest, returns how many placements are in conflict
+func MarkConflicts(pre *PreComputed, cand *Candidate) int {
+	inConflict := make(map[int]bool)
+	for _, v := range FindHardViolations(pre, cand) {
+		for _, sessionIdx := range v.SessionIdxs {
+			inConflict[sessionIdx] = true
+		}
+	}
+	for i := range cand.Placements {
+		cand.Placements[i].Conflict = inConflict[cand.Placements[i].SessionIdx]
+	}
+	return len(inConflict)
+}
+
+// names the courses, lecturers, venues and cohorts of the violations and turns their slots into times
+func DescribeViolations(pre *PreComputed, week TeachingWeek, violations []Violation) []ViolationDetail {
+	dayNames := week.DayNames()
+	slotsPerDay := week.SlotsPerDay()
+	details := make([]ViolationDetail, 0, len(violations))
+	for _, v := range violations {
+		detail := ViolationDetail{
+			Type:      v.Type,
+			Hard:      v.Hard,
+			Penalty:   v.Penalty,
+			Courses:   namesOf(v.CourseIdxs, func(idx int) string { return courseCode(pre, idx) }),
+			Lecturers: namesOf(v.LecturerIdxs, func(idx int) string { return lecturerName(pre, idx) }),
+			Venues:    namesOf(v.VenueIdxs, func(idx int) string { return venueName(pre, idx) }),
+			Cohorts:   namesOf(v.CohortIdxs, func(idx int) string { return cohortName(pre, idx) }),
+			Message:   v.Message,
+		}
+		if slotsPerDay > 0 && v.SlotIdx >= 0 {
+			day := v.SlotIdx / slotsPerDay
+			if day < len(dayNames) {
+				detail.Day = dayNames[day]
+			}
+			slotInDay := v.SlotIdx % slotsPerDay
+			detail.StartTime = FormatMinutes(week.SlotStartMinutes(slotInDay))
+			detail.EndTime = FormatMinutes(week.SlotStartMinutes(slotInDay) + v.Slots*week.SlotMinutes)
+		}
+		details = append(details, detail)
+	}
+	return details
+}
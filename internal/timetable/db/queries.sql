@@ -95,7 +95,12 @@ SELECT
     venue_id,
     venue_name,
     capacity,
-    location
+    location,
+    venue_latitude,
+    venue_longitude,
+    venue_kind,
+    is_active,
+    is_restricted
 FROM venues
 WHERE university_id = $1;
 
@@ -114,51 +119,242 @@ SELECT
 FROM courses
 WHERE university_id = $1;
 
+-- the courses a timetable of the semester schedules, every course when the semester is empty
+-- name: RetrieveCoursesForSemester :many
+SELECT
+    course_id,
+    course_code,
+    course_title,
+    course_credit_unit,
+    course_duration,
+    department_id,
+    university_id,
+    lecturer_id,
+    sessions_per_week,
+    semester
+FROM courses
+WHERE university_id = $1 AND (semester = $2 OR $2 = '');
+
 
 -- name: RetrieveCohortsForAllCourses :many
 SELECT 
-    cohort_id,
-    course_id,
-    university_id
-FROM cohort_courses_offered
-WHERE university_id = $1;
-
+    cco.cohort_id,
+    cco.course_id,
+    cco.university_id
+FROM cohort_courses_offered cco
+INNER JOIN courses c
+ON c.course_id = cco.course_id
+WHERE cco.university_id = $1 AND (c.semester = $2 OR $2 = '');
+
+-- name: RetrieveCourseLecturersForUni :many
+SELECT 
+    cl.course_id,
+    cl.lecturer_id
+FROM courses_lecturers cl
+INNER JOIN courses c
+ON c.course_id = cl.course_id
+WHERE c.university_id = $1 AND (c.semester = $2 OR $2 = '');
+
+-- name: RetrieveCohortStudentCounts :many
+SELECT 
+    c.cohort_id,
+    COUNT(s.student_id)::int AS student_count
+FROM cohorts c
+LEFT JOIN students s
+ON s.student_department_id = c.cohort_department_id
+    AND s.student_level = c.cohort_level
+WHERE c.cohort_university_id = $1
+GROUP BY c.cohort_id;
+
+-- the students enrolled in every course, the same students GetStudentTimetableSessions deals out
+-- to the groups of a split course
+-- name: RetrieveCourseStudentCounts :many
+SELECT
+    sco.course_id,
+    COUNT(st.student_id)::int AS student_count
+FROM student_courses_offered sco
+JOIN students st
+    ON st.student_id = sco.student_id
+JOIN courses c
+    ON c.course_id = sco.course_id
+WHERE c.university_id = $1
+GROUP BY sco.course_id;
 
 -- name: CreateCandidate :one
 INSERT INTO candidates(
-    fitness,university_id,candidate_status,start_of_day,end_of_day
-)VALUES($1,$2,$3,$4,$5)
+    fitness,university_id,candidate_status,start_of_day,end_of_day,
+    seed,population_size,generations,mutation_rate,tournament_size,elitism_fraction,
+    repaired_from,solver,workers,generations_run,stop_reason,academic_session,semester
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18)
 RETURNING *;
 
 
 -- name: CreateSessionPlacements :exec
 INSERT INTO session_placements(
-    candidate_id,session_idx,course_id,venue_id,day,session_time,university_id
-)VALUES($1,$2,$3,$4,$5,$6,$7);
+    candidate_id,session_idx,course_id,venue_id,day,session_time,university_id,conflict,session_type,group_idx,group_count
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11);
+
+-- a new draft replaces the drafts of its term, one under review stays until it is published
+-- name: ArchiveDraftCandidates :exec
+UPDATE candidates
+SET candidate_status = 'ARCHIVED',
+    updated_at = NOW()
+WHERE university_id = $1 AND candidate_status = 'DRAFT'
+    AND academic_session IS NOT DISTINCT FROM $2
+    AND semester IS NOT DISTINCT FROM $3;
 
--- name: DeprecateLatestCandidate :exec
-UPDATE candidates AS c
-SET candidate_status = 'DEPRECATED',
+-- name: ArchivePublishedCandidate :exec
+UPDATE candidates
+SET candidate_status = 'ARCHIVED',
     updated_at = NOW()
-WHERE c.id = (
-  SELECT id
-  FROM candidates
-  WHERE candidates.university_id = $1
-  ORDER BY created_at DESC
-  LIMIT 1
-);
-
--- name: RestoreCurrentCandidate :exec
-UPDATE candidates AS c
-SET candidate_status = 'CURRENT',
+WHERE university_id = $1 AND candidate_status = 'PUBLISHED'
+    AND academic_session IS NOT DISTINCT FROM $2
+    AND semester IS NOT DISTINCT FROM $3;
+
+-- name: ListCandidates :many
+SELECT * FROM candidates
+WHERE university_id = $1
+ORDER BY created_at DESC;
+
+-- name: PublishCandidate :execrows
+UPDATE candidates
+SET candidate_status = 'PUBLISHED',
+    published_at = NOW(),
+    published_by = $3,
     updated_at = NOW()
-WHERE c.id = (
-  SELECT id
-  FROM candidates
-  WHERE candidates.university_id = $1
-  ORDER BY created_at DESC
-  LIMIT 1
-);
+WHERE id = $1 AND university_id = $2;
+
+-- name: UpdateCandidateStatus :exec
+UPDATE candidates
+SET candidate_status = $3,
+    updated_at = NOW()
+WHERE id = $1 AND university_id = $2;
+
+-- name: GetCandidateSessionsForFaculty :many
+SELECT 
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    co.course_title,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.conflict,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN courses co ON co.course_id = sp.course_id
+JOIN departments d ON d.department_id = co.department_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1 AND d.faculty_id = $2
+ORDER BY sp.session_idx;
+
+-- name: GetCandidateSessionsForDepartment :many
+SELECT 
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    co.course_title,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.conflict,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN courses co ON co.course_id = sp.course_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1 AND co.department_id = $2
+ORDER BY sp.session_idx;
+
+-- name: GetCourseDepartments :many
+SELECT
+    co.course_id,
+    co.department_id,
+    d.faculty_id
+FROM courses co
+JOIN departments d ON d.department_id = co.department_id
+WHERE co.university_id = $1;
+
+-- name: UpsertCandidateReview :one
+INSERT INTO candidate_reviews(
+    candidate_id,university_id,reviewer_id,reviewer_role,faculty_id,department_id,decision,comment
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8)
+ON CONFLICT (candidate_id,reviewer_id,reviewer_role)
+DO UPDATE SET
+    faculty_id = EXCLUDED.faculty_id,
+    department_id = EXCLUDED.department_id,
+    decision = EXCLUDED.decision,
+    comment = EXCLUDED.comment,
+    updated_at = NOW()
+RETURNING review_id;
+
+-- name: GetCandidateReviews :many
+SELECT 
+    r.review_id,
+    r.candidate_id,
+    r.reviewer_id,
+    l.lecturer_first_name,
+    l.lecturer_last_name,
+    r.reviewer_role,
+    r.faculty_id,
+    f.faculty_name,
+    r.department_id,
+    d.department_name,
+    r.decision,
+    r.comment,
+    r.created_at,
+    r.updated_at
+FROM candidate_reviews r
+JOIN lecturers l ON l.lecturer_id = r.reviewer_id
+LEFT JOIN faculties f ON f.faculty_id = r.faculty_id
+LEFT JOIN departments d ON d.department_id = r.department_id
+WHERE r.candidate_id = $1
+ORDER BY r.updated_at DESC;
+
+-- name: GetCandidateSessions :many
+SELECT 
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    co.course_title,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.conflict,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN courses co ON co.course_id = sp.course_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1
+ORDER BY sp.session_idx;
+
+-- name: GetCandidateCohortSessions :many
+SELECT 
+    cco.cohort_id,
+    ch.cohort_name,
+    sp.session_idx,
+    sp.course_id,
+    co.course_code,
+    sp.venue_id,
+    v.venue_name,
+    sp.day,
+    sp.session_time,
+    sp.session_type
+FROM session_placements sp
+JOIN cohort_courses_offered cco ON cco.course_id = sp.course_id
+JOIN cohorts ch ON ch.cohort_id = cco.cohort_id
+JOIN courses co ON co.course_id = sp.course_id
+JOIN venues v ON v.venue_id = sp.venue_id
+WHERE sp.candidate_id = $1
+ORDER BY ch.cohort_name, co.course_code, sp.session_idx;
 
 
 -- name: GetCohortSessionsInCurrentTimetable :many
@@ -173,7 +369,10 @@ SELECT
     c.fitness,
     c.candidate_status,
     c.start_of_day,
-    c.end_of_day
+    c.end_of_day,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
 FROM session_placements sp
 JOIN candidates c 
     ON sp.candidate_id = c.id
@@ -183,11 +382,29 @@ WHERE
     cco.cohort_id = $1
     AND cco.university_id = $2
     AND c.university_id = $2
-    AND c.candidate_status = 'CURRENT';
+    AND c.id = $3
+    AND c.candidate_status = 'PUBLISHED';
 
 
 
 -- name: GetStudentTimetableSessions :many
+-- a course split into n groups puts the student in group position % n, where position is the
+-- student's place among the students of the course by reg no
+WITH course_positions AS (
+    SELECT
+        sco.student_id,
+        sco.course_id,
+        (ROW_NUMBER() OVER (
+            PARTITION BY sco.course_id
+            ORDER BY st.student_reg_no NULLS LAST, st.student_id
+        ) - 1)::int AS position
+    FROM student_courses_offered sco
+    JOIN students st
+        ON st.student_id = sco.student_id
+    WHERE sco.course_id IN (
+        SELECT course_id FROM student_courses_offered WHERE student_id = $1
+    )
+)
 SELECT 
     sp.id AS session_id,
     sp.session_idx,
@@ -199,17 +416,22 @@ SELECT
     c.fitness,
     c.candidate_status,
     c.start_of_day,
-    c.end_of_day
+    c.end_of_day,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
 FROM session_placements sp
 JOIN candidates c 
     ON sp.candidate_id = c.id
-JOIN student_courses_offered sco 
-    ON sp.course_id = sco.course_id
+JOIN course_positions cp 
+    ON sp.course_id = cp.course_id
 JOIN students s 
-    ON sco.student_id = s.student_id
+    ON cp.student_id = s.student_id
 WHERE s.student_id = $1
   AND c.university_id = s.university_id
-  AND c.candidate_status = 'CURRENT'
+  AND c.id = $2
+  AND c.candidate_status = 'PUBLISHED'
+  AND (sp.group_count <= 1 OR cp.position % sp.group_count = sp.group_idx)
 ORDER BY 
     CASE sp.day
         WHEN 'Monday' THEN 1
@@ -217,16 +439,332 @@ ORDER BY
         WHEN 'Wednesday' THEN 3
         WHEN 'Thursday' THEN 4
         WHEN 'Friday' THEN 5
+        WHEN 'Saturday' THEN 6
+        WHEN 'Sunday' THEN 7
     END,
     sp.session_time ASC;
 
 
 
+-- name: GetTimetableSettings :one
+SELECT * FROM timetable_settings
+WHERE university_id = $1;
+
+-- name: UpsertTimetableSettings :one
+INSERT INTO timetable_settings(
+    university_id,population_size,generations,mutation_rate,tournament_size,elitism_fraction,seed,solver,
+    stall_generations,time_budget_seconds,stop_when_feasible
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
+ON CONFLICT (university_id) DO UPDATE
+SET population_size = EXCLUDED.population_size,
+    generations = EXCLUDED.generations,
+    mutation_rate = EXCLUDED.mutation_rate,
+    tournament_size = EXCLUDED.tournament_size,
+    elitism_fraction = EXCLUDED.elitism_fraction,
+    seed = EXCLUDED.seed,
+    solver = EXCLUDED.solver,
+    stall_generations = EXCLUDED.stall_generations,
+    time_budget_seconds = EXCLUDED.time_budget_seconds,
+    stop_when_feasible = EXCLUDED.stop_when_feasible,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetTimetableConstraints :many
+SELECT * FROM timetable_constraints
+WHERE university_id = $1
+ORDER BY constraint_name;
+
+-- name: UpsertTimetableConstraint :one
+INSERT INTO timetable_constraints(
+    university_id,constraint_name,enabled,weight,limit_value,hard
+)VALUES($1,$2,$3,$4,$5,$6)
+ON CONFLICT (university_id,constraint_name) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    weight = EXCLUDED.weight,
+    limit_value = EXCLUDED.limit_value,
+    hard = EXCLUDED.hard,
+    updated_at = NOW()
+RETURNING *;
+
+
 -- -- name: UpdateOtherCandidateStatus :one
 -- UPDATE 
 
+-- name: SetTimetableSlotMinutes :exec
+INSERT INTO timetable_settings(
+    university_id,slot_minutes
+)VALUES($1,$2)
+ON CONFLICT (university_id) DO UPDATE
+SET slot_minutes = EXCLUDED.slot_minutes,
+    updated_at = NOW();
 
+-- name: GetTeachingDays :many
+SELECT 
+    university_id,
+    day,
+    start_time::text AS start_time,
+    end_time::text AS end_time
+FROM timetable_teaching_days
+WHERE university_id = $1;
+
+-- name: DeleteTeachingDays :exec
+DELETE FROM timetable_teaching_days
+WHERE university_id = $1;
 
+-- name: CreateTeachingDay :exec
+INSERT INTO timetable_teaching_days(
+    university_id,day,start_time,end_time
+)VALUES($1,$2,$3::text::time,$4::text::time);
+
+-- name: UpsertSessionPin :one
+INSERT INTO timetable_session_pins(
+    university_id,course_id,session_number,day,start_time,venue_id
+)VALUES($1,$2,$3,$4,$5::text::time,$6)
+ON CONFLICT (course_id,session_number) DO UPDATE
+SET day = EXCLUDED.day,
+    start_time = EXCLUDED.start_time,
+    venue_id = EXCLUDED.venue_id,
+    updated_at = NOW()
+RETURNING pin_id;
+
+-- name: GetSessionPins :many
+SELECT 
+    p.pin_id,
+    p.course_id,
+    c.course_code,
+    p.session_number,
+    p.day,
+    p.start_time::text AS start_time,
+    p.venue_id,
+    v.venue_name,
+    c.semester
+FROM timetable_session_pins p
+JOIN courses c ON c.course_id = p.course_id
+JOIN venues v ON v.venue_id = p.venue_id
+WHERE p.university_id = $1
+ORDER BY c.course_code, p.session_number;
+
+-- name: DeleteSessionPin :exec
+DELETE FROM timetable_session_pins
+WHERE pin_id = $1 AND university_id = $2;
+
+-- name: GetCandidateById :one
+SELECT * FROM candidates
+WHERE id = $1 AND university_id = $2;
+
+-- name: GetPublishedCandidate :one
+SELECT * FROM candidates
+WHERE university_id = $1 AND candidate_status = 'PUBLISHED'
+    AND academic_session IS NOT DISTINCT FROM $2
+    AND semester IS NOT DISTINCT FROM $3
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetUniversityCurrentSession :one
+SELECT current_session FROM universities
+WHERE university_id = $1;
+
+-- the university of a signed in admin or lecturer, deans and HODs sign in as lecturers
+-- name: GetUserUniversity :one
+SELECT university_id FROM university_admin
+WHERE admin_id = $1 AND university_id IS NOT NULL
+UNION ALL
+SELECT lecturer_university_id FROM lecturers
+WHERE lecturer_id = $1 AND lecturer_university_id IS NOT NULL
+LIMIT 1;
+
+-- the timetable published last in any term
+-- name: GetLatestPublishedCandidate :one
+SELECT * FROM candidates
+WHERE university_id = $1 AND candidate_status = 'PUBLISHED'
+ORDER BY published_at DESC NULLS LAST, created_at DESC
+LIMIT 1;
+
+-- name: GetCandidateSessionPlacements :many
+SELECT 
+    session_idx,
+    course_id,
+    venue_id,
+    day,
+    session_time,
+    conflict
+FROM session_placements
+WHERE candidate_id = $1
+ORDER BY session_idx;
+
+-- name: CreateMovedSession :exec
+INSERT INTO candidate_moved_sessions(
+    candidate_id,session_idx,course_id,from_venue_id,from_day,from_session_time,
+    to_venue_id,to_day,to_session_time,reason
+)VALUES($1,$2,$3,$4,$5,$6,$7,$8,$9,$10);
+
+-- name: GetMovedSessions :many
+SELECT 
+    m.session_idx,
+    m.course_id,
+    c.course_code,
+    m.from_venue_id,
+    fv.venue_name AS from_venue_name,
+    m.from_day,
+    m.from_session_time,
+    m.to_venue_id,
+    tv.venue_name AS to_venue_name,
+    m.to_day,
+    m.to_session_time,
+    m.reason
+FROM candidate_moved_sessions m
+JOIN candidates cd ON cd.id = m.candidate_id
+JOIN courses c ON c.course_id = m.course_id
+LEFT JOIN venues fv ON fv.venue_id = m.from_venue_id
+JOIN venues tv ON tv.venue_id = m.to_venue_id
+WHERE m.candidate_id = $1 AND cd.university_id = $2
+ORDER BY c.course_code, m.session_idx;
+
+-- name: CreateCandidateFitness :exec
+INSERT INTO candidate_fitness_history(
+    candidate_id,generation,best_fitness,mean_fitness
+)VALUES($1,$2,$3,$4);
+
+-- name: GetCandidateFitnessHistory :many
+SELECT generation,best_fitness,mean_fitness FROM candidate_fitness_history
+WHERE candidate_id = $1
+ORDER BY generation;
+
+-- name: GetVenueTravelTimes :many
+SELECT
+    t.from_venue_id,
+    fv.venue_name AS from_venue_name,
+    t.to_venue_id,
+    tv.venue_name AS to_venue_name,
+    t.minutes
+FROM venue_travel_times t
+JOIN venues fv ON fv.venue_id = t.from_venue_id
+JOIN venues tv ON tv.venue_id = t.to_venue_id
+WHERE t.university_id = $1
+ORDER BY fv.venue_name, tv.venue_name;
+
+-- name: UpsertVenueTravelTime :exec
+INSERT INTO venue_travel_times(
+    university_id,from_venue_id,to_venue_id,minutes
+)VALUES($1,$2,$3,$4)
+ON CONFLICT (from_venue_id,to_venue_id) DO UPDATE
+SET minutes = EXCLUDED.minutes,
+    updated_at = NOW();
+
+-- name: DeleteVenueTravelTime :exec
+DELETE FROM venue_travel_times
+WHERE university_id = $1 AND from_venue_id = $2 AND to_venue_id = $3;
+
+-- name: RetrieveElectiveGroupCourses :many
+SELECT
+    eg.elective_group_id,
+    eg.cohort_id,
+    egc.course_id
+FROM elective_groups eg
+JOIN elective_group_courses egc ON egc.elective_group_id = eg.elective_group_id
+JOIN courses c ON c.course_id = egc.course_id
+WHERE eg.university_id = $1 AND (c.semester = $2 OR $2 = '');
+
+-- name: RetrieveElectiveStudentOverlaps :many
+SELECT DISTINCT
+    a.course_id AS first_course_id,
+    b.course_id AS second_course_id
+FROM student_courses_offered a
+JOIN student_courses_offered b ON b.student_id = a.student_id AND a.course_id < b.course_id
+JOIN elective_group_courses ga ON ga.course_id = a.course_id
+JOIN elective_group_courses gb ON gb.course_id = b.course_id AND gb.elective_group_id = ga.elective_group_id
+JOIN elective_groups eg ON eg.elective_group_id = ga.elective_group_id
+WHERE eg.university_id = $1;
+
+-- name: GetVenueSessionsInCurrentTimetable :many
+SELECT
+    sp.id AS session_id,
+    sp.session_idx,
+    sp.course_id,
+    sp.venue_id,
+    sp.day,
+    sp.session_time,
+    sp.university_id,
+    c.fitness,
+    c.candidate_status,
+    c.start_of_day,
+    c.end_of_day,
+    sp.session_type,
+    sp.group_idx,
+    sp.group_count
+FROM session_placements sp
+JOIN candidates c
+    ON sp.candidate_id = c.id
+WHERE
+    sp.venue_id = $1
+    AND c.university_id = $2
+    AND c.id = $3
+    AND c.candidate_status = 'PUBLISHED';
 
+-- name: RetrieveCourseComponentsForUni :many
+SELECT
+    cc.course_id,
+    cc.session_type,
+    cc.duration,
+    cc.sessions_per_week,
+    cc.venue_kind,
+    cc.max_group_size
+FROM course_components cc
+JOIN courses c ON c.course_id = cc.course_id
+WHERE cc.university_id = $1 AND (c.semester = $2 OR $2 = '');
+
+-- name: RetrieveVenueFeaturesForUni :many
+SELECT
+    venue_id,
+    feature
+FROM venue_features
+WHERE university_id = $1;
 
+-- name: RetrieveVenueOwnersForUni :many
+SELECT
+    venue_id,
+    faculty_id,
+    NULL::uuid AS department_id
+FROM faculty_venues
+WHERE faculty_venues.university_id = $1
+UNION ALL
+SELECT
+    venue_id,
+    NULL::uuid AS faculty_id,
+    department_id
+FROM dept_venues
+WHERE dept_venues.university_id = $1;
 
+-- name: RetrieveCourseRequiredFeaturesForUni :many
+SELECT
+    crf.course_id,
+    crf.session_type,
+    crf.feature
+FROM course_required_features crf
+JOIN courses c ON c.course_id = crf.course_id
+WHERE crf.university_id = $1 AND (c.semester = $2 OR $2 = '');
+
+-- name: GetDepartmentVenueUsage :many
+SELECT
+    d.department_id,
+    d.department_name,
+    COUNT(*)::int AS sessions,
+    COUNT(*) FILTER (WHERE EXISTS (
+        SELECT 1 FROM dept_venues dv WHERE dv.venue_id = sp.venue_id AND dv.department_id = d.department_id
+    ))::int AS own_department,
+    COUNT(*) FILTER (WHERE NOT EXISTS (
+        SELECT 1 FROM dept_venues dv WHERE dv.venue_id = sp.venue_id AND dv.department_id = d.department_id
+    ) AND EXISTS (
+        SELECT 1 FROM faculty_venues fv WHERE fv.venue_id = sp.venue_id AND fv.faculty_id = d.faculty_id
+    ))::int AS own_faculty,
+    COUNT(*) FILTER (WHERE NOT EXISTS (
+        SELECT 1 FROM dept_venues dv WHERE dv.venue_id = sp.venue_id
+    ) AND NOT EXISTS (
+        SELECT 1 FROM faculty_venues fv WHERE fv.venue_id = sp.venue_id
+    ))::int AS shared
+FROM session_placements sp
+JOIN courses c ON c.course_id = sp.course_id
+JOIN departments d ON d.department_id = c.department_id
+WHERE sp.candidate_id = $1
+GROUP BY d.department_id, d.department_name
+ORDER BY d.department_name;
@@ -2,13 +2,32 @@ CREATE TABLE candidates(
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     fitness DOUBLE PRECISION NOT NULL,
     university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
-    candidate_status TEXT NOT NULL CHECK(candidate_status IN ('CURRENT','DEPRECATED')),
+    candidate_status TEXT NOT NULL CHECK(candidate_status IN ('DRAFT','UNDER_REVIEW','PUBLISHED','ARCHIVED')),
     start_of_day TIMESTAMPTZ NOT NULL,
     end_of_day TIMESTAMPTZ NOT NULL,
     created_at TIMESTAMPTZ DEFAULT NOW(),
-    updated_at TIMESTAMPTZ DEFAULT NOW()
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    seed BIGINT,
+    population_size INT,
+    generations INT,
+    mutation_rate DOUBLE PRECISION,
+    tournament_size INT,
+    elitism_fraction DOUBLE PRECISION,
+    repaired_from UUID REFERENCES candidates(id) ON DELETE SET NULL,
+    published_at TIMESTAMPTZ,
+    published_by UUID REFERENCES university_admin(admin_id) ON DELETE SET NULL,
+    solver TEXT,
+    workers INT,
+    generations_run INT,
+    stop_reason TEXT,
+    academic_session TEXT,
+    semester TEXT CHECK (semester IN ('First','Second'))
 );
 
+CREATE UNIQUE INDEX unique_published_candidate_per_term
+ON candidates(university_id,academic_session,semester)
+WHERE candidate_status = 'PUBLISHED';
+
 
 
 CREATE TABLE session_placements(
@@ -17,9 +36,132 @@ CREATE TABLE session_placements(
     session_idx INT NOT NULL,
     course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
     venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
-    day TEXT NOT NULL CHECK (day IN('Monday','Tuesday','Wednesday','Thursday','Friday')),
+    day TEXT NOT NULL CHECK (day IN('Monday','Tuesday','Wednesday','Thursday','Friday','Saturday','Sunday')),
     session_time TIMESTAMPTZ NOT NULL,
     university_id UUID NOT NULL REFERENCES  universities(university_id) ON DELETE CASCADE,
     created_at TIMESTAMPTZ DEFAULT NOW(),
-    updated_at TIMESTAMPTZ DEFAULT NOW()
-);
\ No newline at end of file
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    conflict BOOLEAN NOT NULL DEFAULT FALSE,
+    session_type TEXT NOT NULL DEFAULT 'LECTURE',
+    group_idx INT NOT NULL DEFAULT 0, -- the group of the course's students the session is for
+    group_count INT NOT NULL DEFAULT 1 -- 1 when the whole class attends
+);
+
+
+-- the sessions a repair moved, from_* is null for a session that had no placement yet
+CREATE TABLE candidate_moved_sessions(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    session_idx INT NOT NULL,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    from_venue_id UUID REFERENCES venues(venue_id) ON DELETE SET NULL,
+    from_day TEXT,
+    from_session_time TIMESTAMPTZ,
+    to_venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    to_day TEXT NOT NULL,
+    to_session_time TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+
+-- the best and mean fitness of the population after every generation of a run, generation 0 is
+-- the initial population
+CREATE TABLE candidate_fitness_history(
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    generation INT NOT NULL,
+    best_fitness DOUBLE PRECISION NOT NULL,
+    mean_fitness DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY(candidate_id,generation)
+);
+
+
+-- deans review a candidate for their faculty and HODs for their department
+CREATE TABLE candidate_reviews(
+    review_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    reviewer_id UUID NOT NULL REFERENCES lecturers(lecturer_id) ON DELETE CASCADE,
+    reviewer_role TEXT NOT NULL CHECK (reviewer_role IN ('DEAN','HOD')),
+    faculty_id UUID REFERENCES faculties(faculty_id) ON DELETE CASCADE,
+    department_id UUID REFERENCES departments(department_id) ON DELETE CASCADE,
+    decision TEXT NOT NULL CHECK (decision IN ('APPROVED','CHANGES_REQUESTED')),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(candidate_id,reviewer_id,reviewer_role)
+);
+
+
+CREATE TABLE timetable_settings(
+    university_id UUID PRIMARY KEY REFERENCES universities(university_id) ON DELETE CASCADE,
+    population_size INT NOT NULL DEFAULT 100,
+    generations INT NOT NULL DEFAULT 100,
+    mutation_rate DOUBLE PRECISION NOT NULL DEFAULT 0.05,
+    tournament_size INT NOT NULL DEFAULT 10,
+    elitism_fraction DOUBLE PRECISION NOT NULL DEFAULT 0.1,
+    seed BIGINT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    slot_minutes INT NOT NULL DEFAULT 60 CHECK (slot_minutes IN (30,60,90)),
+    solver TEXT NOT NULL DEFAULT 'GENETIC' CHECK (solver IN ('GENETIC','ANNEALING','GENETIC_TABU')),
+    stall_generations INT NOT NULL DEFAULT 0 CHECK (stall_generations >= 0),
+    time_budget_seconds INT NOT NULL DEFAULT 0 CHECK (time_budget_seconds >= 0),
+    stop_when_feasible BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+
+-- the days a university teaches on, a university with none teaches monday to friday
+CREATE TABLE timetable_teaching_days(
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    day TEXT NOT NULL CHECK (day IN ('Monday','Tuesday','Wednesday','Thursday','Friday','Saturday','Sunday')),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (university_id, day),
+    CHECK (start_time < end_time)
+);
+
+
+-- a course session fixed to a day, time and venue that the solver never moves
+CREATE TABLE timetable_session_pins(
+    pin_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    session_number INT NOT NULL CHECK (session_number >= 1),
+    day TEXT NOT NULL CHECK (day IN ('Monday','Tuesday','Wednesday','Thursday','Friday','Saturday','Sunday')),
+    start_time TIME NOT NULL,
+    venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (course_id, session_number)
+);
+
+
+CREATE TABLE timetable_constraints(
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    constraint_name TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    weight DOUBLE PRECISION NOT NULL CHECK (weight >= 0),
+    limit_value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    -- a hard constraint is never traded for a better score, its breaches count like clashes
+    hard BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (university_id, constraint_name)
+);
+
+
+-- minutes it takes to walk between two venues where the distance between their coordinates is
+-- wrong e.g a river in the way, it takes as long both ways so a pair is stored once
+CREATE TABLE venue_travel_times(
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    from_venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    to_venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    minutes INT NOT NULL CHECK (minutes >= 0),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (from_venue_id, to_venue_id),
+    CHECK (from_venue_id < to_venue_id)
+);
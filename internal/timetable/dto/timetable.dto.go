@@ -11,10 +11,427 @@ import (
 
 
 type CreateATimeTableDto struct{
-	StartTime time.Time `json:"startTime" validate:"required"`
-	EndTime time.Time `json:"endTime" validate:"required"`
+	// the hours of a university without a configured teaching week, otherwise only their date is used
+	StartTime time.Time `json:"startTime" validate:"omitempty"`
+	EndTime time.Time `json:"endTime" validate:"omitempty"`
 	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	// only the courses of the semester are scheduled, the session defaults to the current session of the university
+	AcademicSession string `json:"academicSession" validate:"omitempty"`
+	Semester string `json:"semester" validate:"required,oneof=First Second"`
+	Params *GAParamsDto `json:"params" validate:"omitempty"`
+	// falls back to the solver in the university settings
+	Solver string `json:"solver" validate:"omitempty,oneof=GENETIC ANNEALING GENETIC_TABU"`
+}
+
+// every field is optional, missing ones fall back to the university settings
+type GAParamsDto struct{
+	PopulationSize *int32 `json:"populationSize" validate:"omitempty,min=2"`
+	Generations *int32 `json:"generations" validate:"omitempty,min=1"`
+	MutationRate *float64 `json:"mutationRate" validate:"omitempty,min=0,max=1"`
+	TournamentSize *int32 `json:"tournamentSize" validate:"omitempty,min=1"`
+	ElitismFraction *float64 `json:"elitismFraction" validate:"omitempty,min=0,lt=1"`
+	Seed *int64 `json:"seed" validate:"omitempty"`
+	// defaults to one per core, a seed only replays with the same number of workers
+	Workers *int32 `json:"workers" validate:"omitempty,min=1"`
+	// 0 and false never stop a run early
+	StallGenerations *int32 `json:"stallGenerations" validate:"omitempty,min=0"`
+	TimeBudgetSeconds *int32 `json:"timeBudgetSeconds" validate:"omitempty,min=0"`
+	StopWhenFeasible *bool `json:"stopWhenFeasible"`
+}
+
+type TimetableSettingsDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	PopulationSize int32 `json:"populationSize" validate:"required,min=2"`
+	Generations int32 `json:"generations" validate:"required,min=1"`
+	MutationRate float64 `json:"mutationRate" validate:"min=0,max=1"`
+	TournamentSize int32 `json:"tournamentSize" validate:"required,min=1"`
+	ElitismFraction float64 `json:"elitismFraction" validate:"min=0,lt=1"`
+	Seed *int64 `json:"seed" validate:"omitempty"`
+	Solver string `json:"solver" validate:"omitempty,oneof=GENETIC ANNEALING GENETIC_TABU"`
+	StallGenerations int32 `json:"stallGenerations" validate:"min=0"`
+	TimeBudgetSeconds int32 `json:"timeBudgetSeconds" validate:"min=0"`
+	StopWhenFeasible bool `json:"stopWhenFeasible"`
+}
+
+// runs the given solvers, all of them when empty, on the same data of a university
+type BenchmarkSolversDto struct{
+	StartTime time.Time `json:"startTime" validate:"omitempty"`
+	EndTime time.Time `json:"endTime" validate:"omitempty"`
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	Params *GAParamsDto `json:"params" validate:"omitempty"`
+	Solvers []string `json:"solvers" validate:"omitempty,dive,oneof=GENETIC ANNEALING GENETIC_TABU"`
+	Semester string `json:"semester" validate:"required,oneof=First Second"`
+}
+
+
+// the data of a university as a problem file the solvers can run on without the database
+type ExportProblemDto struct{
+	StartTime time.Time `json:"startTime" validate:"omitempty"`
+	EndTime time.Time `json:"endTime" validate:"omitempty"`
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	Params *GAParamsDto `json:"params" validate:"omitempty"`
+	Solver string `json:"solver" validate:"omitempty,oneof=GENETIC ANNEALING GENETIC_TABU"`
+	Semester string `json:"semester" validate:"required,oneof=First Second"`
+}
+
+
+type TimetableConstraintDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	Name string `json:"name" validate:"required"`
+	Enabled bool `json:"enabled"`
+	Weight float64 `json:"weight" validate:"min=0"`
+	LimitValue float64 `json:"limitValue" validate:"omitempty"`
+	// a hard constraint is never broken if the solver can help it, its weight is ignored
+	Hard bool `json:"hard"`
+}
+
+// the minutes it takes to walk between two venues, used instead of the time worked out from
+// their coordinates
+type VenueTravelTimeDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	FromVenueId uuid.UUID `json:"fromVenueId" validate:"required"`
+	ToVenueId uuid.UUID `json:"toVenueId" validate:"required"`
+	Minutes int32 `json:"minutes" validate:"min=0"`
+}
+
+// times are HH:MM e.g 08:00
+type TeachingDayDto struct{
+	Day string `json:"day" validate:"required"`
+	StartTime string `json:"startTime" validate:"required"`
+	EndTime string `json:"endTime" validate:"required"`
+}
+
+type TeachingWeekDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	SlotMinutes int32 `json:"slotMinutes" validate:"required"`
+	Days []TeachingDayDto `json:"days" validate:"required,min=1,dive"`
+}
+
+// a dean rebuilds their faculty and a HOD their department, the rest of the published timetable
+// of the term stays as it is
+type ScopedTimetableDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	AcademicSession string `json:"academicSession" validate:"omitempty"`
+	Semester string `json:"semester" validate:"required,oneof=First Second"`
+	Params *GAParamsDto `json:"params" validate:"omitempty"`
+	Solver string `json:"solver" validate:"omitempty,oneof=GENETIC ANNEALING GENETIC_TABU"`
+}
+
+// repairs the timetable published for the term, the one published last when no semester is given
+type RepairTimetableDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	AcademicSession string `json:"academicSession" validate:"omitempty"`
+	Semester string `json:"semester" validate:"omitempty,oneof=First Second"`
+}
+
+// fixes the nth session of a course to a day, time and venue
+type SessionPinDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	CourseId uuid.UUID `json:"courseId" validate:"required"`
+	SessionNumber int32 `json:"sessionNumber" validate:"required,min=1"`
+	Day string `json:"day" validate:"required"`
+	StartTime string `json:"startTime" validate:"required"`
+	VenueId uuid.UUID `json:"venueId" validate:"required"`
+}
+
+// submits a draft for review or publishes a candidate
+type CandidateActionDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	CandidateId uuid.UUID `json:"candidateId" validate:"required"`
+}
+
+// a dean or HOD's decision on the draft for their faculty or department
+type CandidateReviewDto struct{
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	CandidateId uuid.UUID `json:"candidateId" validate:"required"`
+	Decision string `json:"decision" validate:"required,oneof=APPROVED CHANGES_REQUESTED"`
+	Comment string `json:"comment" validate:"required_if=Decision CHANGES_REQUESTED"`
 }
 
 
+type TimetableJobResponse struct {
+	JobId          uuid.UUID
+	UniversityId   uuid.UUID
+	Status         string
+	AcademicSession string
+	Semester       string
+	Scope          string
+	ScopeId        uuid.NullUUID
+	Seed           int64
+	Generation     int
+	Generations    int
+	BestFitness    float64
+	ElapsedSeconds float64
+	Error          string
+	CreatedAt      time.Time
+	// what the solvers of a benchmark job produced, empty on generations
+	Benchmark      *BenchmarkSolversResponse
+}
+
+type TimetableSettingsResponse struct {
+	UniversityId    uuid.UUID
+	PopulationSize  int32
+	Generations     int32
+	MutationRate    float64
+	TournamentSize  int32
+	ElitismFraction float64
+	Seed            *int64
+	Solver          string
+	// when a genetic run stops early, 0 and false never stop it
+	StallGenerations  int32
+	TimeBudgetSeconds int32
+	StopWhenFeasible  bool
+}
+
+type TimetableConstraintResponse struct {
+	Name       string
+	Enabled    bool
+	Weight     float64
+	LimitValue float64
+	Hard       bool
+}
 
+// Source is MANUAL for a time the university set and COORDINATES for one worked out from the
+// coordinates of the venues
+type VenueTravelTimeResponse struct {
+	FromVenueId   uuid.UUID
+	FromVenueName string
+	ToVenueId     uuid.UUID
+	ToVenueName   string
+	Minutes       int
+	Source        string
+}
+
+// where the sessions of a department's courses were placed, OutsideOwnRooms counts every session
+// not in a venue of the department
+type DepartmentVenueUsageResponse struct {
+	DepartmentId    uuid.UUID
+	DepartmentName  string
+	Sessions        int
+	InOwnRooms      int
+	InFacultyRooms  int
+	InSharedRooms   int
+	InOtherRooms    int
+	OutsideOwnRooms int
+	OutsideShare    float64 // OutsideOwnRooms out of Sessions, 0 to 1
+}
+
+type TeachingDayResponse struct {
+	Day       string
+	StartTime string
+	EndTime   string
+}
+
+type TeachingWeekResponse struct {
+	UniversityId uuid.UUID
+	Configured   bool // false when the university still uses the monday to friday default
+	SlotMinutes  int
+	SlotsPerDay  int
+	Days         []TeachingDayResponse
+}
+
+type SessionPinResponse struct {
+	PinId         uuid.UUID
+	CourseId      uuid.UUID
+	CourseCode    string
+	SessionNumber int32
+	Day           string
+	StartTime     string
+	VenueId       uuid.UUID
+	VenueName     string
+	Semester      string
+}
+
+type MovedSessionResponse struct {
+	SessionIdx    int32
+	CourseId      uuid.UUID
+	CourseCode    string
+	FromDay       string // empty when the session was not in the timetable
+	FromTime      string
+	FromVenueId   uuid.NullUUID
+	FromVenueName string
+	ToDay         string
+	ToTime        string
+	ToVenueId     uuid.UUID
+	ToVenueName   string
+	Reason        string
+}
+
+type RepairTimetableResponse struct {
+	CandidateId  uuid.UUID // the new candidate, empty when nothing had to move
+	RepairedFrom uuid.UUID
+	Fitness      float64
+	HardPenalty  float64
+	MovedCount   int
+	Moved        []MovedSessionResponse
+}
+
+type ViolationResponse struct {
+	Type      string // e.g LECTURER_DOUBLE_BOOKED or the name of a soft constraint
+	Severity  string // HARD or SOFT
+	Penalty   float64
+	Day       string // empty when the violation has no slot, e.g a session that was never placed
+	StartTime string
+	EndTime   string
+	Courses   []string
+	Lecturers []string
+	Venues    []string
+	Cohorts   []string
+	Message   string
+}
+
+type ViolationReportResponse struct {
+	CandidateId     uuid.UUID
+	CandidateStatus string
+	HardCount       int
+	SoftCount       int
+	SoftPenalty     float64
+	StoredConflicts int // placements flagged as conflicting when the candidate was saved
+	Hard            []ViolationResponse
+	Soft            []ViolationResponse
+}
+
+type SolverBenchmarkResponse struct {
+	Solver         string
+	Fitness        float64
+	HardPenalty    float64
+	SoftPenalty    float64
+	HardViolations int
+	Seconds        float64
+	Error          string
+}
+
+type BenchmarkSolversResponse struct {
+	UniversityId uuid.UUID
+	Seed         int64 // every solver ran with this seed
+	Sessions     int
+	Results      []SolverBenchmarkResponse
+}
+
+type CandidateResponse struct {
+	CandidateId  uuid.UUID
+	Status       string
+	Fitness      float64
+	StartOfDay   time.Time
+	EndOfDay     time.Time
+	CreatedAt    time.Time
+	RepairedFrom uuid.NullUUID
+	PublishedAt  *time.Time // set once the candidate has been published, kept after it is archived
+	PublishedBy  uuid.NullUUID
+	// empty for candidates generated before timetables had a term
+	AcademicSession string
+	Semester     string
+	Solver       string // empty for repaired candidates
+	// the run that generated the candidate, nil for repaired candidates and ones saved before runs were stored
+	Seed            *int64
+	PopulationSize  *int32
+	Generations     *int32
+	MutationRate    *float64
+	TournamentSize  *int32
+	ElitismFraction *float64
+	Workers         *int32
+	GenerationsRun  *int32 // fewer than Generations when the run stopped early
+	StopReason      string
+}
+
+type CandidateSessionResponse struct {
+	SessionIdx  int32
+	CourseId    uuid.UUID
+	CourseCode  string
+	CourseTitle string
+	VenueId     uuid.UUID
+	VenueName   string
+	Day         string
+	StartTime   string
+	Conflict    bool
+	SessionType string
+	Group       int // 1 based group of the course's students, 0 when the whole class attends
+	Groups      int // how many groups the course's students are split into, 0 when not split
+}
+
+type CandidateDetailResponse struct {
+	Candidate CandidateResponse
+	Sessions  []CandidateSessionResponse
+}
+
+type CandidateReviewResponse struct {
+	ReviewId       uuid.UUID
+	CandidateId    uuid.UUID
+	ReviewerId     uuid.UUID // the lecturer id of the dean or HOD
+	ReviewerName   string
+	ReviewerRole   string // DEAN or HOD
+	FacultyId      uuid.NullUUID
+	FacultyName    string
+	DepartmentId   uuid.NullUUID
+	DepartmentName string
+	Decision       string // APPROVED or CHANGES_REQUESTED
+	Comment        string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type CandidateReviewsResponse struct {
+	CandidateId      uuid.UUID
+	Status           string
+	Approved         int
+	ChangesRequested int
+	Reviews          []CandidateReviewResponse
+}
+
+// the sessions of a candidate a dean or HOD reviews, only the courses of their faculty or department
+type CandidateReviewScopeResponse struct {
+	Candidate    CandidateResponse
+	ReviewerRole string
+	FacultyId    uuid.NullUUID
+	DepartmentId uuid.NullUUID
+	Sessions     []CandidateSessionResponse
+}
+
+type SessionChangeResponse struct {
+	CourseId      uuid.UUID
+	CourseCode    string
+	SessionNumber int
+	Change        string // MOVED, VENUE_CHANGED, TIME_CHANGED, ADDED or REMOVED
+	FromDay       string // empty for an added session
+	FromTime      string
+	FromVenueId   uuid.NullUUID
+	FromVenueName string
+	ToDay         string // empty for a removed session
+	ToTime        string
+	ToVenueId     uuid.NullUUID
+	ToVenueName   string
+}
+
+type CohortDiffResponse struct {
+	CohortId   uuid.UUID
+	CohortName string
+	Changes    []SessionChangeResponse
+}
+
+// counts are of sessions, a session shared by several cohorts is counted once
+type CandidateDiffResponse struct {
+	FromCandidateId uuid.UUID
+	ToCandidateId   uuid.UUID
+	Moved           int
+	VenueChanged    int
+	TimeChanged     int
+	Added           int
+	Removed         int
+	Cohorts         []CohortDiffResponse
+}
+
+type GenerationFitnessResponse struct {
+	Generation  int32
+	BestFitness float64
+	MeanFitness float64
+}
+
+// how the fitness of a run went generation by generation, generation 0 is the initial population
+type CandidateFitnessHistoryResponse struct {
+	CandidateId    uuid.UUID
+	Solver         string
+	Generations    *int32
+	GenerationsRun *int32
+	StopReason     string
+	History        []GenerationFitnessResponse
+}
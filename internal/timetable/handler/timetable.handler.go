@@ -57,24 +57,57 @@ func NewTimetableHandler(service service.TimeTableService)*TimetableHandler{
 func (tth *TimetableHandler) CreateATimeTable(res http.ResponseWriter, req *http.Request){
 	var body dto.CreateATimeTableDto
 	utils.HandleBodyParsing(req,res,&body)
-	resp,errMsg,err := tth.TimeTableService.CreateATimeTable(ctx,body.StartTime,body.EndTime,body.UniversityId)
+	resp,errMsg,err := tth.TimeTableService.CreateATimeTable(ctx,body.StartTime,body.EndTime,body.UniversityId,body.AcademicSession,body.Semester,body.Params,body.Solver)
 	slog.Info("resp","val",resp)
 	utils.HandleAuthResponse(resp,err,errMsg,res)
 }
 
+func (tth *TimetableHandler) createAScopedTimeTable(res http.ResponseWriter, req *http.Request, role string){
+	var body dto.ScopedTimetableDto
+	utils.HandleBodyParsing(req,res,&body)
+	reviewerId := reviewerIdFromCookie(req,role)
+	resp,errMsg,err := tth.TimeTableService.CreateAScopedTimeTable(ctx,body,role,utils.StringToUUID(reviewerId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+// a dean rebuilds only the sessions of their faculty
+func (tth *TimetableHandler) CreateAFacultyTimeTable(res http.ResponseWriter, req *http.Request){
+	tth.createAScopedTimeTable(res,req,service.ReviewerDean)
+}
+
+// a HOD rebuilds only the sessions of their department
+func (tth *TimetableHandler) CreateADepartmentTimeTable(res http.ResponseWriter, req *http.Request){
+	tth.createAScopedTimeTable(res,req,service.ReviewerHod)
+}
+
 func (tth *TimetableHandler) FetchTimetableForCohort(res http.ResponseWriter, req *http.Request){
 	queryParams := req.URL.Query()
 	cohortId := queryParams.Get("cohortId")
 	uniId := queryParams.Get("uniId")
+	// without a semester the timetable published last is returned
+	session := queryParams.Get("session")
+	semester := queryParams.Get("semester")
 	slog.Info("cohortid","val",cohortId)
 	slog.Info("universityId","val",uniId)
-	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableForACohort(ctx,utils.StringToUUID(cohortId),utils.StringToUUID(uniId))
+	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableForACohort(ctx,utils.StringToUUID(cohortId),utils.StringToUUID(uniId),session,semester)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchTimetableForVenue(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	venueId := queryParams.Get("venueId")
+	uniId := queryParams.Get("uniId")
+	session := queryParams.Get("session")
+	semester := queryParams.Get("semester")
+	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableForAVenue(ctx,utils.StringToUUID(venueId),utils.StringToUUID(uniId),session,semester)
 	utils.HandleAuthResponse(resp,err,errMsg,res)
 }
 
 func (tth *TimetableHandler) FetchTimetableForAStudent(res http.ResponseWriter, req *http.Request){
 	queryParams := req.URL.Query()
 	uniId := queryParams.Get("uniId")
+	session := queryParams.Get("session")
+	semester := queryParams.Get("semester")
 	var studentId string
 	claims := req.Context().Value(constants.UserInfoKey)
 	if claims != nil{
@@ -87,6 +120,272 @@ func (tth *TimetableHandler) FetchTimetableForAStudent(res http.ResponseWriter,
 			"error": errors.New("error validating student authenticity"),
 		})
 	}
-	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableForAStudent(ctx,utils.StringToUUID(studentId),utils.StringToUUID(uniId))
+	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableForAStudent(ctx,utils.StringToUUID(studentId),utils.StringToUUID(uniId),session,semester)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchTimetableJob(res http.ResponseWriter, req *http.Request){
+	jobId := req.URL.Query().Get("jobId")
+	var userId string
+	claims := req.Context().Value(constants.UserInfoKey)
+	if claims != nil{
+		userId = claims.(*jwt.CustomClaims).User_id
+	}
+	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableJob(ctx,utils.StringToUUID(jobId),utils.StringToUUID(userId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) CancelTimetableJob(res http.ResponseWriter, req *http.Request){
+	jobId := req.URL.Query().Get("jobId")
+	var userId string
+	claims := req.Context().Value(constants.UserInfoKey)
+	if claims != nil{
+		userId = claims.(*jwt.CustomClaims).User_id
+	}
+	resp,errMsg,err := tth.TimeTableService.CancelTimetableJob(ctx,utils.StringToUUID(jobId),utils.StringToUUID(userId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchTimetableSettings(res http.ResponseWriter, req *http.Request){
+	uniId := req.URL.Query().Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableSettings(ctx,utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) UpdateTimetableSettings(res http.ResponseWriter, req *http.Request){
+	var body dto.TimetableSettingsDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.UpdateTimetableSettings(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchTimetableConstraints(res http.ResponseWriter, req *http.Request){
+	uniId := req.URL.Query().Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveTimetableConstraints(ctx,utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) UpdateTimetableConstraint(res http.ResponseWriter, req *http.Request){
+	var body dto.TimetableConstraintDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.UpdateTimetableConstraint(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchVenueTravelTimes(res http.ResponseWriter, req *http.Request){
+	uniId := req.URL.Query().Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveVenueTravelTimes(ctx,utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) SetVenueTravelTime(res http.ResponseWriter, req *http.Request){
+	var body dto.VenueTravelTimeDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.SetVenueTravelTime(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) DeleteVenueTravelTime(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	uniId := queryParams.Get("uniId")
+	fromVenueId := queryParams.Get("fromVenueId")
+	toVenueId := queryParams.Get("toVenueId")
+	resp,errMsg,err := tth.TimeTableService.DeleteVenueTravelTime(ctx,utils.StringToUUID(uniId),utils.StringToUUID(fromVenueId),utils.StringToUUID(toVenueId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchTeachingWeek(res http.ResponseWriter, req *http.Request){
+	uniId := req.URL.Query().Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveTeachingWeek(ctx,utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) UpdateTeachingWeek(res http.ResponseWriter, req *http.Request){
+	var body dto.TeachingWeekDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.UpdateTeachingWeek(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchSessionPins(res http.ResponseWriter, req *http.Request){
+	uniId := req.URL.Query().Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveSessionPins(ctx,utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) PinSession(res http.ResponseWriter, req *http.Request){
+	var body dto.SessionPinDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.PinSession(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) DeleteSessionPin(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	pinId := queryParams.Get("pinId")
+	uniId := queryParams.Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.DeleteSessionPin(ctx,utils.StringToUUID(pinId),utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) BenchmarkSolvers(res http.ResponseWriter, req *http.Request){
+	var body dto.BenchmarkSolversDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.BenchmarkSolvers(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) ExportProblem(res http.ResponseWriter, req *http.Request){
+	var body dto.ExportProblemDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.ExportProblem(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) RepairTimetable(res http.ResponseWriter, req *http.Request){
+	var body dto.RepairTimetableDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.RepairTimetable(ctx,body.UniversityId,body.AcademicSession,body.Semester)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchMovedSessions(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	candidateId := queryParams.Get("candidateId")
+	uniId := queryParams.Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveMovedSessions(ctx,utils.StringToUUID(candidateId),utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchCandidateViolations(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	candidateId := queryParams.Get("candidateId")
+	uniId := queryParams.Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveCandidateViolations(ctx,utils.StringToUUID(candidateId),utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchCandidates(res http.ResponseWriter, req *http.Request){
+	uniId := req.URL.Query().Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveCandidates(ctx,utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchCandidate(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	candidateId := queryParams.Get("candidateId")
+	uniId := queryParams.Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveCandidate(ctx,utils.StringToUUID(candidateId),utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchCandidateFitnessHistory(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	candidateId := queryParams.Get("candidateId")
+	uniId := queryParams.Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveCandidateFitnessHistory(ctx,utils.StringToUUID(candidateId),utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchDepartmentVenueUsage(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	candidateId := queryParams.Get("candidateId")
+	uniId := queryParams.Get("uniId")
+	departmentId := queryParams.Get("departmentId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveDepartmentVenueUsage(ctx,utils.StringToUUID(candidateId),utils.StringToUUID(uniId),utils.StringToUUID(departmentId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) DiffCandidates(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	uniId := queryParams.Get("uniId")
+	fromId := queryParams.Get("from")
+	toId := queryParams.Get("to")
+	resp,errMsg,err := tth.TimeTableService.DiffCandidates(ctx,utils.StringToUUID(uniId),utils.StringToUUID(fromId),utils.StringToUUID(toId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) SubmitCandidateForReview(res http.ResponseWriter, req *http.Request){
+	var body dto.CandidateActionDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := tth.TimeTableService.SubmitCandidateForReview(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) PublishCandidate(res http.ResponseWriter, req *http.Request){
+	var body dto.CandidateActionDto
+	utils.HandleBodyParsing(req,res,&body)
+	var adminId string
+	claims := req.Context().Value(constants.UserInfoKey)
+	if claims != nil{
+		adminId = claims.(*jwt.CustomClaims).User_id
+	}
+	resp,errMsg,err := tth.TimeTableService.PublishCandidate(ctx,body,utils.StringToUUID(adminId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) PromoteCandidate(res http.ResponseWriter, req *http.Request){
+	var body dto.CandidateActionDto
+	utils.HandleBodyParsing(req,res,&body)
+	var adminId string
+	claims := req.Context().Value(constants.UserInfoKey)
+	if claims != nil{
+		adminId = claims.(*jwt.CustomClaims).User_id
+	}
+	resp,errMsg,err := tth.TimeTableService.PromoteCandidate(ctx,body,utils.StringToUUID(adminId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) FetchCandidateReviews(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	candidateId := queryParams.Get("candidateId")
+	uniId := queryParams.Get("uniId")
+	resp,errMsg,err := tth.TimeTableService.RetrieveCandidateReviews(ctx,utils.StringToUUID(candidateId),utils.StringToUUID(uniId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+// the dean and HOD routes sit behind their middlewares so the cookie holds a confirmed dean or hod id
+func reviewerIdFromCookie(req *http.Request, role string) string{
+	name := "current_dean_id"
+	if role == service.ReviewerHod{
+		name = "current_hod_id"
+	}
+	cookie,err := req.Cookie(name)
+	if err != nil{
+		return ""
+	}
+	return cookie.Value
+}
+
+func (tth *TimetableHandler) fetchCandidateForReview(res http.ResponseWriter, req *http.Request, role string){
+	queryParams := req.URL.Query()
+	candidateId := queryParams.Get("candidateId")
+	uniId := queryParams.Get("uniId")
+	reviewerId := reviewerIdFromCookie(req,role)
+	resp,errMsg,err := tth.TimeTableService.RetrieveCandidateForReview(ctx,utils.StringToUUID(candidateId),utils.StringToUUID(uniId),role,utils.StringToUUID(reviewerId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (tth *TimetableHandler) reviewCandidate(res http.ResponseWriter, req *http.Request, role string){
+	var body dto.CandidateReviewDto
+	utils.HandleBodyParsing(req,res,&body)
+	reviewerId := reviewerIdFromCookie(req,role)
+	resp,errMsg,err := tth.TimeTableService.ReviewCandidate(ctx,body,role,utils.StringToUUID(reviewerId))
 	utils.HandleAuthResponse(resp,err,errMsg,res)
-}
\ No newline at end of file
+}
+
+func (tth *TimetableHandler) FetchCandidateForDeanReview(res http.ResponseWriter, req *http.Request){
+	tth.fetchCandidateForReview(res,req,service.ReviewerDean)
+}
+
+func (tth *TimetableHandler) DeanReviewCandidate(res http.ResponseWriter, req *http.Request){
+	tth.reviewCandidate(res,req,service.ReviewerDean)
+}
+
+func (tth *TimetableHandler) FetchCandidateForHodReview(res http.ResponseWriter, req *http.Request){
+	tth.fetchCandidateForReview(res,req,service.ReviewerHod)
+}
+
+func (tth *TimetableHandler) HodReviewCandidate(res http.ResponseWriter, req *http.Request){
+	tth.reviewCandidate(res,req,service.ReviewerHod)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
 
@@ -20,15 +21,58 @@ type TimetableRepository interface{
 	RetrieveTotalLecturerUnavailability(ctx context.Context,uniId uuid.NullUUID)([]sqlc.RetrieveTotalLecturerUnavailabilityRow,error)
 	RetrieveAllVenues(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveAllVenuesRow,error)
 	RetrieveAllCourses(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveAllCoursesRow,error)
-	RetrieveAllCoursesAndVenues(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveAllCoursesAndTheirVenueIdsRow,error)
+	RetrieveCoursesForSemester(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveCoursesForSemesterRow,error)
+	RetrieveAllCoursesAndVenues(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveAllCoursesAndTheirVenueIdsRow,error)
 	RetrieveAllCohorts(ctx context.Context,uniId uuid.UUID)([]sqlc.Cohort,error)
 	RetrieveTotalLecturers(ctx context.Context, uniId uuid.NullUUID)([]sqlc.RetrieveTotalLecturersRow,error)
-	RetrieveCohortsForAllCourses(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveCohortsForAllCoursesRow,error)
-	CreateACandidateTimeTable(ctx context.Context, candidateData sqlc.CreateCandidateParams, sessionPlacements []types.CustomSessionPlacement)error
-	DeprecateLatestCandidate(ctx context.Context,uniId uuid.UUID)error
-	RestoreCurrentCandidate(ctx context.Context,uniId uuid.UUID)error
+	RetrieveCohortsForAllCourses(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveCohortsForAllCoursesRow,error)
+	RetrieveCohortStudentCounts(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveCohortStudentCountsRow,error)
+	RetrieveCourseStudentCounts(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveCourseStudentCountsRow,error)
+	RetrieveCourseLecturers(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveCourseLecturersForUniRow,error)
+	CreateACandidateTimeTable(ctx context.Context, candidateData sqlc.CreateCandidateParams, sessionPlacements []types.CustomSessionPlacement, fitnessHistory []sqlc.CreateCandidateFitnessParams)error
 	FetchSessionsForACohort(ctx context.Context,params sqlc.GetCohortSessionsInCurrentTimetableParams)([]sqlc.GetCohortSessionsInCurrentTimetableRow,error)
-	FetchSessionsForAStudent(ctx context.Context,studentId uuid.UUID)([]sqlc.GetStudentTimetableSessionsRow,error)
+	FetchSessionsForAStudent(ctx context.Context,studentId uuid.UUID,candidateId uuid.UUID)([]sqlc.GetStudentTimetableSessionsRow,error)
+	GetTimetableSettings(ctx context.Context,uniId uuid.UUID)(sqlc.TimetableSetting,error)
+	UpsertTimetableSettings(ctx context.Context,params sqlc.UpsertTimetableSettingsParams)(sqlc.TimetableSetting,error)
+	GetTimetableConstraints(ctx context.Context,uniId uuid.UUID)([]sqlc.TimetableConstraint,error)
+	UpsertTimetableConstraint(ctx context.Context,params sqlc.UpsertTimetableConstraintParams)(sqlc.TimetableConstraint,error)
+	GetTeachingDays(ctx context.Context,uniId uuid.UUID)([]sqlc.GetTeachingDaysRow,error)
+	ReplaceTeachingWeek(ctx context.Context,uniId uuid.UUID,slotMinutes int32,days []sqlc.CreateTeachingDayParams)error
+	UpsertSessionPin(ctx context.Context,params sqlc.UpsertSessionPinParams)(uuid.UUID,error)
+	RetrieveSessionPins(ctx context.Context,uniId uuid.UUID)([]sqlc.GetSessionPinsRow,error)
+	DeleteSessionPin(ctx context.Context,params sqlc.DeleteSessionPinParams)error
+	RetrievePublishedCandidate(ctx context.Context,params sqlc.GetPublishedCandidateParams)(sqlc.Candidate,error)
+	RetrieveLatestPublishedCandidate(ctx context.Context,uniId uuid.UUID)(sqlc.Candidate,error)
+	RetrieveCurrentSession(ctx context.Context,uniId uuid.UUID)(sql.NullString,error)
+	RetrieveUserUniversity(ctx context.Context,userId uuid.UUID)(uuid.NullUUID,error)
+	RetrieveCandidate(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)(sqlc.Candidate,error)
+	RetrieveCandidates(ctx context.Context,uniId uuid.UUID)([]sqlc.Candidate,error)
+	RetrieveCandidateSessions(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateSessionsRow,error)
+	RetrieveCandidateCohortSessions(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateCohortSessionsRow,error)
+	PublishCandidate(ctx context.Context,candidate sqlc.Candidate,adminId uuid.NullUUID)error
+	UpdateCandidateStatus(ctx context.Context,params sqlc.UpdateCandidateStatusParams)error
+	RetrieveCandidateSessionsForFaculty(ctx context.Context,params sqlc.GetCandidateSessionsForFacultyParams)([]sqlc.GetCandidateSessionsForFacultyRow,error)
+	RetrieveCandidateSessionsForDepartment(ctx context.Context,params sqlc.GetCandidateSessionsForDepartmentParams)([]sqlc.GetCandidateSessionsForDepartmentRow,error)
+	UpsertCandidateReview(ctx context.Context,params sqlc.UpsertCandidateReviewParams)(uuid.UUID,error)
+	RetrieveCandidateReviews(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateReviewsRow,error)
+	RetrieveDean(ctx context.Context,deanId uuid.UUID)(sqlc.RetrieveDeanRow,error)
+	RetrieveHod(ctx context.Context,hodId uuid.UUID)(sqlc.RetrieveHodRow,error)
+	RetrieveCourseDepartments(ctx context.Context,uniId uuid.UUID)([]sqlc.GetCourseDepartmentsRow,error)
+	RetrieveVenueTravelTimes(ctx context.Context,uniId uuid.UUID)([]sqlc.GetVenueTravelTimesRow,error)
+	UpsertVenueTravelTime(ctx context.Context,params sqlc.UpsertVenueTravelTimeParams)error
+	DeleteVenueTravelTime(ctx context.Context,params sqlc.DeleteVenueTravelTimeParams)error
+	RetrieveElectiveGroupCourses(ctx context.Context,uniId uuid.UUID,semester string)([]sqlc.RetrieveElectiveGroupCoursesRow,error)
+	RetrieveElectiveStudentOverlaps(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveElectiveStudentOverlapsRow,error)
+	RetrieveCourseComponents(ctx context.Context,uniId uuid.UUID,semester string)([]sqlc.RetrieveCourseComponentsForUniRow,error)
+	FetchSessionsForAVenue(ctx context.Context,params sqlc.GetVenueSessionsInCurrentTimetableParams)([]sqlc.GetVenueSessionsInCurrentTimetableRow,error)
+	RetrieveVenueFeatures(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveVenueFeaturesForUniRow,error)
+	RetrieveVenueOwners(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveVenueOwnersForUniRow,error)
+	RetrieveCourseRequiredFeatures(ctx context.Context,uniId uuid.UUID,semester string)([]sqlc.RetrieveCourseRequiredFeaturesForUniRow,error)
+	RetrieveCandidateSessionPlacements(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateSessionPlacementsRow,error)
+	RetrieveMovedSessions(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)([]sqlc.GetMovedSessionsRow,error)
+	RetrieveCandidateFitnessHistory(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateFitnessHistoryRow,error)
+	RetrieveDepartmentVenueUsage(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetDepartmentVenueUsageRow,error)
+	CreateARepairedCandidate(ctx context.Context, candidateData sqlc.CreateCandidateParams, sessionPlacements []types.CustomSessionPlacement, movedSessions []sqlc.CreateMovedSessionParams)(uuid.UUID,error)
 }
 type timetableRepository struct {
 	vq *queries.VenueQueries
@@ -86,8 +130,18 @@ func (ttrp *timetableRepository) RetrieveAllCourses(ctx context.Context, uniId u
 	return ttrp.cq.RetrieveAllCourses(ctx,uniId)
 }
 
-func (ttrp *timetableRepository) RetrieveAllCoursesAndVenues(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveAllCoursesAndTheirVenueIdsRow,error){
-	return ttrp.cq.RetrieveAllCoursesAndVenues(ctx,uniId)
+func (ttrp *timetableRepository) RetrieveCoursesForSemester(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveCoursesForSemesterRow,error){
+	return ttrp.cq.RetrieveCoursesForSemester(ctx,sqlc.RetrieveCoursesForSemesterParams{
+		UniversityID: uniId,
+		Semester: semester,
+	})
+}
+
+func (ttrp *timetableRepository) RetrieveAllCoursesAndVenues(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveAllCoursesAndTheirVenueIdsRow,error){
+	return ttrp.cq.RetrieveAllCoursesAndVenues(ctx,sqlc.RetrieveAllCoursesAndTheirVenueIdsParams{
+		UniversityID: uniId,
+		Semester: semester,
+	})
 }
 
 func (ttrp *timetableRepository) RetrieveAllCohorts(ctx context.Context,uniId uuid.UUID)([]sqlc.Cohort,error){
@@ -98,12 +152,11 @@ func (ttrp *timetableRepository) RetrieveTotalLecturers(ctx context.Context, uni
 	return ttrp.lq.RetrieveAllLecturers(ctx,uniId)
 }
 
-func (ttrp *timetableRepository) RetrieveTotalCohortCourses(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveCohortsForAllCoursesRow,error){
-	return ttrp.cohq.RetrieveTotalCohortCourses(ctx,uniId)
-}
-
-func (ttrp *timetableRepository) RetrieveCohortsForAllCourses(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveCohortsForAllCoursesRow,error){
-	return ttrp.cohq.RetrieveTotalCohortCourses(ctx,uniId)
+func (ttrp *timetableRepository) RetrieveCohortsForAllCourses(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveCohortsForAllCoursesRow,error){
+	return ttrp.cohq.RetrieveTotalCohortCourses(ctx,sqlc.RetrieveCohortsForAllCoursesParams{
+		UniversityID: uniId,
+		Semester: semester,
+	})
 }
 // func parseTimeFromString(timeStr string) (time.Time, error) {
 //     if timeStr == "" {
@@ -134,55 +187,341 @@ func (ttrp *timetableRepository) RetrieveCohortsForAllCourses(ctx context.Contex
     
 //     return time.Time{}, fmt.Errorf("unable to parse time string: %s", timeStr)
 // }
-func (ttrp *timetableRepository) CreateACandidateTimeTable(ctx context.Context, candidateData sqlc.CreateCandidateParams, sessionPlacements []types.CustomSessionPlacement) error {
+// saves a generated candidate with its sessions and the fitness of every generation of its run
+func (ttrp *timetableRepository) CreateACandidateTimeTable(ctx context.Context, candidateData sqlc.CreateCandidateParams, sessionPlacements []types.CustomSessionPlacement, fitnessHistory []sqlc.CreateCandidateFitnessParams) error {
     return ttrp.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+        // a new run replaces the drafts of its term, never one the deans and HODs are reviewing
+        if err := q.ArchiveDraftCandidates(ctx, archiveDraftParams(candidateData)); err != nil {
+            return err
+        }
         val, createCandidateErr := q.CreateCandidate(ctx, candidateData)
         if createCandidateErr != nil {
             return createCandidateErr
         }
-
-        slog.Info("Creating session placements", "count", len(sessionPlacements))
-
-        for i, placement := range sessionPlacements {
-            params := sqlc.CreateSessionPlacementsParams{
-                CandidateID:  val.ID,
-                SessionIdx:   placement.SessionIdx,
-                CourseID:     placement.CourseId,
-                VenueID:      placement.VenueId,
-                Day:          placement.Day,
-                SessionTime:  placement.SessionTime,
-                UniversityID: placement.UniversityId,
+        if err := createSessionPlacements(ctx, q, val.ID, sessionPlacements); err != nil {
+            return err
+        }
+        for _, fitness := range fitnessHistory {
+            fitness.CandidateID = val.ID
+            if err := q.CreateCandidateFitness(ctx, fitness); err != nil {
+                return fmt.Errorf("failed to record the fitness of generation %d: %w", fitness.Generation, err)
             }
+        }
+        return nil
+    })
+}
 
-            createSessionPlacementsErr := q.CreateSessionPlacements(ctx, params)
-            if createSessionPlacementsErr != nil {
-                slog.Error("Failed to create session placement", 
-                    "sessionIdx", placement.SessionIdx,
-                    "index", i,
-                    "error", createSessionPlacementsErr,
-                    "sessionTime", placement.SessionTime,
-                    "sessionTimeType", fmt.Sprintf("%T", placement.SessionTime))
-                return fmt.Errorf("failed to create session placement for session %d: %w", placement.SessionIdx, createSessionPlacementsErr)
+// saves a candidate made by repairing another one with the sessions the repair moved
+func (ttrp *timetableRepository) CreateARepairedCandidate(ctx context.Context, candidateData sqlc.CreateCandidateParams, sessionPlacements []types.CustomSessionPlacement, movedSessions []sqlc.CreateMovedSessionParams) (uuid.UUID, error) {
+    var candidateId uuid.UUID
+    err := ttrp.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+        if err := q.ArchiveDraftCandidates(ctx, archiveDraftParams(candidateData)); err != nil {
+            return err
+        }
+        val, err := q.CreateCandidate(ctx, candidateData)
+        if err != nil {
+            return err
+        }
+        candidateId = val.ID
+        if err := createSessionPlacements(ctx, q, val.ID, sessionPlacements); err != nil {
+            return err
+        }
+        for _, moved := range movedSessions {
+            moved.CandidateID = val.ID
+            if err := q.CreateMovedSession(ctx, moved); err != nil {
+                return fmt.Errorf("failed to record moved session %d: %w", moved.SessionIdx, err)
             }
         }
-        
-        slog.Info("Successfully created all session placements", "count", len(sessionPlacements))
         return nil
     })
+    return candidateId, err
 }
 
-func (ttrp *timetableRepository) DeprecateLatestCandidate(ctx context.Context,uniId uuid.UUID)error{
-	return ttrp.tmtq.DeprecateLatestCandidate(ctx,uniId)
+func archiveDraftParams(candidateData sqlc.CreateCandidateParams) sqlc.ArchiveDraftCandidatesParams {
+    return sqlc.ArchiveDraftCandidatesParams{
+        UniversityID:    candidateData.UniversityID,
+        AcademicSession: candidateData.AcademicSession,
+        Semester:        candidateData.Semester,
+    }
 }
 
-func (ttrp *timetableRepository) RestoreCurrentCandidate(ctx context.Context,uniId uuid.UUID)error{
-	return ttrp.tmtq.RestoreCurrentCandidate(ctx,uniId)
+func createSessionPlacements(ctx context.Context, q *sqlc.Queries, candidateId uuid.UUID, sessionPlacements []types.CustomSessionPlacement) error {
+    slog.Info("Creating session placements", "count", len(sessionPlacements))
+
+    for i, placement := range sessionPlacements {
+        params := sqlc.CreateSessionPlacementsParams{
+            CandidateID:  candidateId,
+            SessionIdx:   placement.SessionIdx,
+            CourseID:     placement.CourseId,
+            VenueID:      placement.VenueId,
+            Day:          placement.Day,
+            SessionTime:  placement.SessionTime,
+            UniversityID: placement.UniversityId,
+            Conflict:     placement.Conflict,
+            SessionType:  placement.SessionType,
+            GroupIdx:     placement.GroupIdx,
+            GroupCount:   placement.GroupCount,
+        }
+
+        createSessionPlacementsErr := q.CreateSessionPlacements(ctx, params)
+        if createSessionPlacementsErr != nil {
+            slog.Error("Failed to create session placement", 
+                "sessionIdx", placement.SessionIdx,
+                "index", i,
+                "error", createSessionPlacementsErr,
+                "sessionTime", placement.SessionTime,
+                "sessionTimeType", fmt.Sprintf("%T", placement.SessionTime))
+            return fmt.Errorf("failed to create session placement for session %d: %w", placement.SessionIdx, createSessionPlacementsErr)
+        }
+    }
+    
+    slog.Info("Successfully created all session placements", "count", len(sessionPlacements))
+    return nil
 }
 
 func (ttrp *timetableRepository) FetchSessionsForACohort(ctx context.Context,params sqlc.GetCohortSessionsInCurrentTimetableParams)([]sqlc.GetCohortSessionsInCurrentTimetableRow,error){
 	return ttrp.cq.FetchSessionsForACohort(ctx,params)
 }
 
-func (ttrp *timetableRepository) FetchSessionsForAStudent(ctx context.Context,studentId uuid.UUID)([]sqlc.GetStudentTimetableSessionsRow,error){
-	return ttrp.cq.FetchSessionsForAStudent(ctx,studentId)
-}
\ No newline at end of file
+func (ttrp *timetableRepository) FetchSessionsForAStudent(ctx context.Context,studentId uuid.UUID,candidateId uuid.UUID)([]sqlc.GetStudentTimetableSessionsRow,error){
+	return ttrp.cq.FetchSessionsForAStudent(ctx,sqlc.GetStudentTimetableSessionsParams{
+		StudentID: studentId,
+		ID: candidateId,
+	})
+}
+
+func (ttrp *timetableRepository) GetTimetableSettings(ctx context.Context,uniId uuid.UUID)(sqlc.TimetableSetting,error){
+	return ttrp.tmtq.GetTimetableSettings(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) UpsertTimetableSettings(ctx context.Context,params sqlc.UpsertTimetableSettingsParams)(sqlc.TimetableSetting,error){
+	return ttrp.tmtq.UpsertTimetableSettings(ctx,params)
+}
+
+func (ttrp *timetableRepository) GetTimetableConstraints(ctx context.Context,uniId uuid.UUID)([]sqlc.TimetableConstraint,error){
+	return ttrp.tmtq.GetTimetableConstraints(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) UpsertTimetableConstraint(ctx context.Context,params sqlc.UpsertTimetableConstraintParams)(sqlc.TimetableConstraint,error){
+	return ttrp.tmtq.UpsertTimetableConstraint(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrieveCohortStudentCounts(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveCohortStudentCountsRow,error){
+	return ttrp.cohq.RetrieveCohortStudentCounts(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) RetrieveCourseStudentCounts(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveCourseStudentCountsRow,error){
+	return ttrp.cq.RetrieveCourseStudentCounts(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) RetrieveCourseLecturers(ctx context.Context, uniId uuid.UUID, semester string)([]sqlc.RetrieveCourseLecturersForUniRow,error){
+	return ttrp.cq.RetrieveCourseLecturersForUni(ctx,sqlc.RetrieveCourseLecturersForUniParams{
+		UniversityID: uniId,
+		Semester: semester,
+	})
+}
+
+func (ttrp *timetableRepository) GetTeachingDays(ctx context.Context,uniId uuid.UUID)([]sqlc.GetTeachingDaysRow,error){
+	return ttrp.tmtq.GetTeachingDays(ctx,uniId)
+}
+
+// swaps the teaching days of a university for the given ones and sets its slot length in one transaction
+func (ttrp *timetableRepository) ReplaceTeachingWeek(ctx context.Context,uniId uuid.UUID,slotMinutes int32,days []sqlc.CreateTeachingDayParams)error{
+	return ttrp.store.ExecTx(ctx,func(q *sqlc.Queries)error{
+		if err := q.DeleteTeachingDays(ctx,uniId); err != nil{
+			return err
+		}
+		for _,day := range days{
+			if err := q.CreateTeachingDay(ctx,day); err != nil{
+				return err
+			}
+		}
+		return q.SetTimetableSlotMinutes(ctx,sqlc.SetTimetableSlotMinutesParams{
+			UniversityID: uniId,
+			SlotMinutes: slotMinutes,
+		})
+	})
+}
+
+func (ttrp *timetableRepository) UpsertSessionPin(ctx context.Context,params sqlc.UpsertSessionPinParams)(uuid.UUID,error){
+	return ttrp.tmtq.UpsertSessionPin(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrieveSessionPins(ctx context.Context,uniId uuid.UUID)([]sqlc.GetSessionPinsRow,error){
+	return ttrp.tmtq.GetSessionPins(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) DeleteSessionPin(ctx context.Context,params sqlc.DeleteSessionPinParams)error{
+	return ttrp.tmtq.DeleteSessionPin(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrievePublishedCandidate(ctx context.Context,params sqlc.GetPublishedCandidateParams)(sqlc.Candidate,error){
+	return ttrp.tmtq.GetPublishedCandidate(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrieveLatestPublishedCandidate(ctx context.Context,uniId uuid.UUID)(sqlc.Candidate,error){
+	return ttrp.tmtq.GetLatestPublishedCandidate(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) RetrieveCurrentSession(ctx context.Context,uniId uuid.UUID)(sql.NullString,error){
+	return ttrp.tmtq.GetUniversityCurrentSession(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) RetrieveUserUniversity(ctx context.Context,userId uuid.UUID)(uuid.NullUUID,error){
+	return ttrp.tmtq.GetUserUniversity(ctx,userId)
+}
+
+func (ttrp *timetableRepository) RetrieveCandidate(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)(sqlc.Candidate,error){
+	return ttrp.tmtq.GetCandidateById(ctx,sqlc.GetCandidateByIdParams{
+		ID: candidateId,
+		UniversityID: uniId,
+	})
+}
+
+func (ttrp *timetableRepository) RetrieveCandidates(ctx context.Context,uniId uuid.UUID)([]sqlc.Candidate,error){
+	return ttrp.tmtq.ListCandidates(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) RetrieveCandidateSessions(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateSessionsRow,error){
+	return ttrp.tmtq.GetCandidateSessions(ctx,candidateId)
+}
+
+func (ttrp *timetableRepository) RetrieveCandidateCohortSessions(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateCohortSessionsRow,error){
+	return ttrp.tmtq.GetCandidateCohortSessions(ctx,candidateId)
+}
+
+// archives the candidate published for the term of the given one and publishes it in its place,
+// the timetables of other terms stay published
+func (ttrp *timetableRepository) PublishCandidate(ctx context.Context,candidate sqlc.Candidate,adminId uuid.NullUUID)error{
+	return ttrp.store.ExecTx(ctx,func(q *sqlc.Queries)error{
+		if err := q.ArchivePublishedCandidate(ctx,sqlc.ArchivePublishedCandidateParams{
+			UniversityID: candidate.UniversityID,
+			AcademicSession: candidate.AcademicSession,
+			Semester: candidate.Semester,
+		}); err != nil{
+			return err
+		}
+		published,err := q.PublishCandidate(ctx,sqlc.PublishCandidateParams{
+			ID: candidate.ID,
+			UniversityID: candidate.UniversityID,
+			PublishedBy: adminId,
+		})
+		if err != nil{
+			return err
+		}
+		// the candidate is gone or belongs to another university, rolling back keeps the one archived above published
+		if published == 0{
+			return sql.ErrNoRows
+		}
+		return nil
+	})
+}
+
+func (ttrp *timetableRepository) UpdateCandidateStatus(ctx context.Context,params sqlc.UpdateCandidateStatusParams)error{
+	return ttrp.tmtq.UpdateCandidateStatus(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrieveCandidateSessionsForFaculty(ctx context.Context,params sqlc.GetCandidateSessionsForFacultyParams)([]sqlc.GetCandidateSessionsForFacultyRow,error){
+	return ttrp.tmtq.GetCandidateSessionsForFaculty(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrieveCandidateSessionsForDepartment(ctx context.Context,params sqlc.GetCandidateSessionsForDepartmentParams)([]sqlc.GetCandidateSessionsForDepartmentRow,error){
+	return ttrp.tmtq.GetCandidateSessionsForDepartment(ctx,params)
+}
+
+func (ttrp *timetableRepository) UpsertCandidateReview(ctx context.Context,params sqlc.UpsertCandidateReviewParams)(uuid.UUID,error){
+	return ttrp.tmtq.UpsertCandidateReview(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrieveCandidateReviews(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateReviewsRow,error){
+	return ttrp.tmtq.GetCandidateReviews(ctx,candidateId)
+}
+
+func (ttrp *timetableRepository) RetrieveDean(ctx context.Context,deanId uuid.UUID)(sqlc.RetrieveDeanRow,error){
+	return ttrp.tmtq.RetrieveDean(ctx,deanId)
+}
+
+func (ttrp *timetableRepository) RetrieveHod(ctx context.Context,hodId uuid.UUID)(sqlc.RetrieveHodRow,error){
+	return ttrp.tmtq.RetrieveHod(ctx,hodId)
+}
+
+// the department and faculty of every course, what a scoped generation rebuilds is decided by them
+func (ttrp *timetableRepository) RetrieveCourseDepartments(ctx context.Context,uniId uuid.UUID)([]sqlc.GetCourseDepartmentsRow,error){
+	return ttrp.tmtq.GetCourseDepartments(ctx,uniId)
+}
+
+// the walking times a university set between venues, they replace the ones worked out from coordinates
+func (ttrp *timetableRepository) RetrieveVenueTravelTimes(ctx context.Context,uniId uuid.UUID)([]sqlc.GetVenueTravelTimesRow,error){
+	return ttrp.tmtq.GetVenueTravelTimes(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) UpsertVenueTravelTime(ctx context.Context,params sqlc.UpsertVenueTravelTimeParams)error{
+	return ttrp.tmtq.UpsertVenueTravelTime(ctx,params)
+}
+
+func (ttrp *timetableRepository) DeleteVenueTravelTime(ctx context.Context,params sqlc.DeleteVenueTravelTimeParams)error{
+	return ttrp.tmtq.DeleteVenueTravelTime(ctx,params)
+}
+
+// the courses of every elective group of the university, only the ones of the semester when it is set
+func (ttrp *timetableRepository) RetrieveElectiveGroupCourses(ctx context.Context,uniId uuid.UUID,semester string)([]sqlc.RetrieveElectiveGroupCoursesRow,error){
+	return ttrp.tmtq.RetrieveElectiveGroupCourses(ctx,sqlc.RetrieveElectiveGroupCoursesParams{
+		UniversityID: uniId,
+		Semester: semester,
+	})
+}
+
+// the pairs of courses of the same elective group some student takes both of
+func (ttrp *timetableRepository) RetrieveElectiveStudentOverlaps(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveElectiveStudentOverlapsRow,error){
+	return ttrp.tmtq.RetrieveElectiveStudentOverlaps(ctx,uniId)
+}
+
+func (ttrp *timetableRepository) RetrieveCandidateSessionPlacements(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateSessionPlacementsRow,error){
+	return ttrp.tmtq.GetCandidateSessionPlacements(ctx,candidateId)
+}
+
+func (ttrp *timetableRepository) RetrieveMovedSessions(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)([]sqlc.GetMovedSessionsRow,error){
+	return ttrp.tmtq.GetMovedSessions(ctx,sqlc.GetMovedSessionsParams{
+		CandidateID: candidateId,
+		UniversityID: uniId,
+	})
+}
+
+func (ttrp *timetableRepository) RetrieveCandidateFitnessHistory(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetCandidateFitnessHistoryRow,error){
+	return ttrp.tmtq.GetCandidateFitnessHistory(ctx,candidateId)
+}
+
+// how many sessions of every department's courses the candidate placed in venues of the department,
+// of its faculty and in shared venues
+func (ttrp *timetableRepository) RetrieveDepartmentVenueUsage(ctx context.Context,candidateId uuid.UUID)([]sqlc.GetDepartmentVenueUsageRow,error){
+	return ttrp.tmtq.GetDepartmentVenueUsage(ctx,candidateId)
+}
+
+// the kinds of session of every course of the university, only the courses of the semester when it is set
+func (ttrp *timetableRepository) RetrieveCourseComponents(ctx context.Context,uniId uuid.UUID,semester string)([]sqlc.RetrieveCourseComponentsForUniRow,error){
+	return ttrp.tmtq.RetrieveCourseComponentsForUni(ctx,sqlc.RetrieveCourseComponentsForUniParams{
+		UniversityID: uniId,
+		Semester: semester,
+	})
+}
+
+func (ttrp *timetableRepository) FetchSessionsForAVenue(ctx context.Context,params sqlc.GetVenueSessionsInCurrentTimetableParams)([]sqlc.GetVenueSessionsInCurrentTimetableRow,error){
+	return ttrp.tmtq.GetVenueSessionsInCurrentTimetable(ctx,params)
+}
+
+func (ttrp *timetableRepository) RetrieveVenueFeatures(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveVenueFeaturesForUniRow,error){
+	return ttrp.tmtq.RetrieveVenueFeaturesForUni(ctx,uniId)
+}
+
+// every faculty_venues and dept_venues row of the university, one of the two ids is set on each
+func (ttrp *timetableRepository) RetrieveVenueOwners(ctx context.Context,uniId uuid.UUID)([]sqlc.RetrieveVenueOwnersForUniRow,error){
+	return ttrp.tmtq.RetrieveVenueOwnersForUni(ctx,uniId)
+}
+
+// the features the courses of the university need, only the courses of the semester when it is set
+func (ttrp *timetableRepository) RetrieveCourseRequiredFeatures(ctx context.Context,uniId uuid.UUID,semester string)([]sqlc.RetrieveCourseRequiredFeaturesForUniRow,error){
+	return ttrp.tmtq.RetrieveCourseRequiredFeaturesForUni(ctx,sqlc.RetrieveCourseRequiredFeaturesForUniParams{
+		UniversityID: uniId,
+		Semester: semester,
+	})
+}
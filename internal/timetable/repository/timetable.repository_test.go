@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+// a database with every migration applied, the tests that need one are skipped without it
+func testDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+	connStr := os.Getenv("TEST_DATABASE_URL")
+	if connStr == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sqlc.NewDatabase(connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.CloseConnection() })
+	return db.DB
+}
+
+// a university of its own so the test never touches anything else, deleted with its candidates
+func testUniversity(t *testing.T, db *sql.DB) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	suffix := uuid.NewString()[:8]
+	uni, err := sqlc.New(db).CreateUniversity(ctx, sqlc.CreateUniversityParams{
+		UniversityName: "test " + suffix,
+		Email:          suffix + "@test.invalid",
+		PhoneNumber:    fmt.Sprintf("%d", time.Now().UnixNano()%1e12),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.ExecContext(context.Background(), "DELETE FROM universities WHERE university_id = $1", uni.UniversityID); err != nil {
+			t.Error(err)
+		}
+	})
+	return uni.UniversityID
+}
+
+// saving a generated or a repaired draft archives the drafts of its term but not the candidate
+// the deans and HODs are reviewing
+func TestSavingADraftKeepsCandidatesUnderReview(t *testing.T) {
+	db := testDatabase(t)
+	q := sqlc.New(db)
+	repo := NewtimeTableRepository(nil, nil, nil, nil, nil, sqlc.NewStore(db))
+
+	saves := map[string]func(ctx context.Context, candidateData sqlc.CreateCandidateParams) error{
+		"generated": func(ctx context.Context, candidateData sqlc.CreateCandidateParams) error {
+			return repo.CreateACandidateTimeTable(ctx, candidateData, nil, nil)
+		},
+		"repaired": func(ctx context.Context, candidateData sqlc.CreateCandidateParams) error {
+			_, err := repo.CreateARepairedCandidate(ctx, candidateData, nil, nil)
+			return err
+		},
+	}
+	for name, save := range saves {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			uniId := testUniversity(t, db)
+			candidate := func(status string) sqlc.CreateCandidateParams {
+				return sqlc.CreateCandidateParams{
+					UniversityID:    uniId,
+					CandidateStatus: status,
+					StartOfDay:      time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC),
+					EndOfDay:        time.Date(2026, 1, 5, 18, 0, 0, 0, time.UTC),
+					AcademicSession: sql.NullString{String: "2025/2026", Valid: true},
+					Semester:        sql.NullString{String: "First", Valid: true},
+				}
+			}
+			draft, err := q.CreateCandidate(ctx, candidate("DRAFT"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			underReview, err := q.CreateCandidate(ctx, candidate("UNDER_REVIEW"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := save(ctx, candidate("DRAFT")); err != nil {
+				t.Fatal(err)
+			}
+
+			for id, want := range map[uuid.UUID]string{draft.ID: "ARCHIVED", underReview.ID: "UNDER_REVIEW"} {
+				got, err := q.GetCandidateById(ctx, sqlc.GetCandidateByIdParams{ID: id, UniversityID: uniId})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.CandidateStatus != want {
+					t.Errorf("candidate %s is %s after a new draft of its term was saved, want %s", id, got.CandidateStatus, want)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package routes
 
 import (
+	authMiddleware "github.com/Cxons/unischedulebackend/internal/auth/middleware"
+	regHandler "github.com/Cxons/unischedulebackend/internal/registration/handler"
+	regMiddleware "github.com/Cxons/unischedulebackend/internal/registration/middleware"
 	timetableHandler "github.com/Cxons/unischedulebackend/internal/timetable/handler"
 	"github.com/go-chi/chi/v5"
 )
@@ -9,11 +12,92 @@ import (
 
 
 
-func Routes (timetableHandler timetableHandler.TimetableHandler)chi.Router{
+func Routes (timetableHandler timetableHandler.TimetableHandler,regHandler regHandler.RegHandler)chi.Router{
 	r := chi.NewRouter()
 
 	r.Post("/",timetableHandler.CreateATimeTable)
 	r.Get("/cohort",timetableHandler.FetchTimetableForCohort)
+	r.Get("/venue",timetableHandler.FetchTimetableForVenue)
+	r.Get("/settings",timetableHandler.FetchTimetableSettings)
+	r.Get("/constraints",timetableHandler.FetchTimetableConstraints)
+	r.Get("/travel",timetableHandler.FetchVenueTravelTimes)
+	r.Get("/week",timetableHandler.FetchTeachingWeek)
+	r.Post("/benchmark",timetableHandler.BenchmarkSolvers)
+	r.Post("/export",timetableHandler.ExportProblem)
+	r.Get("/repair/moved",timetableHandler.FetchMovedSessions)
+	r.Get("/violations",timetableHandler.FetchCandidateViolations)
+	r.Get("/candidates",timetableHandler.FetchCandidates)
+	r.Get("/candidates/diff",timetableHandler.DiffCandidates)
+	r.Get("/candidate",timetableHandler.FetchCandidate)
+	r.Get("/candidate/fitness",timetableHandler.FetchCandidateFitnessHistory)
+	r.Get("/candidate/venues",timetableHandler.FetchDepartmentVenueUsage)
+	r.Get("/pins",timetableHandler.FetchSessionPins)
+
+	r.Route("/pin",func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Use(regMiddleware.HodMiddleware(regHandler.RegService))
+		r.Post("/",timetableHandler.PinSession)
+		r.Delete("/",timetableHandler.DeleteSessionPin)
+	})
+
+	r.Get("/reviews",timetableHandler.FetchCandidateReviews)
+
+	// admins, deans and HODs all start generations, so any signed in user polls and cancels the
+	// jobs of their university
+	r.Route("/job",func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Get("/",timetableHandler.FetchTimetableJob)
+		r.Post("/cancel",timetableHandler.CancelTimetableJob)
+	})
+
+	// only an admin changes how the timetables of the university are generated
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Use(regMiddleware.AdminMiddleware(regHandler.RegService))
+		r.Post("/settings",timetableHandler.UpdateTimetableSettings)
+		r.Post("/constraints",timetableHandler.UpdateTimetableConstraint)
+		r.Post("/week",timetableHandler.UpdateTeachingWeek)
+		r.Post("/travel",timetableHandler.SetVenueTravelTime)
+		r.Delete("/travel",timetableHandler.DeleteVenueTravelTime)
+	})
+
+	// only an admin repairs the published timetable, moves a candidate to review, publishes it and
+	// rolls back to an older one
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Use(regMiddleware.AdminMiddleware(regHandler.RegService))
+		r.Post("/repair",timetableHandler.RepairTimetable)
+		r.Post("/candidate/submit",timetableHandler.SubmitCandidateForReview)
+		r.Post("/candidate/publish",timetableHandler.PublishCandidate)
+		r.Post("/candidate/promote",timetableHandler.PromoteCandidate)
+	})
+
+	// a dean or HOD rebuilds their own part of the published timetable
+	r.Route("/scoped/dean",func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Use(regMiddleware.DeanMiddleware(regHandler.RegService))
+		r.Post("/",timetableHandler.CreateAFacultyTimeTable)
+	})
+
+	r.Route("/scoped/hod",func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Use(regMiddleware.HodMiddleware(regHandler.RegService))
+		r.Post("/",timetableHandler.CreateADepartmentTimeTable)
+	})
+
+	r.Route("/review/dean",func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Use(regMiddleware.DeanMiddleware(regHandler.RegService))
+		r.Get("/",timetableHandler.FetchCandidateForDeanReview)
+		r.Post("/",timetableHandler.DeanReviewCandidate)
+	})
+
+	r.Route("/review/hod",func(r chi.Router) {
+		r.Use(authMiddleware.JwtMiddleware())
+		r.Use(regMiddleware.HodMiddleware(regHandler.RegService))
+		r.Get("/",timetableHandler.FetchCandidateForHodReview)
+		r.Post("/",timetableHandler.HodReviewCandidate)
+	})
 
 	return r
 }
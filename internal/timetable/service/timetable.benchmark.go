@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+// runs the chosen solvers one after another in a background job on the same data of a university
+// with the same seed and compares what they produced, nothing is saved
+func (tts *timeTableService) BenchmarkSolvers(ctx context.Context, body timetableDto.BenchmarkSolversDto) (timeTableResponse, string, error) {
+	week, err := tts.loadTeachingWeek(ctx, body.UniversityId, body.StartTime, body.EndTime)
+	if err != nil {
+		if errors.Is(err, errInvalidTeachingWeek) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error loading teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	gaParams, err := tts.resolveGAParams(ctx, body.UniversityId, body.Params)
+	if err != nil {
+		if errors.Is(err, errInvalidGAParams) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error resolving genetic algorithm params", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	names := body.Solvers
+	if len(names) == 0 {
+		names = []string{computed.SolverGenetic, computed.SolverAnnealing, computed.SolverGeneticTabu}
+	}
+	solvers := make([]computed.Solver, 0, len(names))
+	for _, name := range names {
+		solver, err := computed.NewSolver(name, gaParams)
+		if err != nil {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		solvers = append(solvers, solver)
+	}
+
+	// runs as a job of the university so a benchmark and a generation do not compete for the cpu,
+	// the results are polled from the job
+	job, jobCtx, created := tts.jobs.create(TimetableJob{UniversityId: body.UniversityId, Semester: body.Semester, Seed: gaParams.Seed})
+	if !created {
+		return timeTableResponse{}, status.Conflict.Message, errors.New("a timetable is already being generated for this university")
+	}
+	go tts.runJob(jobCtx, job.JobId, func(ctx context.Context) error {
+		return tts.benchmarkSolvers(ctx, job.JobId, body, week, gaParams.Seed, solvers)
+	})
+
+	return timeTableResponse{
+		Message:           "Solver benchmark started",
+		Data:              toJobResponse(job),
+		StatusCode:        status.Accepted.Code,
+		StatusCodeMessage: status.Accepted.Message,
+	}, status.Accepted.Message, nil
+}
+
+// the work of a benchmark job, what the solvers that finished produced is kept on the job even
+// when it is cancelled
+func (tts *timeTableService) benchmarkSolvers(ctx context.Context, jobId uuid.UUID, body timetableDto.BenchmarkSolversDto, week computed.TeachingWeek, seed int64, solvers []computed.Solver) error {
+	pre, _, _, _, _, err := tts.computed.ComputePreComputed(ctx, body.UniversityId, body.Semester, week)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	constraintSettings, err := tts.loadConstraintSettings(ctx, body.UniversityId)
+	if err != nil {
+		return err
+	}
+	pre.Constraints, err = computed.BuildConstraints(constraintSettings)
+	if err != nil {
+		return err
+	}
+
+	results := computed.BenchmarkSolvers(ctx, pre, solvers)
+
+	resp := timetableDto.BenchmarkSolversResponse{
+		UniversityId: body.UniversityId,
+		Seed:         seed,
+		Sessions:     len(pre.SessionAtoms),
+		Results:      make([]timetableDto.SolverBenchmarkResponse, 0, len(results)),
+	}
+	for _, result := range results {
+		entry := timetableDto.SolverBenchmarkResponse{
+			Solver:         result.Solver,
+			Fitness:        result.Fitness,
+			HardPenalty:    result.HardPenalty,
+			SoftPenalty:    result.SoftPenalty,
+			HardViolations: result.HardViolations,
+			Seconds:        result.Duration.Seconds(),
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		}
+		tts.logger.Info("solver benchmarked", "universityId", body.UniversityId, "solver", result.Solver, "fitness", result.Fitness, "hardViolations", result.HardViolations, "seconds", entry.Seconds)
+		resp.Results = append(resp.Results, entry)
+	}
+	tts.jobs.update(jobId, func(job *TimetableJob) {
+		job.Benchmark = &resp
+	})
+	return ctx.Err()
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+// the default constraints with whatever the university has saved laid over them
+func (tts *timeTableService) loadConstraintSettings(ctx context.Context, uniId uuid.UUID) ([]computed.ConstraintSetting, error) {
+	rows, err := tts.repo.GetTimetableConstraints(ctx, uniId)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := make(map[string]sqlc.TimetableConstraint)
+	for _, row := range rows {
+		saved[row.ConstraintName] = row
+	}
+
+	settings := computed.DefaultConstraintSettings()
+	for i, setting := range settings {
+		row, ok := saved[setting.Name]
+		if !ok {
+			continue
+		}
+		settings[i].Enabled = row.Enabled
+		settings[i].Weight = row.Weight
+		settings[i].LimitValue = row.LimitValue
+		settings[i].Hard = row.Hard
+	}
+	return settings, nil
+}
+
+func (tts *timeTableService) RetrieveTimetableConstraints(ctx context.Context, uniId uuid.UUID) (timeTableResponse, string, error) {
+	settings, err := tts.loadConstraintSettings(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving timetable constraints", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	constraints := make([]timetableDto.TimetableConstraintResponse, 0, len(settings))
+	for _, setting := range settings {
+		constraints = append(constraints, timetableDto.TimetableConstraintResponse{
+			Name:       setting.Name,
+			Enabled:    setting.Enabled,
+			Weight:     setting.Weight,
+			LimitValue: setting.LimitValue,
+			Hard:       setting.Hard,
+		})
+	}
+
+	return timeTableResponse{
+		Message:           "Timetable constraints retrieved successfully",
+		Data:              constraints,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+func (tts *timeTableService) UpdateTimetableConstraint(ctx context.Context, body timetableDto.TimetableConstraintDto) (timeTableResponse, string, error) {
+	if !computed.IsKnownConstraint(body.Name) {
+		return timeTableResponse{}, status.BadRequest.Message, fmt.Errorf("unknown constraint %q", body.Name)
+	}
+	if body.Weight < 0 {
+		return timeTableResponse{}, status.BadRequest.Message, fmt.Errorf("constraint weight cannot be negative")
+	}
+
+	row, err := tts.repo.UpsertTimetableConstraint(ctx, sqlc.UpsertTimetableConstraintParams{
+		UniversityID:   body.UniversityId,
+		ConstraintName: body.Name,
+		Enabled:        body.Enabled,
+		Weight:         body.Weight,
+		LimitValue:     body.LimitValue,
+		Hard:           body.Hard,
+	})
+	if err != nil {
+		tts.logger.Error("error updating timetable constraint", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	return timeTableResponse{
+		Message: "Timetable constraint updated successfully",
+		Data: timetableDto.TimetableConstraintResponse{
+			Name:       row.ConstraintName,
+			Enabled:    row.Enabled,
+			Weight:     row.Weight,
+			LimitValue: row.LimitValue,
+			Hard:       row.Hard,
+		},
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+)
+
+// the live data of a university with its pins, constraints, solver and params as a problem file,
+// so a reported timetable can be solved again with cmd/ttsolve without the database
+func (tts *timeTableService) ExportProblem(ctx context.Context, body timetableDto.ExportProblemDto) (timeTableResponse, string, error) {
+	week, err := tts.loadTeachingWeek(ctx, body.UniversityId, body.StartTime, body.EndTime)
+	if err != nil {
+		if errors.Is(err, errInvalidTeachingWeek) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error loading teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	gaParams, err := tts.resolveGAParams(ctx, body.UniversityId, body.Params)
+	if err != nil {
+		if errors.Is(err, errInvalidGAParams) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error resolving genetic algorithm params", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	solver, err := tts.resolveSolver(ctx, body.UniversityId, body.Solver, gaParams)
+	if err != nil {
+		if errors.Is(err, errUnknownSolver) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error resolving solver", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	pre, cohortMap, venueMap, lecturerMap, coursesMap, err := tts.computed.ComputePreComputed(ctx, body.UniversityId, body.Semester, week)
+	if err != nil {
+		if errors.Is(err, computed.ErrInvalidPin) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error computing timetable data", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	constraintSettings, err := tts.loadConstraintSettings(ctx, body.UniversityId)
+	if err != nil {
+		tts.logger.Error("error loading constraint settings", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	problem, err := computed.NewProblem(pre, week, constraintSettings,
+		computed.IdsByIdx(venueMap, pre.NumVenues),
+		computed.IdsByIdx(lecturerMap, pre.NumLecturers),
+		computed.IdsByIdx(cohortMap, pre.NumCohorts),
+		computed.IdsByIdx(coursesMap, pre.NumCourses))
+	if err != nil {
+		tts.logger.Error("error building the problem file", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	problem.Solver = solver.Name()
+	problem.Params = computed.ToProblemParams(gaParams)
+
+	return timeTableResponse{
+		Message:           "Timetable problem exported successfully",
+		Data:              problem,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+const (
+	SessionMoved        = "MOVED"
+	SessionVenueChanged = "VENUE_CHANGED"
+	SessionTimeChanged  = "TIME_CHANGED"
+	SessionAdded        = "ADDED"
+	SessionRemoved      = "REMOVED"
+)
+
+func toCandidateResponse(candidate sqlc.Candidate) timetableDto.CandidateResponse {
+	resp := timetableDto.CandidateResponse{
+		CandidateId:     candidate.ID,
+		Status:          candidate.CandidateStatus,
+		Fitness:         candidate.Fitness,
+		StartOfDay:      candidate.StartOfDay,
+		EndOfDay:        candidate.EndOfDay,
+		CreatedAt:       candidate.CreatedAt.Time,
+		RepairedFrom:    candidate.RepairedFrom,
+		PublishedBy:     candidate.PublishedBy,
+		AcademicSession: candidate.AcademicSession.String,
+		Semester:        candidate.Semester.String,
+		Solver:          candidate.Solver.String,
+		StopReason:      candidate.StopReason.String,
+	}
+	if candidate.PublishedAt.Valid {
+		resp.PublishedAt = &candidate.PublishedAt.Time
+	}
+	if candidate.Seed.Valid {
+		resp.Seed = &candidate.Seed.Int64
+	}
+	if candidate.PopulationSize.Valid {
+		resp.PopulationSize = &candidate.PopulationSize.Int32
+	}
+	if candidate.Generations.Valid {
+		resp.Generations = &candidate.Generations.Int32
+	}
+	if candidate.MutationRate.Valid {
+		resp.MutationRate = &candidate.MutationRate.Float64
+	}
+	if candidate.TournamentSize.Valid {
+		resp.TournamentSize = &candidate.TournamentSize.Int32
+	}
+	if candidate.ElitismFraction.Valid {
+		resp.ElitismFraction = &candidate.ElitismFraction.Float64
+	}
+	if candidate.Workers.Valid {
+		resp.Workers = &candidate.Workers.Int32
+	}
+	if candidate.GenerationsRun.Valid {
+		resp.GenerationsRun = &candidate.GenerationsRun.Int32
+	}
+	return resp
+}
+
+func toCandidateSessionResponse(row sqlc.GetCandidateSessionsRow) timetableDto.CandidateSessionResponse {
+	group, groups := sessionGroup(row.GroupIdx, row.GroupCount)
+	return timetableDto.CandidateSessionResponse{
+		SessionIdx:  row.SessionIdx,
+		CourseId:    row.CourseID,
+		CourseCode:  row.CourseCode,
+		CourseTitle: row.CourseTitle,
+		VenueId:     row.VenueID,
+		VenueName:   row.VenueName,
+		Day:         row.Day,
+		StartTime:   row.SessionTime.UTC().Format("15:04"),
+		Conflict:    row.Conflict,
+		SessionType: row.SessionType,
+		Group:       group,
+		Groups:      groups,
+	}
+}
+
+func (tts *timeTableService) retrieveCandidate(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID) (sqlc.Candidate, string, error) {
+	candidate, err := tts.repo.RetrieveCandidate(ctx, candidateId, uniId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.Candidate{}, status.NotFound.Message, errors.New("candidate not found")
+		}
+		tts.logger.Error("error retrieving candidate", "err", err)
+		return sqlc.Candidate{}, status.InternalServerError.Message, err
+	}
+	return candidate, status.OK.Message, nil
+}
+
+// every candidate of the university, latest first
+func (tts *timeTableService) RetrieveCandidates(ctx context.Context, uniId uuid.UUID) (timeTableResponse, string, error) {
+	candidates, err := tts.repo.RetrieveCandidates(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving candidates", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	resp := make([]timetableDto.CandidateResponse, 0, len(candidates))
+	for _, candidate := range candidates {
+		resp = append(resp, toCandidateResponse(candidate))
+	}
+
+	return timeTableResponse{
+		Message:           "Candidates retrieved successfully",
+		Data:              resp,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// a candidate with all of its sessions, whatever its status
+func (tts *timeTableService) RetrieveCandidate(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID) (timeTableResponse, string, error) {
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, candidateId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	rows, err := tts.repo.RetrieveCandidateSessions(ctx, candidate.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate sessions", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	sessions := make([]timetableDto.CandidateSessionResponse, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, toCandidateSessionResponse(row))
+	}
+
+	return timeTableResponse{
+		Message: "Candidate retrieved successfully",
+		Data: timetableDto.CandidateDetailResponse{
+			Candidate: toCandidateResponse(candidate),
+			Sessions:  sessions,
+		},
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// the best and mean fitness of every generation of the run that produced a candidate, empty for
+// candidates that did not come from a genetic run
+func (tts *timeTableService) RetrieveCandidateFitnessHistory(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID) (timeTableResponse, string, error) {
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, candidateId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	rows, err := tts.repo.RetrieveCandidateFitnessHistory(ctx, candidate.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate fitness history", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	history := make([]timetableDto.GenerationFitnessResponse, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, timetableDto.GenerationFitnessResponse{
+			Generation:  row.Generation,
+			BestFitness: row.BestFitness,
+			MeanFitness: row.MeanFitness,
+		})
+	}
+	summary := toCandidateResponse(candidate)
+
+	return timeTableResponse{
+		Message: "Candidate fitness history retrieved successfully",
+		Data: timetableDto.CandidateFitnessHistoryResponse{
+			CandidateId:    candidate.ID,
+			Solver:         summary.Solver,
+			Generations:    summary.Generations,
+			GenerationsRun: summary.GenerationsRun,
+			StopReason:     summary.StopReason,
+			History:        history,
+		},
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// how the nth session of a course changed between two candidates, empty if it did not
+func sessionChange(from *sqlc.GetCandidateCohortSessionsRow, to *sqlc.GetCandidateCohortSessionsRow) string {
+	switch {
+	case from == nil:
+		return SessionAdded
+	case to == nil:
+		return SessionRemoved
+	}
+	timeChanged := from.Day != to.Day || from.SessionTime.UTC().Format("15:04") != to.SessionTime.UTC().Format("15:04")
+	venueChanged := from.VenueID != to.VenueID
+	switch {
+	case timeChanged && venueChanged:
+		return SessionMoved
+	case venueChanged:
+		return SessionVenueChanged
+	case timeChanged:
+		return SessionTimeChanged
+	}
+	return ""
+}
+
+// compares two candidates cohort by cohort, the nth session of a course in one is matched with
+// the nth session of the course in the other
+func diffCandidateSessions(from []sqlc.GetCandidateCohortSessionsRow, to []sqlc.GetCandidateCohortSessionsRow) ([]timetableDto.CohortDiffResponse, map[string]int) {
+	type cohortSessions struct {
+		name    string
+		from    map[uuid.UUID][]sqlc.GetCandidateCohortSessionsRow
+		to      map[uuid.UUID][]sqlc.GetCandidateCohortSessionsRow
+		codes   map[uuid.UUID]string
+		courses []uuid.UUID
+	}
+	cohorts := make(map[uuid.UUID]*cohortSessions)
+	cohortIds := make([]uuid.UUID, 0)
+	add := func(rows []sqlc.GetCandidateCohortSessionsRow, isFrom bool) {
+		for _, row := range rows {
+			cohort, ok := cohorts[row.CohortID]
+			if !ok {
+				cohort = &cohortSessions{
+					name:  row.CohortName,
+					from:  make(map[uuid.UUID][]sqlc.GetCandidateCohortSessionsRow),
+					to:    make(map[uuid.UUID][]sqlc.GetCandidateCohortSessionsRow),
+					codes: make(map[uuid.UUID]string),
+				}
+				cohorts[row.CohortID] = cohort
+				cohortIds = append(cohortIds, row.CohortID)
+			}
+			if _, ok := cohort.codes[row.CourseID]; !ok {
+				cohort.codes[row.CourseID] = row.CourseCode
+				cohort.courses = append(cohort.courses, row.CourseID)
+			}
+			if isFrom {
+				cohort.from[row.CourseID] = append(cohort.from[row.CourseID], row)
+			} else {
+				cohort.to[row.CourseID] = append(cohort.to[row.CourseID], row)
+			}
+		}
+	}
+	add(from, true)
+	add(to, false)
+	sort.SliceStable(cohortIds, func(i, j int) bool {
+		return cohorts[cohortIds[i]].name < cohorts[cohortIds[j]].name
+	})
+
+	// a session shared by several cohorts is only counted once
+	type sessionKey struct {
+		courseId uuid.UUID
+		n        int
+	}
+	changed := make(map[sessionKey]string)
+
+	diffs := make([]timetableDto.CohortDiffResponse, 0)
+	for _, cohortId := range cohortIds {
+		cohort := cohorts[cohortId]
+		sort.SliceStable(cohort.courses, func(i, j int) bool {
+			return cohort.codes[cohort.courses[i]] < cohort.codes[cohort.courses[j]]
+		})
+		diff := timetableDto.CohortDiffResponse{CohortId: cohortId, CohortName: cohort.name, Changes: []timetableDto.SessionChangeResponse{}}
+		for _, courseId := range cohort.courses {
+			fromRows, toRows := cohort.from[courseId], cohort.to[courseId]
+			for n := 0; n < max(len(fromRows), len(toRows)); n++ {
+				var f, t *sqlc.GetCandidateCohortSessionsRow
+				if n < len(fromRows) {
+					f = &fromRows[n]
+				}
+				if n < len(toRows) {
+					t = &toRows[n]
+				}
+				change := sessionChange(f, t)
+				if change == "" {
+					continue
+				}
+				changed[sessionKey{courseId: courseId, n: n}] = change
+
+				entry := timetableDto.SessionChangeResponse{
+					CourseId:      courseId,
+					CourseCode:    cohort.codes[courseId],
+					SessionNumber: n + 1,
+					Change:        change,
+				}
+				if f != nil {
+					entry.FromDay = f.Day
+					entry.FromTime = f.SessionTime.UTC().Format("15:04")
+					entry.FromVenueId = uuid.NullUUID{UUID: f.VenueID, Valid: true}
+					entry.FromVenueName = f.VenueName
+				}
+				if t != nil {
+					entry.ToDay = t.Day
+					entry.ToTime = t.SessionTime.UTC().Format("15:04")
+					entry.ToVenueId = uuid.NullUUID{UUID: t.VenueID, Valid: true}
+					entry.ToVenueName = t.VenueName
+				}
+				diff.Changes = append(diff.Changes, entry)
+			}
+		}
+		if len(diff.Changes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, change := range changed {
+		counts[change]++
+	}
+	return diffs, counts
+}
+
+// the sessions that moved, changed venue or changed time going from one candidate to another
+func (tts *timeTableService) DiffCandidates(ctx context.Context, uniId uuid.UUID, fromId uuid.UUID, toId uuid.UUID) (timeTableResponse, string, error) {
+	from, statusMsg, err := tts.retrieveCandidate(ctx, fromId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	to, statusMsg, err := tts.retrieveCandidate(ctx, toId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+
+	fromRows, err := tts.repo.RetrieveCandidateCohortSessions(ctx, from.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate sessions", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	toRows, err := tts.repo.RetrieveCandidateCohortSessions(ctx, to.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate sessions", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	cohorts, counts := diffCandidateSessions(fromRows, toRows)
+
+	return timeTableResponse{
+		Message: "Candidates compared successfully",
+		Data: timetableDto.CandidateDiffResponse{
+			FromCandidateId: from.ID,
+			ToCandidateId:   to.ID,
+			Moved:           counts[SessionMoved],
+			VenueChanged:    counts[SessionVenueChanged],
+			TimeChanged:     counts[SessionTimeChanged],
+			Added:           counts[SessionAdded],
+			Removed:         counts[SessionRemoved],
+			Cohorts:         cohorts,
+		},
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// rolls back to a candidate that was published before, the one published for its term is
+// archived in the same transaction
+func (tts *timeTableService) PromoteCandidate(ctx context.Context, body timetableDto.CandidateActionDto, adminId uuid.UUID) (timeTableResponse, string, error) {
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, body.CandidateId, body.UniversityId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	if candidate.CandidateStatus == CandidatePublished {
+		return timeTableResponse{
+			Message:           "Candidate is already published",
+			Data:              toCandidateResponse(candidate),
+			StatusCode:        status.OK.Code,
+			StatusCodeMessage: status.OK.Message,
+		}, status.OK.Message, nil
+	}
+	// a candidate that was never published goes through review instead
+	if candidate.CandidateStatus != CandidateArchived || !candidate.PublishedAt.Valid {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("only a candidate that was published before can be promoted back")
+	}
+
+	// holds the university so a generation cannot replace the candidate meanwhile
+	release, held := tts.jobs.hold(body.UniversityId)
+	if !held {
+		return timeTableResponse{}, status.Conflict.Message, errors.New("a timetable is already being generated for this university")
+	}
+	defer release()
+
+	publishedBy := uuid.NullUUID{UUID: adminId, Valid: adminId != uuid.Nil}
+	if err := tts.repo.PublishCandidate(context.WithoutCancel(ctx), candidate, publishedBy); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return timeTableResponse{}, status.NotFound.Message, errors.New("candidate not found")
+		}
+		tts.logger.Error("error promoting candidate", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	tts.logger.Info("candidate promoted", "universityId", body.UniversityId, "candidateId", candidate.ID)
+
+	candidate.CandidateStatus = CandidatePublished
+	candidate.PublishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	candidate.PublishedBy = publishedBy
+	return timeTableResponse{
+		Message:           "Candidate promoted back to the published timetable",
+		Data:              toCandidateResponse(candidate),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "PENDING"
+	JobRunning   JobStatus = "RUNNING"
+	JobCompleted JobStatus = "COMPLETED"
+	JobFailed    JobStatus = "FAILED"
+	JobCancelled JobStatus = "CANCELLED"
+)
+
+// a timetable generation running in the background
+type TimetableJob struct {
+	JobId        uuid.UUID
+	UniversityId uuid.UUID
+	Status       JobStatus
+	// the term a generation schedules
+	AcademicSession string
+	Semester        string
+	// the part of the timetable a generation rebuilds and the faculty or department it belongs to
+	Scope       string
+	ScopeId     uuid.NullUUID
+	Seed        int64
+	Generation  int
+	Generations int
+	BestFitness float64
+	Error       string
+	// what the solvers of a benchmark job produced, nil on generations
+	Benchmark  *timetableDto.BenchmarkSolversResponse
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	cancel     context.CancelFunc
+}
+
+// how long the job has been running or ran for
+func (j TimetableJob) Elapsed() time.Duration {
+	if j.StartedAt.IsZero() {
+		return 0
+	}
+	if j.FinishedAt.IsZero() {
+		return time.Since(j.StartedAt)
+	}
+	return j.FinishedAt.Sub(j.StartedAt)
+}
+
+func (j TimetableJob) isFinished() bool {
+	return j.Status == JobCompleted || j.Status == JobFailed || j.Status == JobCancelled
+}
+
+// how long a finished job can still be polled before it is dropped
+const finishedJobTTL = time.Hour
+
+// keeps the jobs in memory so they can be polled and cancelled by id until finishedJobTTL after
+// they finish
+type jobStore struct {
+	mu   sync.RWMutex
+	jobs map[uuid.UUID]*TimetableJob
+	// universities a repair or publish holds outside of any job, no job starts for them meanwhile
+	held map[uuid.UUID]bool
+}
+
+func newJobStore() *jobStore {
+	return &jobStore{
+		jobs: make(map[uuid.UUID]*TimetableJob),
+		held: make(map[uuid.UUID]bool),
+	}
+}
+
+// true if the university has a job that has not finished or is held, the caller holds the lock
+func (js *jobStore) busy(uniId uuid.UUID) bool {
+	if js.held[uniId] {
+		return true
+	}
+	for _, job := range js.jobs {
+		if job.UniversityId == uniId && !job.isFinished() {
+			return true
+		}
+	}
+	return false
+}
+
+// holds the university so no job starts for it and nothing else holds it until release is
+// called, callers defer it so a panic cannot leave the university held. returns false if the
+// university is already busy
+func (js *jobStore) hold(uniId uuid.UUID) (func(), bool) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	if js.busy(uniId) {
+		return nil, false
+	}
+	js.held[uniId] = true
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			js.mu.Lock()
+			defer js.mu.Unlock()
+			delete(js.held, uniId)
+		})
+	}, true
+}
+
+// creates a pending job from what the caller filled in with its own context so it outlives the
+// request that started it. returns false if the university already has a job that has not
+// finished or is held
+func (js *jobStore) create(details TimetableJob) (TimetableJob, context.Context, bool) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	js.evictFinished(time.Now())
+	if js.busy(details.UniversityId) {
+		return TimetableJob{}, nil, false
+	}
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	job := &details
+	job.JobId = uuid.New()
+	job.Status = JobPending
+	job.CreatedAt = time.Now()
+	job.cancel = cancel
+	js.jobs[job.JobId] = job
+	return *job, jobCtx, true
+}
+
+// drops the jobs that finished more than finishedJobTTL ago, the caller holds the lock
+func (js *jobStore) evictFinished(now time.Time) {
+	for jobId, job := range js.jobs {
+		if job.isFinished() && now.Sub(job.FinishedAt) > finishedJobTTL {
+			delete(js.jobs, jobId)
+		}
+	}
+}
+
+// returns a copy of the job so callers can read it without holding the lock. a job of another
+// university is not found
+func (js *jobStore) get(jobId uuid.UUID, uniId uuid.UUID) (TimetableJob, bool) {
+	js.mu.RLock()
+	defer js.mu.RUnlock()
+
+	job, ok := js.jobs[jobId]
+	if !ok || job.UniversityId != uniId {
+		return TimetableJob{}, false
+	}
+	return *job, true
+}
+
+func (js *jobStore) update(jobId uuid.UUID, fn func(job *TimetableJob)) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	if job, ok := js.jobs[jobId]; ok {
+		fn(job)
+	}
+}
+
+// marks the job as finished with the given status and releases its context
+func (js *jobStore) finish(jobId uuid.UUID, status JobStatus, err error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	now := time.Now()
+	if job, ok := js.jobs[jobId]; ok {
+		job.Status = status
+		job.FinishedAt = now
+		if err != nil {
+			job.Error = err.Error()
+		}
+		job.cancel()
+	}
+	js.evictFinished(now)
+}
+
+// cancels the job context, the genetic algorithm picks it up at the next generation. a job of
+// another university is not found
+func (js *jobStore) cancel(jobId uuid.UUID, uniId uuid.UUID) (TimetableJob, bool) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	job, ok := js.jobs[jobId]
+	if !ok || job.UniversityId != uniId {
+		return TimetableJob{}, false
+	}
+	if !job.isFinished() {
+		job.cancel()
+	}
+	return *job, true
+}
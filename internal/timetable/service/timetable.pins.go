@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+func toSessionPinResponse(row sqlc.GetSessionPinsRow) timetableDto.SessionPinResponse {
+	startTime := row.StartTime
+	if startMinutes, err := computed.ParseMinutes(row.StartTime); err == nil {
+		startTime = computed.FormatMinutes(startMinutes)
+	}
+	return timetableDto.SessionPinResponse{
+		PinId:         row.PinID,
+		CourseId:      row.CourseID,
+		CourseCode:    row.CourseCode,
+		SessionNumber: row.SessionNumber,
+		Day:           row.Day,
+		StartTime:     startTime,
+		VenueId:       row.VenueID,
+		VenueName:     row.VenueName,
+		Semester:      row.Semester,
+	}
+}
+
+func (tts *timeTableService) RetrieveSessionPins(ctx context.Context, uniId uuid.UUID) (timeTableResponse, string, error) {
+	rows, err := tts.repo.RetrieveSessionPins(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving pinned sessions", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	pins := make([]timetableDto.SessionPinResponse, 0, len(rows))
+	for _, row := range rows {
+		pins = append(pins, toSessionPinResponse(row))
+	}
+
+	return timeTableResponse{
+		Message:           "Pinned sessions retrieved successfully",
+		Data:              pins,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// pins a session after checking it against the unavailabilities and the other pins of the
+// semester of its course, pinning a session that is already pinned moves it
+func (tts *timeTableService) PinSession(ctx context.Context, body timetableDto.SessionPinDto) (timeTableResponse, string, error) {
+	startMinutes, err := computed.ParseMinutes(body.StartTime)
+	if err != nil {
+		return timeTableResponse{}, status.BadRequest.Message, fmt.Errorf("invalid start time: %v", err)
+	}
+
+	// a pin is only meaningful on a fixed grid so the week has to be configured first
+	days, slotMinutes, err := tts.loadTeachingDays(ctx, body.UniversityId)
+	if err != nil {
+		tts.logger.Error("error loading teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	if len(days) == 0 {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("configure the teaching week of the university before pinning sessions")
+	}
+	week, err := computed.NewTeachingWeek(days, slotMinutes)
+	if err != nil {
+		return timeTableResponse{}, status.BadRequest.Message, fmt.Errorf("%w: %v", errInvalidTeachingWeek, err)
+	}
+
+	// pins of the other semester are never scheduled with this one so they cannot clash
+	courses, err := tts.repo.RetrieveAllCourses(ctx, body.UniversityId)
+	if err != nil {
+		tts.logger.Error("error retrieving all courses", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	semester, found := "", false
+	for _, course := range courses {
+		if course.CourseID == body.CourseId {
+			semester, found = course.Semester, true
+			break
+		}
+	}
+	if !found {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("the course does not belong to this university")
+	}
+
+	rows, err := tts.repo.RetrieveSessionPins(ctx, body.UniversityId)
+	if err != nil {
+		tts.logger.Error("error retrieving pinned sessions", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	existing, err := computed.SessionPinsFromRows(computed.PinsForSemester(rows, semester))
+	if err != nil {
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	pin := computed.SessionPin{
+		CourseId:      body.CourseId,
+		SessionNumber: int(body.SessionNumber),
+		Day:           body.Day,
+		StartMinutes:  startMinutes,
+		VenueId:       body.VenueId,
+	}
+	pins := make([]computed.SessionPin, 0, len(existing)+1)
+	for _, p := range existing {
+		if p.CourseId == pin.CourseId && p.SessionNumber == pin.SessionNumber {
+			continue
+		}
+		pins = append(pins, p)
+	}
+	pins = append(pins, pin)
+
+	if err := tts.computed.CheckSessionPins(ctx, body.UniversityId, semester, week, pins); err != nil {
+		if errors.Is(err, computed.ErrInvalidPin) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error checking pinned sessions", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	pinId, err := tts.repo.UpsertSessionPin(ctx, sqlc.UpsertSessionPinParams{
+		UniversityID:  body.UniversityId,
+		CourseID:      body.CourseId,
+		SessionNumber: body.SessionNumber,
+		Day:           body.Day,
+		StartTime:     computed.FormatMinutes(startMinutes),
+		VenueID:       body.VenueId,
+	})
+	if err != nil {
+		tts.logger.Error("error pinning session", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	resp := timetableDto.SessionPinResponse{PinId: pinId}
+	rows, err = tts.repo.RetrieveSessionPins(ctx, body.UniversityId)
+	if err == nil {
+		for _, row := range rows {
+			if row.PinID == pinId {
+				resp = toSessionPinResponse(row)
+			}
+		}
+	}
+
+	return timeTableResponse{
+		Message:           "Session pinned successfully",
+		Data:              resp,
+		StatusCode:        status.Created.Code,
+		StatusCodeMessage: status.Created.Message,
+	}, status.Created.Message, nil
+}
+
+func (tts *timeTableService) DeleteSessionPin(ctx context.Context, pinId uuid.UUID, uniId uuid.UUID) (timeTableResponse, string, error) {
+	err := tts.repo.DeleteSessionPin(ctx, sqlc.DeleteSessionPinParams{
+		PinID:        pinId,
+		UniversityID: uniId,
+	})
+	if err != nil {
+		tts.logger.Error("error deleting pinned session", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	return timeTableResponse{
+		Message:           "Pinned session deleted successfully",
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+func toMovedSessionResponse(row sqlc.GetMovedSessionsRow) timetableDto.MovedSessionResponse {
+	resp := timetableDto.MovedSessionResponse{
+		SessionIdx:    row.SessionIdx,
+		CourseId:      row.CourseID,
+		CourseCode:    row.CourseCode,
+		FromDay:       row.FromDay.String,
+		FromVenueId:   row.FromVenueID,
+		FromVenueName: row.FromVenueName.String,
+		ToDay:         row.ToDay,
+		ToTime:        row.ToSessionTime.UTC().Format("15:04"),
+		ToVenueId:     row.ToVenueID,
+		ToVenueName:   row.ToVenueName,
+		Reason:        row.Reason,
+	}
+	if row.FromSessionTime.Valid {
+		resp.FromTime = row.FromSessionTime.Time.UTC().Format("15:04")
+	}
+	return resp
+}
+
+// the rows recording where every moved session came from and went to
+func toMovedSessionParams(moved []computed.MovedSession, placements []customSessionPlacement, baseDate time.Time) []sqlc.CreateMovedSessionParams {
+	bySession := make(map[int32]customSessionPlacement)
+	for _, placement := range placements {
+		bySession[placement.SessionIdx] = placement
+	}
+
+	params := make([]sqlc.CreateMovedSessionParams, 0, len(moved))
+	for _, m := range moved {
+		to, ok := bySession[int32(m.SessionIdx)]
+		if !ok {
+			continue
+		}
+		param := sqlc.CreateMovedSessionParams{
+			SessionIdx:    to.SessionIdx,
+			CourseID:      to.CourseId,
+			ToVenueID:     to.VenueId,
+			ToDay:         to.Day,
+			ToSessionTime: to.SessionTime,
+			Reason:        m.Reason,
+		}
+		if m.Previous != nil {
+			param.FromVenueID = uuid.NullUUID{UUID: m.Previous.VenueId, Valid: true}
+			param.FromDay = sql.NullString{String: m.Previous.Day, Valid: true}
+			param.FromSessionTime = sql.NullTime{Time: baseDate.Add(minutesToDuration(m.Previous.StartMinutes)), Valid: true}
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// moves only the sessions of the published timetable that break a constraint after the data
+// changed and saves the result as a new draft
+func (tts *timeTableService) RepairTimetable(ctx context.Context, uniId uuid.UUID, academicSession string, semester string) (timeTableResponse, string, error) {
+	// holds the university so a generation cannot run at the same time
+	release, held := tts.jobs.hold(uniId)
+	if !held {
+		return timeTableResponse{}, status.Conflict.Message, errors.New("a timetable is already being generated for this university")
+	}
+	defer release()
+
+	resp, statusMsg, err := tts.repairTimetable(ctx, uniId, academicSession, semester)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+
+	message := "Timetable repaired successfully"
+	if resp.MovedCount == 0 {
+		message = "Nothing in the published timetable had to move"
+	}
+	return timeTableResponse{
+		Message:           message,
+		Data:              resp,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// a saved candidate and the current data of its university on the week it was generated for
+type savedTimetable struct {
+	week       computed.TeachingWeek
+	baseDate   time.Time
+	pre        *computed.PreComputed
+	venueMap   map[uuid.UUID]int
+	coursesMap map[uuid.UUID]int
+	existing   []computed.ExistingPlacement
+}
+
+// the sessions of a saved candidate with their start times as minutes after midnight
+func (tts *timeTableService) retrieveExistingPlacements(ctx context.Context, candidateId uuid.UUID) ([]computed.ExistingPlacement, error) {
+	rows, err := tts.repo.RetrieveCandidateSessionPlacements(ctx, candidateId)
+	if err != nil {
+		return nil, err
+	}
+	existing := make([]computed.ExistingPlacement, 0, len(rows))
+	for _, row := range rows {
+		existing = append(existing, computed.ExistingPlacement{
+			CourseId:     row.CourseID,
+			SessionIdx:   int(row.SessionIdx),
+			VenueId:      row.VenueID,
+			Day:          row.Day,
+			StartMinutes: computed.MinutesOfDay(row.SessionTime.UTC()),
+		})
+	}
+	return existing, nil
+}
+
+func (tts *timeTableService) loadSavedTimetable(ctx context.Context, uniId uuid.UUID, candidate sqlc.Candidate) (savedTimetable, string, error) {
+	week, err := tts.loadTeachingWeek(ctx, uniId, candidate.StartOfDay.UTC(), candidate.EndOfDay.UTC())
+	if err != nil {
+		if errors.Is(err, errInvalidTeachingWeek) {
+			return savedTimetable{}, status.BadRequest.Message, err
+		}
+		return savedTimetable{}, status.InternalServerError.Message, err
+	}
+
+	// the courses of the term the candidate was generated for, all of them on a candidate without one
+	pre, _, venueMap, _, coursesMap, err := tts.computed.ComputePreComputed(ctx, uniId, candidateTerm(candidate).Semester, week)
+	if err != nil {
+		if errors.Is(err, computed.ErrInvalidPin) {
+			return savedTimetable{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error computing timetable data", "err", err)
+		return savedTimetable{}, status.InternalServerError.Message, err
+	}
+	constraintSettings, err := tts.loadConstraintSettings(ctx, uniId)
+	if err != nil {
+		return savedTimetable{}, status.InternalServerError.Message, err
+	}
+	pre.Constraints, err = computed.BuildConstraints(constraintSettings)
+	if err != nil {
+		return savedTimetable{}, status.InternalServerError.Message, err
+	}
+
+	existing, err := tts.retrieveExistingPlacements(ctx, candidate.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate session placements", "err", err)
+		return savedTimetable{}, status.InternalServerError.Message, err
+	}
+
+	return savedTimetable{
+		week:       week,
+		baseDate:   weekBaseDate(candidate.StartOfDay.UTC()),
+		pre:        pre,
+		venueMap:   venueMap,
+		coursesMap: coursesMap,
+		existing:   existing,
+	}, status.OK.Message, nil
+}
+
+func (tts *timeTableService) repairTimetable(ctx context.Context, uniId uuid.UUID, academicSession string, semester string) (timetableDto.RepairTimetableResponse, string, error) {
+	current, err := tts.resolvePublishedCandidate(ctx, uniId, academicSession, semester)
+	if err != nil {
+		if errors.Is(err, errInvalidTerm) {
+			return timetableDto.RepairTimetableResponse{}, status.BadRequest.Message, err
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return timetableDto.RepairTimetableResponse{}, status.NotFound.Message, errors.New("there is no published timetable to repair")
+		}
+		tts.logger.Error("error retrieving published candidate", "err", err)
+		return timetableDto.RepairTimetableResponse{}, status.InternalServerError.Message, err
+	}
+
+	saved, statusMsg, err := tts.loadSavedTimetable(ctx, uniId, current)
+	if err != nil {
+		return timetableDto.RepairTimetableResponse{}, statusMsg, err
+	}
+	pre, week, baseDate := saved.pre, saved.week, saved.baseDate
+	coursesMap, venueMap := saved.coursesMap, saved.venueMap
+
+	candidate, moved := computed.RepairPlacements(pre, week, saved.existing, coursesMap, venueMap)
+	computed.ComputeCandidateFitness(pre, candidate, nil)
+	computed.MarkConflicts(pre, candidate)
+	tts.logger.Info("timetable repaired", "universityId", uniId, "sessions", len(candidate.Placements), "moved", len(moved))
+
+	resp := timetableDto.RepairTimetableResponse{
+		RepairedFrom: current.ID,
+		Fitness:      candidate.Fitness,
+		HardPenalty:  candidate.HardPenalty,
+		MovedCount:   len(moved),
+		Moved:        []timetableDto.MovedSessionResponse{},
+	}
+	if len(moved) == 0 {
+		return resp, status.OK.Message, nil
+	}
+
+	sessionPlacements := tts.toSessionPlacements(pre, candidate.Placements, coursesMap, venueMap, BuildSlotMap(week, baseDate), uniId)
+	if len(sessionPlacements) == 0 {
+		return timetableDto.RepairTimetableResponse{}, status.InternalServerError.Message, fmt.Errorf("no valid session placements generated")
+	}
+	candidateData := sqlc.CreateCandidateParams{
+		Fitness:         candidate.Fitness,
+		UniversityID:    uniId,
+		CandidateStatus: CandidateDraft,
+		StartOfDay:      baseDate.Add(minutesToDuration(week.GridStartMinutes())),
+		EndOfDay:        baseDate.Add(minutesToDuration(week.GridEndMinutes())),
+		RepairedFrom:    uuid.NullUUID{UUID: current.ID, Valid: true},
+		AcademicSession: current.AcademicSession,
+		Semester:        current.Semester,
+	}
+
+	// the repair is saved as a draft even if the client goes away, it goes live once it is published
+	ctx = context.WithoutCancel(ctx)
+	candidateId, err := tts.repo.CreateARepairedCandidate(ctx, candidateData, sessionPlacements, toMovedSessionParams(moved, sessionPlacements, baseDate))
+	if err != nil {
+		tts.logger.Error("error creating the repaired candidate", "err", err)
+		return timetableDto.RepairTimetableResponse{}, status.InternalServerError.Message, err
+	}
+	resp.CandidateId = candidateId
+
+	movedRows, err := tts.repo.RetrieveMovedSessions(ctx, candidateId, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving moved sessions", "err", err)
+		return resp, status.OK.Message, nil
+	}
+	for _, row := range movedRows {
+		resp.Moved = append(resp.Moved, toMovedSessionResponse(row))
+	}
+	return resp, status.OK.Message, nil
+}
+
+// the sessions a repair moved to make the given candidate of the university
+func (tts *timeTableService) RetrieveMovedSessions(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID) (timeTableResponse, string, error) {
+	rows, err := tts.repo.RetrieveMovedSessions(ctx, candidateId, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving moved sessions", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	moved := make([]timetableDto.MovedSessionResponse, 0, len(rows))
+	for _, row := range rows {
+		moved = append(moved, toMovedSessionResponse(row))
+	}
+
+	return timeTableResponse{
+		Message:           "Moved sessions retrieved successfully",
+		Data:              moved,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+// the states a candidate moves through, only the published one is shown to students
+const (
+	CandidateDraft       = "DRAFT"
+	CandidateUnderReview = "UNDER_REVIEW"
+	CandidatePublished   = "PUBLISHED"
+	CandidateArchived    = "ARCHIVED"
+)
+
+const (
+	ReviewerDean = "DEAN"
+	ReviewerHod  = "HOD"
+)
+
+const (
+	ReviewApproved         = "APPROVED"
+	ReviewChangesRequested = "CHANGES_REQUESTED"
+)
+
+// the dean or HOD reviewing a candidate and the faculty or department they review it for
+type candidateReviewer struct {
+	role         string
+	lecturerId   uuid.UUID
+	universityId uuid.UUID
+	facultyId    uuid.NullUUID
+	departmentId uuid.NullUUID
+}
+
+func (tts *timeTableService) retrieveReviewer(ctx context.Context, role string, reviewerId uuid.UUID, uniId uuid.UUID) (candidateReviewer, string, error) {
+	var reviewer candidateReviewer
+	var universityId uuid.NullUUID
+	switch role {
+	case ReviewerDean:
+		dean, err := tts.repo.RetrieveDean(ctx, reviewerId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return candidateReviewer{}, status.NotFound.Message, errors.New("dean not found")
+			}
+			tts.logger.Error("error retrieving dean", "err", err)
+			return candidateReviewer{}, status.InternalServerError.Message, err
+		}
+		reviewer = candidateReviewer{role: role, lecturerId: dean.LecturerID.UUID, facultyId: dean.FacultyID}
+		universityId = dean.UniversityID
+	case ReviewerHod:
+		hod, err := tts.repo.RetrieveHod(ctx, reviewerId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return candidateReviewer{}, status.NotFound.Message, errors.New("hod not found")
+			}
+			tts.logger.Error("error retrieving hod", "err", err)
+			return candidateReviewer{}, status.InternalServerError.Message, err
+		}
+		reviewer = candidateReviewer{role: role, lecturerId: hod.LecturerID.UUID, departmentId: hod.DepartmentID}
+		universityId = hod.UniversityID
+	default:
+		return candidateReviewer{}, status.BadRequest.Message, errors.New("unknown reviewer role")
+	}
+	if !universityId.Valid || universityId.UUID != uniId {
+		return candidateReviewer{}, status.Forbidden.Message, errors.New("reviewer does not belong to this university")
+	}
+	reviewer.universityId = uniId
+	return reviewer, status.OK.Message, nil
+}
+
+// the admin hands a draft over to the deans and HODs
+func (tts *timeTableService) SubmitCandidateForReview(ctx context.Context, body timetableDto.CandidateActionDto) (timeTableResponse, string, error) {
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, body.CandidateId, body.UniversityId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	if candidate.CandidateStatus != CandidateDraft {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("only a draft can be submitted for review")
+	}
+	err = tts.repo.UpdateCandidateStatus(ctx, sqlc.UpdateCandidateStatusParams{
+		ID:              candidate.ID,
+		UniversityID:    body.UniversityId,
+		CandidateStatus: CandidateUnderReview,
+	})
+	if err != nil {
+		tts.logger.Error("error submitting candidate for review", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	candidate.CandidateStatus = CandidateUnderReview
+	return timeTableResponse{
+		Message:           "Candidate submitted for review",
+		Data:              toCandidateResponse(candidate),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// records the decision of a dean or HOD on a candidate under review, a second decision by the
+// same reviewer replaces their first
+func (tts *timeTableService) ReviewCandidate(ctx context.Context, body timetableDto.CandidateReviewDto, role string, reviewerId uuid.UUID) (timeTableResponse, string, error) {
+	reviewer, statusMsg, err := tts.retrieveReviewer(ctx, role, reviewerId, body.UniversityId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, body.CandidateId, body.UniversityId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	if candidate.CandidateStatus != CandidateUnderReview {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("candidate is not under review")
+	}
+
+	reviewId, err := tts.repo.UpsertCandidateReview(ctx, sqlc.UpsertCandidateReviewParams{
+		CandidateID:  candidate.ID,
+		UniversityID: body.UniversityId,
+		ReviewerID:   reviewer.lecturerId,
+		ReviewerRole: reviewer.role,
+		FacultyID:    reviewer.facultyId,
+		DepartmentID: reviewer.departmentId,
+		Decision:     body.Decision,
+		Comment:      strings.TrimSpace(body.Comment),
+	})
+	if err != nil {
+		tts.logger.Error("error saving candidate review", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	tts.logger.Info("candidate reviewed", "candidateId", candidate.ID, "role", reviewer.role, "decision", body.Decision)
+
+	return timeTableResponse{
+		Message:           "Review saved successfully",
+		Data:              map[string]uuid.UUID{"reviewId": reviewId},
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// the sessions of a candidate that belong to the faculty of a dean or the department of a HOD
+func (tts *timeTableService) RetrieveCandidateForReview(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID, role string, reviewerId uuid.UUID) (timeTableResponse, string, error) {
+	reviewer, statusMsg, err := tts.retrieveReviewer(ctx, role, reviewerId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, candidateId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+
+	sessions := make([]timetableDto.CandidateSessionResponse, 0)
+	if reviewer.role == ReviewerDean {
+		rows, err := tts.repo.RetrieveCandidateSessionsForFaculty(ctx, sqlc.GetCandidateSessionsForFacultyParams{
+			CandidateID: candidate.ID,
+			FacultyID:   reviewer.facultyId.UUID,
+		})
+		if err != nil {
+			tts.logger.Error("error retrieving faculty sessions", "err", err)
+			return timeTableResponse{}, status.InternalServerError.Message, err
+		}
+		for _, row := range rows {
+			sessions = append(sessions, toCandidateSessionResponse(sqlc.GetCandidateSessionsRow(row)))
+		}
+	} else {
+		rows, err := tts.repo.RetrieveCandidateSessionsForDepartment(ctx, sqlc.GetCandidateSessionsForDepartmentParams{
+			CandidateID:  candidate.ID,
+			DepartmentID: reviewer.departmentId.UUID,
+		})
+		if err != nil {
+			tts.logger.Error("error retrieving department sessions", "err", err)
+			return timeTableResponse{}, status.InternalServerError.Message, err
+		}
+		for _, row := range rows {
+			sessions = append(sessions, toCandidateSessionResponse(sqlc.GetCandidateSessionsRow(row)))
+		}
+	}
+
+	return timeTableResponse{
+		Message: "Candidate retrieved successfully",
+		Data: timetableDto.CandidateReviewScopeResponse{
+			Candidate:    toCandidateResponse(candidate),
+			ReviewerRole: reviewer.role,
+			FacultyId:    reviewer.facultyId,
+			DepartmentId: reviewer.departmentId,
+			Sessions:     sessions,
+		},
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// every review left on a candidate with how many approved and how many asked for changes
+func (tts *timeTableService) RetrieveCandidateReviews(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID) (timeTableResponse, string, error) {
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, candidateId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	rows, err := tts.repo.RetrieveCandidateReviews(ctx, candidate.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate reviews", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	resp := timetableDto.CandidateReviewsResponse{
+		CandidateId: candidate.ID,
+		Status:      candidate.CandidateStatus,
+		Reviews:     make([]timetableDto.CandidateReviewResponse, 0, len(rows)),
+	}
+	for _, row := range rows {
+		switch row.Decision {
+		case ReviewApproved:
+			resp.Approved++
+		case ReviewChangesRequested:
+			resp.ChangesRequested++
+		}
+		resp.Reviews = append(resp.Reviews, timetableDto.CandidateReviewResponse{
+			ReviewId:       row.ReviewID,
+			CandidateId:    row.CandidateID,
+			ReviewerId:     row.ReviewerID,
+			ReviewerName:   strings.TrimSpace(row.LecturerFirstName + " " + row.LecturerLastName),
+			ReviewerRole:   row.ReviewerRole,
+			FacultyId:      row.FacultyID,
+			FacultyName:    row.FacultyName.String,
+			DepartmentId:   row.DepartmentID,
+			DepartmentName: row.DepartmentName.String,
+			Decision:       row.Decision,
+			Comment:        row.Comment,
+			CreatedAt:      row.CreatedAt.Time,
+			UpdatedAt:      row.UpdatedAt.Time,
+		})
+	}
+
+	return timeTableResponse{
+		Message:           "Candidate reviews retrieved successfully",
+		Data:              resp,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// makes a reviewed candidate the one students see for its term, the one published for the same
+// term is archived in the same transaction. refused while a dean or HOD still asks for changes.
+// rolling back to an archived one goes through PromoteCandidate
+func (tts *timeTableService) PublishCandidate(ctx context.Context, body timetableDto.CandidateActionDto, adminId uuid.UUID) (timeTableResponse, string, error) {
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, body.CandidateId, body.UniversityId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	switch candidate.CandidateStatus {
+	case CandidatePublished:
+		return timeTableResponse{
+			Message:           "Candidate is already published",
+			Data:              toCandidateResponse(candidate),
+			StatusCode:        status.OK.Code,
+			StatusCodeMessage: status.OK.Message,
+		}, status.OK.Message, nil
+	case CandidateDraft:
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("submit the draft for review first")
+	case CandidateArchived:
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("promote an archived candidate to roll back to it")
+	}
+
+	reviews, err := tts.repo.RetrieveCandidateReviews(ctx, candidate.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate reviews", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	changesRequested := 0
+	for _, review := range reviews {
+		if review.Decision == ReviewChangesRequested {
+			changesRequested++
+		}
+	}
+	if changesRequested > 0 {
+		return timeTableResponse{}, status.Conflict.Message, fmt.Errorf("%d reviews ask for changes, they must approve the candidate before it is published", changesRequested)
+	}
+
+	// holds the university so a generation cannot replace the candidate meanwhile
+	release, held := tts.jobs.hold(body.UniversityId)
+	if !held {
+		return timeTableResponse{}, status.Conflict.Message, errors.New("a timetable is already being generated for this university")
+	}
+	defer release()
+
+	publishedBy := uuid.NullUUID{UUID: adminId, Valid: adminId != uuid.Nil}
+	if err := tts.repo.PublishCandidate(context.WithoutCancel(ctx), candidate, publishedBy); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return timeTableResponse{}, status.NotFound.Message, errors.New("candidate not found")
+		}
+		tts.logger.Error("error publishing candidate", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	tts.logger.Info("candidate published", "universityId", body.UniversityId, "candidateId", candidate.ID)
+
+	candidate.CandidateStatus = CandidatePublished
+	candidate.PublishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	candidate.PublishedBy = publishedBy
+	return timeTableResponse{
+		Message:           "Candidate published successfully",
+		Data:              toCandidateResponse(candidate),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+// what a faculty or department generation keeps of the published timetable
+type scopedGeneration struct {
+	scope     computed.GenerationScope
+	courses   []sqlc.GetCourseDepartmentsRow
+	published []computed.ExistingPlacement
+}
+
+// the faculty of a dean or the department of a HOD
+func reviewerScope(reviewer candidateReviewer) (computed.GenerationScope, error) {
+	switch {
+	case reviewer.role == ReviewerDean && reviewer.facultyId.Valid:
+		return computed.GenerationScope{Kind: computed.ScopeFaculty, Id: reviewer.facultyId.UUID}, nil
+	case reviewer.role == ReviewerHod && reviewer.departmentId.Valid:
+		return computed.GenerationScope{Kind: computed.ScopeDepartment, Id: reviewer.departmentId.UUID}, nil
+	}
+	return computed.GenerationScope{}, errors.New("reviewer is not assigned to a faculty or department")
+}
+
+// rebuilds only the sessions of the faculty of a dean or the department of a HOD. every other
+// session stays where the published timetable of the term has it and the merged timetable is
+// saved as a new draft, on the same week as the published one
+func (tts *timeTableService) CreateAScopedTimeTable(ctx context.Context, body timetableDto.ScopedTimetableDto, role string, reviewerId uuid.UUID) (timeTableResponse, string, error) {
+	reviewer, statusMsg, err := tts.retrieveReviewer(ctx, role, reviewerId, body.UniversityId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	scope, err := reviewerScope(reviewer)
+	if err != nil {
+		return timeTableResponse{}, status.Forbidden.Message, err
+	}
+
+	term, err := tts.resolveTerm(ctx, body.UniversityId, body.AcademicSession, body.Semester)
+	if err != nil {
+		if errors.Is(err, errInvalidTerm) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error resolving the academic term", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	published, err := tts.repo.RetrievePublishedCandidate(ctx, sqlc.GetPublishedCandidateParams{
+		UniversityID:    body.UniversityId,
+		AcademicSession: term.nullSession(),
+		Semester:        term.nullSemester(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return timeTableResponse{}, status.NotFound.Message, errors.New("publish a timetable of the whole university for this term first")
+		}
+		tts.logger.Error("error retrieving published candidate", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	// the published sessions only line up with the new ones on the week they were placed on
+	week, err := tts.loadTeachingWeek(ctx, body.UniversityId, published.StartOfDay.UTC(), published.EndOfDay.UTC())
+	if err != nil {
+		if errors.Is(err, errInvalidTeachingWeek) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error loading teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	gaParams, err := tts.resolveGAParams(ctx, body.UniversityId, body.Params)
+	if err != nil {
+		if errors.Is(err, errInvalidGAParams) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error resolving genetic algorithm params", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	solver, err := tts.resolveSolver(ctx, body.UniversityId, body.Solver, gaParams)
+	if err != nil {
+		if errors.Is(err, errUnknownSolver) {
+			return timeTableResponse{}, status.BadRequest.Message, err
+		}
+		tts.logger.Error("error resolving solver", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	courses, err := tts.repo.RetrieveCourseDepartments(ctx, body.UniversityId)
+	if err != nil {
+		tts.logger.Error("error retrieving course departments", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	existing, err := tts.retrieveExistingPlacements(ctx, published.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate session placements", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	job, jobCtx, created := tts.jobs.create(TimetableJob{
+		UniversityId:    body.UniversityId,
+		AcademicSession: term.Session,
+		Semester:        term.Semester,
+		Scope:           scope.Kind,
+		ScopeId:         uuid.NullUUID{UUID: scope.Id, Valid: true},
+		Seed:            gaParams.Seed,
+		Generations:     gaParams.Generations,
+	})
+	if !created {
+		return timeTableResponse{}, status.Conflict.Message, errors.New("a timetable is already being generated for this university")
+	}
+
+	scoped := &scopedGeneration{scope: scope, courses: courses, published: existing}
+	go tts.runTimetableJob(jobCtx, job.JobId, week, weekBaseDate(published.StartOfDay.UTC()), body.UniversityId, term, scoped, gaParams, solver)
+
+	return timeTableResponse{
+		Message:           "Timetable generation started",
+		Data:              toJobResponse(job),
+		StatusCode:        status.Accepted.Code,
+		StatusCodeMessage: status.Accepted.Message,
+	}, status.Accepted.Message, nil
+}
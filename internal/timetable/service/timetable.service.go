@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
@@ -10,6 +12,7 @@ import (
 	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
 	"github.com/Cxons/unischedulebackend/internal/shared/dto"
 	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
 	"github.com/Cxons/unischedulebackend/internal/timetable/repository"
 	"github.com/Cxons/unischedulebackend/internal/timetable/types"
 	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
@@ -32,6 +35,7 @@ type timeTableService struct{
 	repo timetableRepository
 	computed computed.Computed
 	logger *slog.Logger
+	jobs *jobStore
 }
 
 type TimetableSession struct {
@@ -41,12 +45,47 @@ type TimetableSession struct {
 	SessionID  uuid.UUID 
 	CourseName string 
 	VenueName  string 
+	SessionType string // LECTURE, LAB or TUTORIAL
+	Group       int    // 1 based group of the course's students the session is for, 0 when the whole class attends
+	Groups      int    // how many groups the course's students are split into, 0 when not split
 }
 
 type TimeTableService interface{
-	CreateATimeTable(ctx context.Context,startOfDay time.Time, endOfDay time.Time,uniId uuid.UUID)(timeTableResponse,string,error)
-	RetrieveTimetableForACohort(ctx context.Context,cohortId uuid.UUID,uniId uuid.UUID)(timeTableResponse,string,error)
-	RetrieveTimetableForAStudent(ctx context.Context,studentId uuid.UUID,uniId uuid.UUID,) (timeTableResponse, string, error) 
+	CreateATimeTable(ctx context.Context,startOfDay time.Time, endOfDay time.Time,uniId uuid.UUID,academicSession string,semester string,params *timetableDto.GAParamsDto,solverName string)(timeTableResponse,string,error)
+	CreateAScopedTimeTable(ctx context.Context,body timetableDto.ScopedTimetableDto,role string,reviewerId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveTimetableForACohort(ctx context.Context,cohortId uuid.UUID,uniId uuid.UUID,academicSession string,semester string)(timeTableResponse,string,error)
+	RetrieveTimetableForAStudent(ctx context.Context,studentId uuid.UUID,uniId uuid.UUID,academicSession string,semester string) (timeTableResponse, string, error) 
+	RetrieveTimetableForAVenue(ctx context.Context,venueId uuid.UUID,uniId uuid.UUID,academicSession string,semester string)(timeTableResponse,string,error)
+	RetrieveTimetableJob(ctx context.Context,jobId uuid.UUID,userId uuid.UUID)(timeTableResponse,string,error)
+	CancelTimetableJob(ctx context.Context,jobId uuid.UUID,userId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveTimetableSettings(ctx context.Context,uniId uuid.UUID)(timeTableResponse,string,error)
+	UpdateTimetableSettings(ctx context.Context,body timetableDto.TimetableSettingsDto)(timeTableResponse,string,error)
+	RetrieveTimetableConstraints(ctx context.Context,uniId uuid.UUID)(timeTableResponse,string,error)
+	UpdateTimetableConstraint(ctx context.Context,body timetableDto.TimetableConstraintDto)(timeTableResponse,string,error)
+	RetrieveTeachingWeek(ctx context.Context,uniId uuid.UUID)(timeTableResponse,string,error)
+	UpdateTeachingWeek(ctx context.Context,body timetableDto.TeachingWeekDto)(timeTableResponse,string,error)
+	RetrieveSessionPins(ctx context.Context,uniId uuid.UUID)(timeTableResponse,string,error)
+	PinSession(ctx context.Context,body timetableDto.SessionPinDto)(timeTableResponse,string,error)
+	DeleteSessionPin(ctx context.Context,pinId uuid.UUID,uniId uuid.UUID)(timeTableResponse,string,error)
+	RepairTimetable(ctx context.Context,uniId uuid.UUID,academicSession string,semester string)(timeTableResponse,string,error)
+	RetrieveMovedSessions(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveCandidateViolations(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveCandidates(ctx context.Context,uniId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveCandidate(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)(timeTableResponse,string,error)
+	DiffCandidates(ctx context.Context,uniId uuid.UUID,fromId uuid.UUID,toId uuid.UUID)(timeTableResponse,string,error)
+	SubmitCandidateForReview(ctx context.Context,body timetableDto.CandidateActionDto)(timeTableResponse,string,error)
+	ReviewCandidate(ctx context.Context,body timetableDto.CandidateReviewDto,role string,reviewerId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveCandidateForReview(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID,role string,reviewerId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveCandidateReviews(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)(timeTableResponse,string,error)
+	PublishCandidate(ctx context.Context,body timetableDto.CandidateActionDto,adminId uuid.UUID)(timeTableResponse,string,error)
+	PromoteCandidate(ctx context.Context,body timetableDto.CandidateActionDto,adminId uuid.UUID)(timeTableResponse,string,error)
+	BenchmarkSolvers(ctx context.Context,body timetableDto.BenchmarkSolversDto)(timeTableResponse,string,error)
+	RetrieveCandidateFitnessHistory(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID)(timeTableResponse,string,error)
+	ExportProblem(ctx context.Context,body timetableDto.ExportProblemDto)(timeTableResponse,string,error)
+	RetrieveVenueTravelTimes(ctx context.Context,uniId uuid.UUID)(timeTableResponse,string,error)
+	SetVenueTravelTime(ctx context.Context,body timetableDto.VenueTravelTimeDto)(timeTableResponse,string,error)
+	DeleteVenueTravelTime(ctx context.Context,uniId uuid.UUID,fromVenueId uuid.UUID,toVenueId uuid.UUID)(timeTableResponse,string,error)
+	RetrieveDepartmentVenueUsage(ctx context.Context,candidateId uuid.UUID,uniId uuid.UUID,departmentId uuid.UUID)(timeTableResponse,string,error)
 }
 
 
@@ -55,23 +94,19 @@ func NewTimetableService(repo timetableRepository,logger *slog.Logger)*timeTable
 		repo: repo,
 		computed: *computed.NewComputed(repo),
 		logger: logger,
+		jobs: newJobStore(),
 	}
 }
 
 
 
 // BuildSlotMap builds a map from slot index → (day, start time)
-// BuildSlotMap builds a map from slot index → (day, start time)
-func BuildSlotMap(
-    slotsPerDay int,
-    days []string,
-    startOfDay time.Time,
-    slotDuration time.Duration,
-) map[int]SlotInfo {
+func BuildSlotMap(week computed.TeachingWeek, baseDate time.Time) map[int]SlotInfo {
     slotMap := make(map[int]SlotInfo)
-    totalSlots := slotsPerDay * len(days)
+    slotsPerDay := week.SlotsPerDay()
+    days := week.DayNames()
 
-    for i := 0; i < totalSlots; i++ {
+    for i := 0; i < week.TotalSlots(); i++ {
         dayIdx := i / slotsPerDay
         slotIdxInDay := i % slotsPerDay
 
@@ -80,7 +115,7 @@ func BuildSlotMap(
             continue
         }
 
-        startTime := startOfDay.Add(time.Duration(slotIdxInDay) * slotDuration)
+        startTime := baseDate.Add(minutesToDuration(week.SlotStartMinutes(slotIdxInDay)))
 
         slotMap[i] = SlotInfo{
             Day:       days[dayIdx],
@@ -91,42 +126,246 @@ func BuildSlotMap(
     return slotMap
 }
 
-func (tts *timeTableService) CreateATimeTable(ctx context.Context, startOfDay time.Time, endOfDay time.Time, uniId uuid.UUID) (timeTableResponse, string, error) {
-    slotDuration := time.Hour
-    totalDuration := endOfDay.Sub(startOfDay)
-    slotsPerDay := int(totalDuration / slotDuration)
+func (tts *timeTableService) CreateATimeTable(ctx context.Context, startOfDay time.Time, endOfDay time.Time, uniId uuid.UUID, academicSession string, semester string, params *timetableDto.GAParamsDto, solverName string) (timeTableResponse, string, error) {
+    term, err := tts.resolveTerm(ctx, uniId, academicSession, semester)
+    if err != nil {
+        if errors.Is(err, errInvalidTerm) {
+            return timeTableResponse{}, status.BadRequest.Message, err
+        }
+        tts.logger.Error("error resolving the academic term", "err", err)
+        return timeTableResponse{}, status.InternalServerError.Message, err
+    }
 
-    slog.Info("set of values", "startofDay", startOfDay, "end of day", endOfDay, "totalduration", totalDuration, "slotsperday", slotsPerDay)
-    
-    // Validate slotsPerDay - return empty string for status message
-    if slotsPerDay <= 0 {
-        return timeTableResponse{}, "", fmt.Errorf("invalid time range: startOfDay=%v, endOfDay=%v", startOfDay, endOfDay)
+    // Validate the teaching week before starting a job that would fail anyway
+    week, err := tts.loadTeachingWeek(ctx, uniId, startOfDay, endOfDay)
+    if err != nil {
+        if errors.Is(err, errInvalidTeachingWeek) {
+            return timeTableResponse{}, status.BadRequest.Message, err
+        }
+        tts.logger.Error("error loading teaching week", "err", err)
+        return timeTableResponse{}, status.InternalServerError.Message, err
     }
-    
-    days := []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"}
-    slotMap := BuildSlotMap(slotsPerDay, days, startOfDay, slotDuration)
+
+    slog.Info("teaching week", "days", week.DayNames(), "slotMinutes", week.SlotMinutes, "slotsperday", week.SlotsPerDay(), "session", term.Session, "semester", term.Semester)
+
+    gaParams, err := tts.resolveGAParams(ctx, uniId, params)
+    if err != nil {
+        if errors.Is(err, errInvalidGAParams) {
+            return timeTableResponse{}, status.BadRequest.Message, err
+        }
+        tts.logger.Error("error resolving genetic algorithm params", "err", err)
+        return timeTableResponse{}, status.InternalServerError.Message, err
+    }
+
+    solver, err := tts.resolveSolver(ctx, uniId, solverName, gaParams)
+    if err != nil {
+        if errors.Is(err, errUnknownSolver) {
+            return timeTableResponse{}, status.BadRequest.Message, err
+        }
+        tts.logger.Error("error resolving solver", "err", err)
+        return timeTableResponse{}, status.InternalServerError.Message, err
+    }
+
+    job, jobCtx, created := tts.jobs.create(TimetableJob{
+        UniversityId:    uniId,
+        AcademicSession: term.Session,
+        Semester:        term.Semester,
+        Scope:           computed.ScopeUniversity,
+        Seed:            gaParams.Seed,
+        Generations:     gaParams.Generations,
+    })
+    if !created {
+        return timeTableResponse{}, status.Conflict.Message, errors.New("a timetable is already being generated for this university")
+    }
+
+    // the job runs on its own context so it keeps going if the client disconnects
+    go tts.runTimetableJob(jobCtx, job.JobId, week, weekBaseDate(startOfDay), uniId, term, nil, gaParams, solver)
+
+    return timeTableResponse{
+        Message:           "Timetable generation started",
+        Data:              toJobResponse(job),
+        StatusCode:        status.Accepted.Code,
+        StatusCodeMessage: status.Accepted.Message,
+    }, status.Accepted.Message, nil
+}
+
+// runs the generation in the background and records how it ended on the job
+func (tts *timeTableService) runTimetableJob(ctx context.Context, jobId uuid.UUID, week computed.TeachingWeek, baseDate time.Time, uniId uuid.UUID, term academicTerm, scoped *scopedGeneration, gaParams computed.GAParams, solver computed.Solver) {
+    tts.runJob(ctx, jobId, func(ctx context.Context) error {
+        return tts.generateTimetable(ctx, jobId, week, baseDate, uniId, term, scoped, gaParams, solver)
+    })
+}
+
+// runs the work of a job on the job's context and records how it ended, a panic fails the job so
+// the university is never left busy
+func (tts *timeTableService) runJob(ctx context.Context, jobId uuid.UUID, work func(ctx context.Context) error) {
+    defer func() {
+        if r := recover(); r != nil {
+            tts.logger.Error("timetable job panicked", "jobId", jobId, "recover", r)
+            tts.jobs.finish(jobId, JobFailed, fmt.Errorf("timetable job crashed: %v", r))
+        }
+    }()
+
+    tts.jobs.update(jobId, func(job *TimetableJob) {
+        job.Status = JobRunning
+        job.StartedAt = time.Now()
+    })
+
+    err := work(ctx)
+    switch {
+    case err == nil:
+        tts.jobs.finish(jobId, JobCompleted, nil)
+    case errors.Is(err, context.Canceled):
+        tts.logger.Info("timetable job cancelled", "jobId", jobId)
+        tts.jobs.finish(jobId, JobCancelled, nil)
+    default:
+        tts.logger.Error("timetable job failed", "jobId", jobId, "err", err)
+        tts.jobs.finish(jobId, JobFailed, err)
+    }
+}
+
+// a scoped generation only places the sessions of its faculty or department, nil rebuilds the
+// whole university
+func (tts *timeTableService) generateTimetable(ctx context.Context, jobId uuid.UUID, week computed.TeachingWeek, baseDate time.Time, uniId uuid.UUID, term academicTerm, scoped *scopedGeneration, gaParams computed.GAParams, solver computed.Solver) error {
+    slotMap := BuildSlotMap(week, baseDate)
     
     // Debug: Check if slotMap is populated
-    tts.logger.Info("slotMap contents", "size", len(slotMap), "slotsPerDay", slotsPerDay, "days", len(days))
+    tts.logger.Info("slotMap contents", "size", len(slotMap), "slotsPerDay", week.SlotsPerDay(), "days", len(week.Days))
     if len(slotMap) == 0 {
-        return timeTableResponse{}, "", fmt.Errorf("slotMap is empty - check time parameters")
+        return fmt.Errorf("slotMap is empty - check time parameters")
+    }
+
+    // only the courses offered in the semester are scheduled
+    precomputed, _, venueMap, _, coursesMap, err := tts.computed.ComputePreComputed(ctx, uniId, term.Semester, week)
+    if err != nil {
+        // a cancelled job shows up here as a failed query
+        if ctxErr := ctx.Err(); ctxErr != nil {
+            return ctxErr
+        }
+        return err
+    }
+
+    constraintSettings, err := tts.loadConstraintSettings(ctx, uniId)
+    if err != nil {
+        return err
+    }
+    precomputed.Constraints, err = computed.BuildConstraints(constraintSettings)
+    if err != nil {
+        return err
+    }
+    if scoped != nil {
+        inScope := scoped.scope.CoursesInScope(scoped.courses, coursesMap, precomputed.NumCourses)
+        fixed, unplaced := computed.FixOutOfScopeSessions(precomputed, week, scoped.published, inScope, coursesMap, venueMap)
+        tts.logger.Info("sessions outside the scope fixed", "universityId", uniId, "scope", scoped.scope.Kind, "scopeId", scoped.scope.Id, "fixed", fixed, "unplaced", len(unplaced))
+        if len(unplaced) > 0 {
+            tts.logger.Warn("sessions outside the scope have no usable published placement and are placed again", "universityId", uniId, "sessions", len(unplaced))
+        }
+    }
+
+    tts.logger.Info("solving timetable", "universityId", uniId, "session", term.Session, "semester", term.Semester, "solver", solver.Name())
+    candidateTimetable, err := solver.Solve(ctx, precomputed, func(progress computed.GenerationProgress) {
+        tts.jobs.update(jobId, func(job *TimetableJob) {
+            job.Generation = progress.Generation
+            job.Generations = progress.Generations
+            job.BestFitness = progress.BestFitness
+        })
+    })
+    if err != nil {
+        return err
     }
 
-    precomputed, _, venueMap, _, coursesMap := tts.computed.ComputePreComputed(ctx, uniId, slotsPerDay, startOfDay, days, slotDuration)
-    candidateTimetable := tts.computed.GeneticAlgorithm(precomputed)
+    // from here on the candidate is saved even if the job is cancelled
+    ctx = context.WithoutCancel(ctx)
     
     candidateData := sqlc.CreateCandidateParams{
         Fitness:           candidateTimetable.Fitness,
         UniversityID:      uniId,
-        CandidateStatus:   "CURRENT",
-        StartOfDay:        startOfDay,
-        EndOfDay:          endOfDay,
+        // a new run waits as a draft until it is reviewed and published
+        CandidateStatus:   CandidateDraft,
+        StartOfDay:        baseDate.Add(minutesToDuration(week.GridStartMinutes())),
+        EndOfDay:          baseDate.Add(minutesToDuration(week.GridEndMinutes())),
+        // stored so a reported timetable can be generated again with the exact same run
+        Seed:              sql.NullInt64{Int64: gaParams.Seed, Valid: true},
+        PopulationSize:    sql.NullInt32{Int32: int32(gaParams.PopulationSize), Valid: true},
+        Generations:       sql.NullInt32{Int32: int32(gaParams.Generations), Valid: true},
+        MutationRate:      sql.NullFloat64{Float64: gaParams.MutationRate, Valid: true},
+        TournamentSize:    sql.NullInt32{Int32: int32(gaParams.TournamentSize), Valid: true},
+        ElitismFraction:   sql.NullFloat64{Float64: gaParams.ElitismFraction, Valid: true},
+        Solver:            sql.NullString{String: solver.Name(), Valid: true},
+        Workers:           sql.NullInt32{Int32: int32(gaParams.Workers), Valid: true},
+        AcademicSession:   term.nullSession(),
+        Semester:          term.nullSemester(),
+    }
+    // only a genetic run has generations, a local search leaves both empty
+    if len(candidateTimetable.History) > 0 {
+        candidateData.GenerationsRun = sql.NullInt32{Int32: int32(len(candidateTimetable.History) - 1), Valid: true}
+        candidateData.StopReason = sql.NullString{String: candidateTimetable.StopReason, Valid: true}
+        tts.logger.Info("genetic run stopped", "universityId", uniId, "reason", candidateTimetable.StopReason, "generations", len(candidateTimetable.History)-1)
+    }
+    fitnessHistory := make([]sqlc.CreateCandidateFitnessParams, 0, len(candidateTimetable.History))
+    for _, stats := range candidateTimetable.History {
+        fitnessHistory = append(fitnessHistory, sqlc.CreateCandidateFitnessParams{
+            Generation:  int32(stats.Generation),
+            BestFitness: stats.BestFitness,
+            MeanFitness: stats.MeanFitness,
+        })
     }
     
     slog.Info("candidate timetable", "val", candidateTimetable.Placements)
+
+    // the stored conflict flags come from the hard violations of the final placements
+    if conflicts := computed.MarkConflicts(precomputed, candidateTimetable); conflicts > 0 {
+        tts.logger.Warn("candidate timetable has conflicts", "universityId", uniId, "sessions", conflicts)
+    }
     
-    sessionPlacements := make([]customSessionPlacement, 0)
-    for _, val := range candidateTimetable.Placements {
+    sessionPlacements := tts.toSessionPlacements(precomputed, candidateTimetable.Placements, coursesMap, venueMap, slotMap, uniId)
+
+    // Validate that we have session placements
+    if len(sessionPlacements) == 0 {
+        return fmt.Errorf("no valid session placements generated")
+    }
+
+    // Log the placements for debugging
+    tts.logger.Info("session placements", "count", len(sessionPlacements), "firstPlacement", sessionPlacements[0])
+
+    err = tts.repo.CreateACandidateTimeTable(ctx, candidateData, sessionPlacements, fitnessHistory)
+    if err != nil {
+        tts.logger.Error("error creating the candidate timetable", "err", err)
+        return err
+    }
+
+    return nil
+}
+// the type of the session of a placement, a lecture when the session is unknown
+func sessionTypeOf(pre *computed.PreComputed, sessionIdx int) string {
+	if sessionIdx < 0 || sessionIdx >= len(pre.SessionAtoms) || pre.SessionAtoms[sessionIdx].SessionType == "" {
+		return computed.SessionTypeLecture
+	}
+	return pre.SessionAtoms[sessionIdx].SessionType
+}
+
+// the group of the session of a placement and how many groups its course is split into, 0 of 1
+// when the whole class attends
+func sessionGroupOf(pre *computed.PreComputed, sessionIdx int) (int32, int32) {
+	if sessionIdx < 0 || sessionIdx >= len(pre.SessionAtoms) || pre.SessionAtoms[sessionIdx].GroupCount <= 1 {
+		return 0, 1
+	}
+	return int32(pre.SessionAtoms[sessionIdx].GroupIdx), int32(pre.SessionAtoms[sessionIdx].GroupCount)
+}
+
+// the group of a session of a split course, 1 based, and how many groups the course has. 0, 0
+// when the whole class attends
+func sessionGroup(groupIdx int32, groupCount int32) (int, int) {
+	if groupCount <= 1 {
+		return 0, 0
+	}
+	return int(groupIdx) + 1, int(groupCount)
+}
+
+// turns solver placements into rows, placements on a slot outside the map are dropped
+func (tts *timeTableService) toSessionPlacements(pre *computed.PreComputed, placements []computed.SessionPlacement, coursesMap map[uuid.UUID]int, venueMap map[uuid.UUID]int, slotMap map[int]SlotInfo, uniId uuid.UUID) []customSessionPlacement {
+    sessionPlacements := make([]customSessionPlacement, 0, len(placements))
+    for _, val := range placements {
         courseId := uuid.UUID{}
         venueId := uuid.UUID{}
         
@@ -159,6 +398,7 @@ func (tts *timeTableService) CreateATimeTable(ctx context.Context, startOfDay ti
             continue
         }
 
+        groupIdx, groupCount := sessionGroupOf(pre, val.SessionIdx)
         sessionPlacements = append(sessionPlacements, customSessionPlacement{
             SessionIdx:   int32(val.SessionIdx),
             CourseId:     courseId,
@@ -166,39 +406,15 @@ func (tts *timeTableService) CreateATimeTable(ctx context.Context, startOfDay ti
             Day:          slotInfo.Day,
             SessionTime:  slotInfo.StartTime,
             UniversityId: uniId,
+            Conflict:     val.Conflict,
+            SessionType:  sessionTypeOf(pre, val.SessionIdx),
+            GroupIdx:     groupIdx,
+            GroupCount:   groupCount,
         })
     }
-
-    // Validate that we have session placements
-    if len(sessionPlacements) == 0 {
-        return timeTableResponse{}, "", fmt.Errorf("no valid session placements generated")
-    }
-
-    // Log the placements for debugging
-    tts.logger.Info("session placements", "count", len(sessionPlacements), "firstPlacement", sessionPlacements[0])
-
-    deprecateErr := tts.repo.DeprecateLatestCandidate(ctx, uniId)
-    if deprecateErr != nil {
-        tts.logger.Error("error deprecating latest candidate", "err", deprecateErr)
-        return timeTableResponse{}, "", deprecateErr
-    }
-
-    err := tts.repo.CreateACandidateTimeTable(ctx, candidateData, sessionPlacements)
-    if err != nil {
-        restoreErr := tts.repo.RestoreCurrentCandidate(ctx, uniId)
-        if restoreErr != nil {
-            tts.logger.Error("error restoring current candidate", "err", restoreErr)
-        }
-        tts.logger.Error("error creating the candidate timetable", "err", err)
-        return timeTableResponse{}, "", err
-    }
-
-    return timeTableResponse{
-        Message:           "Timetable created successfully",
-        StatusCode:        status.Created.Code,
-        StatusCodeMessage: status.Created.Message,
-    }, status.Created.Message, nil
+    return sessionPlacements
 }
+
 // var dayOrder = map[string]int{
 // 	"Monday":    1,
 // 	"Tuesday":   2,
@@ -212,14 +428,13 @@ func PrepareTimetableJSON(
 	sessions []sqlc.GetCohortSessionsInCurrentTimetableRow,
 	courseNameMap map[uuid.UUID]string,
 	venueNameMap map[uuid.UUID]string,
-	startOfDay time.Time,
-	endOfDay time.Time,
-	slotDuration time.Duration,
+	week computed.TeachingWeek,
 ) map[string][]TimetableSession {
 
 	// Define day order
-	dayOrder := map[string]int{
-		"Monday": 0, "Tuesday": 1, "Wednesday": 2, "Thursday": 3, "Friday": 4,
+	dayOrder := make(map[string]int)
+	for i, day := range computed.WeekDays {
+		dayOrder[day] = i
 	}
 
 	// Sort sessions by day and time
@@ -233,37 +448,44 @@ func PrepareTimetableJSON(
 		return dayI < dayJ
 	})
 
-	// Precompute all time slots for the day
-	var slots []string
-	for t := startOfDay; t.Before(endOfDay); t = t.Add(slotDuration) {
-		slots = append(slots, t.Format("15:04"))
+	// Precompute the time slots of every teaching day
+	slots := make(map[string][]string)
+	for _, day := range week.Days {
+		for _, start := range week.OpenSlotStarts(day) {
+			slots[day.Day] = append(slots[day.Day], computed.FormatMinutes(start))
+		}
 	}
 
-	// Build a quick lookup for sessions
-	sessionLookup := make(map[string]map[string]TimetableSession)
+	// Build a quick lookup for sessions, the groups of a split course may share a slot
+	sessionLookup := make(map[string]map[string][]TimetableSession)
 	for _, s := range sessions {
-		timeStr := s.SessionTime.Format("15:04")
+		// session times are stored against midnight utc
+		timeStr := s.SessionTime.UTC().Format("15:04")
 
 		if _, exists := sessionLookup[s.Day]; !exists {
-			sessionLookup[s.Day] = make(map[string]TimetableSession)
+			sessionLookup[s.Day] = make(map[string][]TimetableSession)
 		}
 
-		sessionLookup[s.Day][timeStr] = TimetableSession{
+		group, groups := sessionGroup(s.GroupIdx, s.GroupCount)
+		sessionLookup[s.Day][timeStr] = append(sessionLookup[s.Day][timeStr], TimetableSession{
 			Time:       timeStr,
 			CourseID:   s.CourseID,
 			VenueID:    s.VenueID,
 			SessionID:  s.SessionID,
 			CourseName: courseNameMap[s.CourseID],
 			VenueName:  venueNameMap[s.VenueID],
-		}
+			SessionType: s.SessionType,
+			Group:      group,
+			Groups:     groups,
+		})
 	}
 
 	// Fill grouped timetable with all days and slots
 	grouped := make(map[string][]TimetableSession)
-	for _, day := range []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"} {
-		for _, slot := range slots {
-			if session, exists := sessionLookup[day][slot]; exists {
-				grouped[day] = append(grouped[day], session)
+	for _, day := range week.DayNames() {
+		for _, slot := range slots[day] {
+			if slotSessions, exists := sessionLookup[day][slot]; exists {
+				grouped[day] = append(grouped[day], slotSessions...)
 			} else {
 				// Empty slot
 				grouped[day] = append(grouped[day], TimetableSession{
@@ -283,14 +505,13 @@ func PrepareStudentTimetableJSON(
 	sessions []sqlc.GetStudentTimetableSessionsRow,
 	courseNameMap map[uuid.UUID]string,
 	venueNameMap map[uuid.UUID]string,
-	startOfDay time.Time,
-	endOfDay time.Time,
-	slotDuration time.Duration,
+	week computed.TeachingWeek,
 ) map[string][]TimetableSession {
 
 	// Define day order
-	dayOrder := map[string]int{
-		"Monday": 0, "Tuesday": 1, "Wednesday": 2, "Thursday": 3, "Friday": 4,
+	dayOrder := make(map[string]int)
+	for i, day := range computed.WeekDays {
+		dayOrder[day] = i
 	}
 
 	// Sort sessions by day and time
@@ -304,21 +525,25 @@ func PrepareStudentTimetableJSON(
 		return dayI < dayJ
 	})
 
-	// Precompute all time slots for the day
-	var slots []string
-	for t := startOfDay; t.Before(endOfDay); t = t.Add(slotDuration) {
-		slots = append(slots, t.Format("15:04"))
+	// Precompute the time slots of every teaching day
+	slots := make(map[string][]string)
+	for _, day := range week.Days {
+		for _, start := range week.OpenSlotStarts(day) {
+			slots[day.Day] = append(slots[day.Day], computed.FormatMinutes(start))
+		}
 	}
 
 	// Build a quick lookup for sessions
 	sessionLookup := make(map[string]map[string]TimetableSession)
 	for _, s := range sessions {
-		timeStr := s.SessionTime.Format("15:04")
+		// session times are stored against midnight utc
+		timeStr := s.SessionTime.UTC().Format("15:04")
 
 		if _, exists := sessionLookup[s.Day]; !exists {
 			sessionLookup[s.Day] = make(map[string]TimetableSession)
 		}
 
+		group, groups := sessionGroup(s.GroupIdx, s.GroupCount)
 		sessionLookup[s.Day][timeStr] = TimetableSession{
 			Time:       timeStr,
 			CourseID:   s.CourseID,
@@ -326,13 +551,16 @@ func PrepareStudentTimetableJSON(
 			SessionID:  s.SessionID,
 			CourseName: courseNameMap[s.CourseID],
 			VenueName:  venueNameMap[s.VenueID],
+			SessionType: s.SessionType,
+			Group:      group,
+			Groups:     groups,
 		}
 	}
 
 	// Fill grouped timetable with all days and slots
 	grouped := make(map[string][]TimetableSession)
-	for _, day := range []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"} {
-		for _, slot := range slots {
+	for _, day := range week.DayNames() {
+		for _, slot := range slots[day] {
 			if session, exists := sessionLookup[day][slot]; exists {
 				grouped[day] = append(grouped[day], session)
 			} else {
@@ -350,10 +578,28 @@ func PrepareStudentTimetableJSON(
 }
 
 
-func (tts *timeTableService) RetrieveTimetableForACohort(ctx context.Context, cohortId uuid.UUID, uniId uuid.UUID) (timeTableResponse, string, error) {
+func (tts *timeTableService) RetrieveTimetableForACohort(ctx context.Context, cohortId uuid.UUID, uniId uuid.UUID, academicSession string, semester string) (timeTableResponse, string, error) {
+    candidate, err := tts.resolvePublishedCandidate(ctx, uniId, academicSession, semester)
+    if err != nil {
+        switch {
+        case errors.Is(err, errInvalidTerm):
+            return timeTableResponse{}, status.BadRequest.Message, err
+        case errors.Is(err, sql.ErrNoRows):
+            return timeTableResponse{
+                Message:           "No timetable has been published for this term",
+                Data:              make(map[string][]TimetableSession),
+                StatusCode:        status.NotFound.Code,
+                StatusCodeMessage: status.NotFound.Message,
+            }, status.NotFound.Message, nil
+        }
+        tts.logger.Error("error retrieving the published candidate", "err", err)
+        return timeTableResponse{}, status.InternalServerError.Message, err
+    }
+
     timetable, err := tts.repo.FetchSessionsForACohort(ctx, sqlc.GetCohortSessionsInCurrentTimetableParams{
         CohortID:      cohortId,
         UniversityID: uniId,
+        ID:            candidate.ID,
     })
     if err != nil {
         tts.logger.Error("error retrieving timetable for cohort", "err", err)
@@ -392,7 +638,13 @@ func (tts *timeTableService) RetrieveTimetableForACohort(ctx context.Context, co
         venuesNameMap[val.VenueID] = val.VenueName
     }
 
-    formattedTimetable := PrepareTimetableJSON(timetable, courseNameMap, venuesNameMap, timetable[0].StartOfDay, timetable[0].EndOfDay, time.Hour)
+    week, err := tts.loadTeachingWeek(ctx, uniId, timetable[0].StartOfDay.UTC(), timetable[0].EndOfDay.UTC())
+    if err != nil {
+        tts.logger.Error("error loading teaching week", "err", err)
+        return timeTableResponse{}, status.InternalServerError.Message, err
+    }
+
+    formattedTimetable := PrepareTimetableJSON(timetable, courseNameMap, venuesNameMap, week)
 	// slog.Info("formattedtimetable","val",formattedTimetable)
     
     return timeTableResponse{
@@ -407,8 +659,26 @@ func (tts *timeTableService) RetrieveTimetableForAStudent(
 	ctx context.Context,
 	studentId uuid.UUID,
 	uniId uuid.UUID,
+	academicSession string,
+	semester string,
 ) (timeTableResponse, string, error) {
-	timetable, err := tts.repo.FetchSessionsForAStudent(ctx,studentId)
+	candidate, err := tts.resolvePublishedCandidate(ctx, uniId, academicSession, semester)
+	if err != nil {
+		switch {
+		case errors.Is(err, errInvalidTerm):
+			return timeTableResponse{}, status.BadRequest.Message, err
+		case errors.Is(err, sql.ErrNoRows):
+			return timeTableResponse{
+				Message:           "No timetable has been published for this term",
+				StatusCode:        status.NotFound.Code,
+				StatusCodeMessage: status.NotFound.Message,
+			}, status.NotFound.Message, nil
+		}
+		tts.logger.Error("error retrieving the published candidate", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	timetable, err := tts.repo.FetchSessionsForAStudent(ctx, studentId, candidate.ID)
 	if err != nil {
 		tts.logger.Error("error retrieving timetable for student", "err", err)
 		return timeTableResponse{}, status.InternalServerError.Message, err
@@ -443,13 +713,17 @@ func (tts *timeTableService) RetrieveTimetableForAStudent(
 		venuesNameMap[val.VenueID] = val.VenueName
 	}
 
+	week, err := tts.loadTeachingWeek(ctx, uniId, timetable[0].StartOfDay.UTC(), timetable[0].EndOfDay.UTC())
+	if err != nil {
+		tts.logger.Error("error loading teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
 	formattedTimetable := PrepareStudentTimetableJSON(
 		timetable,
 		courseNameMap,
 		venuesNameMap,
-		timetable[0].StartOfDay,
-		timetable[0].EndOfDay,
-		time.Hour,
+		week,
 	)
 
 	return timeTableResponse{
@@ -460,15 +734,150 @@ func (tts *timeTableService) RetrieveTimetableForAStudent(
 	}, status.OK.Message, nil
 }
 
+// the sessions held in a venue in the published timetable of the term, laid out like a cohort's
+func (tts *timeTableService) RetrieveTimetableForAVenue(ctx context.Context, venueId uuid.UUID, uniId uuid.UUID, academicSession string, semester string) (timeTableResponse, string, error) {
+	candidate, err := tts.resolvePublishedCandidate(ctx, uniId, academicSession, semester)
+	if err != nil {
+		switch {
+		case errors.Is(err, errInvalidTerm):
+			return timeTableResponse{}, status.BadRequest.Message, err
+		case errors.Is(err, sql.ErrNoRows):
+			return timeTableResponse{
+				Message:           "No timetable has been published for this term",
+				Data:              make(map[string][]TimetableSession),
+				StatusCode:        status.NotFound.Code,
+				StatusCodeMessage: status.NotFound.Message,
+			}, status.NotFound.Message, nil
+		}
+		tts.logger.Error("error retrieving the published candidate", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
 
+	rows, err := tts.repo.FetchSessionsForAVenue(ctx, sqlc.GetVenueSessionsInCurrentTimetableParams{
+		VenueID:      venueId,
+		UniversityID: uniId,
+		ID:           candidate.ID,
+	})
+	if err != nil {
+		tts.logger.Error("error retrieving timetable for venue", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	if len(rows) == 0 {
+		return timeTableResponse{
+			Message:           "No timetable found for this venue",
+			Data:              make(map[string][]TimetableSession),
+			StatusCode:        status.NotFound.Code,
+			StatusCodeMessage: status.NotFound.Message,
+		}, status.NotFound.Message, nil
+	}
+	timetable := make([]sqlc.GetCohortSessionsInCurrentTimetableRow, 0, len(rows))
+	for _, row := range rows {
+		timetable = append(timetable, sqlc.GetCohortSessionsInCurrentTimetableRow(row))
+	}
 
+	courses, err := tts.repo.RetrieveAllCourses(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving all courses", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	venues, err := tts.repo.RetrieveAllVenues(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving all venues", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	courseNameMap := make(map[uuid.UUID]string)
+	venuesNameMap := make(map[uuid.UUID]string)
+	for _, val := range courses {
+		courseNameMap[val.CourseID] = val.CourseTitle
+	}
+	for _, val := range venues {
+		venuesNameMap[val.VenueID] = val.VenueName
+	}
 
+	week, err := tts.loadTeachingWeek(ctx, uniId, timetable[0].StartOfDay.UTC(), timetable[0].EndOfDay.UTC())
+	if err != nil {
+		tts.logger.Error("error loading teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
 
+	return timeTableResponse{
+		Message:           "Venue Timetable",
+		Data:              PrepareTimetableJSON(timetable, courseNameMap, venuesNameMap, week),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
 
 
+func toJobResponse(job TimetableJob) timetableDto.TimetableJobResponse {
+	return timetableDto.TimetableJobResponse{
+		JobId:          job.JobId,
+		UniversityId:   job.UniversityId,
+		Status:         string(job.Status),
+		AcademicSession: job.AcademicSession,
+		Semester:       job.Semester,
+		Scope:          job.Scope,
+		ScopeId:        job.ScopeId,
+		Seed:           job.Seed,
+		Generation:     job.Generation,
+		Generations:    job.Generations,
+		BestFitness:    job.BestFitness,
+		ElapsedSeconds: job.Elapsed().Seconds(),
+		Error:          job.Error,
+		CreatedAt:      job.CreatedAt,
+		Benchmark:      job.Benchmark,
+	}
+}
 
+// the university of the signed in admin or lecturer, the jobs of any other university are hidden
+// from them
+func (tts *timeTableService) userUniversity(ctx context.Context, userId uuid.UUID) (uuid.UUID, string, error) {
+	uniId, err := tts.repo.RetrieveUserUniversity(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, status.Forbidden.Message, errors.New("user does not belong to a university")
+		}
+		tts.logger.Error("error retrieving the university of the user", "err", err)
+		return uuid.Nil, status.InternalServerError.Message, err
+	}
+	return uniId.UUID, status.OK.Message, nil
+}
 
+func (tts *timeTableService) RetrieveTimetableJob(ctx context.Context, jobId uuid.UUID, userId uuid.UUID) (timeTableResponse, string, error) {
+	uniId, statusMsg, err := tts.userUniversity(ctx, userId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	job, ok := tts.jobs.get(jobId, uniId)
+	if !ok {
+		return timeTableResponse{}, status.NotFound.Message, errors.New("timetable job not found")
+	}
 
+	return timeTableResponse{
+		Message:           "Timetable job retrieved successfully",
+		Data:              toJobResponse(job),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
 
+func (tts *timeTableService) CancelTimetableJob(ctx context.Context, jobId uuid.UUID, userId uuid.UUID) (timeTableResponse, string, error) {
+	uniId, statusMsg, err := tts.userUniversity(ctx, userId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	job, ok := tts.jobs.cancel(jobId, uniId)
+	if !ok {
+		return timeTableResponse{}, status.NotFound.Message, errors.New("timetable job not found")
+	}
+	if job.isFinished() {
+		return timeTableResponse{}, status.Conflict.Message, fmt.Errorf("timetable job has already finished with status %s", job.Status)
+	}
 
-
+	return timeTableResponse{
+		Message:           "Timetable job cancellation requested",
+		Data:              toJobResponse(job),
+		StatusCode:        status.Accepted.Code,
+		StatusCodeMessage: status.Accepted.Message,
+	}, status.Accepted.Message, nil
+}
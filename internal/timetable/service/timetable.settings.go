@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+var errInvalidGAParams = errors.New("invalid genetic algorithm params")
+var errUnknownSolver = errors.New("unknown solver")
+
+// starts from the defaults, then the university settings, then whatever the request overrides.
+// when no seed is given anywhere a new one is picked so it can still be stored and replayed
+func (tts *timeTableService) resolveGAParams(ctx context.Context, uniId uuid.UUID, override *timetableDto.GAParamsDto) (computed.GAParams, error) {
+	params := computed.DefaultGAParams()
+	params.Seed = time.Now().UnixNano()
+
+	settings, err := tts.repo.GetTimetableSettings(ctx, uniId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return computed.GAParams{}, err
+	}
+	if err == nil {
+		params.PopulationSize = int(settings.PopulationSize)
+		params.Generations = int(settings.Generations)
+		params.MutationRate = settings.MutationRate
+		params.TournamentSize = int(settings.TournamentSize)
+		params.ElitismFraction = settings.ElitismFraction
+		if settings.Seed.Valid {
+			params.Seed = settings.Seed.Int64
+		}
+		params.StallGenerations = int(settings.StallGenerations)
+		params.TimeBudget = time.Duration(settings.TimeBudgetSeconds) * time.Second
+		params.StopWhenFeasible = settings.StopWhenFeasible
+	}
+
+	if override != nil {
+		if override.PopulationSize != nil {
+			params.PopulationSize = int(*override.PopulationSize)
+		}
+		if override.Generations != nil {
+			params.Generations = int(*override.Generations)
+		}
+		if override.MutationRate != nil {
+			params.MutationRate = *override.MutationRate
+		}
+		if override.TournamentSize != nil {
+			params.TournamentSize = int(*override.TournamentSize)
+		}
+		if override.ElitismFraction != nil {
+			params.ElitismFraction = *override.ElitismFraction
+		}
+		if override.Seed != nil {
+			params.Seed = *override.Seed
+		}
+		if override.Workers != nil {
+			params.Workers = int(*override.Workers)
+		}
+		if override.StallGenerations != nil {
+			params.StallGenerations = int(*override.StallGenerations)
+		}
+		if override.TimeBudgetSeconds != nil {
+			params.TimeBudget = time.Duration(*override.TimeBudgetSeconds) * time.Second
+		}
+		if override.StopWhenFeasible != nil {
+			params.StopWhenFeasible = *override.StopWhenFeasible
+		}
+	}
+	// pinned so the stored run says how many workers it had
+	params.Workers = params.WorkerCount()
+
+	if err := params.Validate(); err != nil {
+		return computed.GAParams{}, fmt.Errorf("%w: %v", errInvalidGAParams, err)
+	}
+	return params, nil
+}
+
+// the solver named in the request, otherwise the one in the university settings, otherwise the genetic algorithm
+func (tts *timeTableService) resolveSolver(ctx context.Context, uniId uuid.UUID, override string, params computed.GAParams) (computed.Solver, error) {
+	name := override
+	if name == "" {
+		settings, err := tts.repo.GetTimetableSettings(ctx, uniId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		name = settings.Solver
+	}
+	solver, err := computed.NewSolver(name, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnknownSolver, err)
+	}
+	return solver, nil
+}
+
+func toSettingsResponse(settings sqlc.TimetableSetting) timetableDto.TimetableSettingsResponse {
+	resp := timetableDto.TimetableSettingsResponse{
+		UniversityId:    settings.UniversityID,
+		PopulationSize:  settings.PopulationSize,
+		Generations:     settings.Generations,
+		MutationRate:    settings.MutationRate,
+		TournamentSize:  settings.TournamentSize,
+		ElitismFraction: settings.ElitismFraction,
+		Solver:          settings.Solver,
+		StallGenerations:  settings.StallGenerations,
+		TimeBudgetSeconds: settings.TimeBudgetSeconds,
+		StopWhenFeasible:  settings.StopWhenFeasible,
+	}
+	if settings.Seed.Valid {
+		seed := settings.Seed.Int64
+		resp.Seed = &seed
+	}
+	return resp
+}
+
+func (tts *timeTableService) RetrieveTimetableSettings(ctx context.Context, uniId uuid.UUID) (timeTableResponse, string, error) {
+	settings, err := tts.repo.GetTimetableSettings(ctx, uniId)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			tts.logger.Error("error retrieving timetable settings", "err", err)
+			return timeTableResponse{}, status.InternalServerError.Message, err
+		}
+		// the university has not saved any settings yet so it runs on the defaults
+		defaults := computed.DefaultGAParams()
+		settings = sqlc.TimetableSetting{
+			UniversityID:    uniId,
+			PopulationSize:  int32(defaults.PopulationSize),
+			Generations:     int32(defaults.Generations),
+			MutationRate:    defaults.MutationRate,
+			TournamentSize:  int32(defaults.TournamentSize),
+			ElitismFraction: defaults.ElitismFraction,
+			Solver:          computed.SolverGenetic,
+		}
+	}
+
+	return timeTableResponse{
+		Message:           "Timetable settings retrieved successfully",
+		Data:              toSettingsResponse(settings),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+func (tts *timeTableService) UpdateTimetableSettings(ctx context.Context, body timetableDto.TimetableSettingsDto) (timeTableResponse, string, error) {
+	params := computed.DefaultGAParams()
+	params.PopulationSize = int(body.PopulationSize)
+	params.Generations = int(body.Generations)
+	params.MutationRate = body.MutationRate
+	params.TournamentSize = int(body.TournamentSize)
+	params.ElitismFraction = body.ElitismFraction
+	params.StallGenerations = int(body.StallGenerations)
+	params.TimeBudget = time.Duration(body.TimeBudgetSeconds) * time.Second
+	if err := params.Validate(); err != nil {
+		return timeTableResponse{}, status.BadRequest.Message, err
+	}
+
+	solver := body.Solver
+	if solver == "" {
+		solver = computed.SolverGenetic
+	}
+	if !computed.IsKnownSolver(solver) {
+		return timeTableResponse{}, status.BadRequest.Message, fmt.Errorf("unknown solver %q", solver)
+	}
+
+	seed := sql.NullInt64{}
+	if body.Seed != nil {
+		seed = sql.NullInt64{Int64: *body.Seed, Valid: true}
+	}
+
+	settings, err := tts.repo.UpsertTimetableSettings(ctx, sqlc.UpsertTimetableSettingsParams{
+		UniversityID:    body.UniversityId,
+		PopulationSize:  body.PopulationSize,
+		Generations:     body.Generations,
+		MutationRate:    body.MutationRate,
+		TournamentSize:  body.TournamentSize,
+		ElitismFraction: body.ElitismFraction,
+		Seed:            seed,
+		Solver:          solver,
+		StallGenerations:  body.StallGenerations,
+		TimeBudgetSeconds: body.TimeBudgetSeconds,
+		StopWhenFeasible:  body.StopWhenFeasible,
+	})
+	if err != nil {
+		tts.logger.Error("error updating timetable settings", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	return timeTableResponse{
+		Message:           "Timetable settings updated successfully",
+		Data:              toSettingsResponse(settings),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/google/uuid"
+)
+
+const (
+	SemesterFirst  = "First"
+	SemesterSecond = "Second"
+)
+
+var errInvalidTerm = errors.New("invalid term")
+
+// the academic session and semester a timetable schedules the courses of, e.g 2025/2026 and First.
+// both are empty on candidates generated before timetables had a term, those hold every course
+type academicTerm struct {
+	Session  string
+	Semester string
+}
+
+func (t academicTerm) nullSession() sql.NullString {
+	return sql.NullString{String: t.Session, Valid: t.Session != ""}
+}
+
+func (t academicTerm) nullSemester() sql.NullString {
+	return sql.NullString{String: t.Semester, Valid: t.Semester != ""}
+}
+
+func candidateTerm(candidate sqlc.Candidate) academicTerm {
+	return academicTerm{Session: candidate.AcademicSession.String, Semester: candidate.Semester.String}
+}
+
+// the term a timetable is generated for or read from, the session falls back to the current
+// session of the university
+func (tts *timeTableService) resolveTerm(ctx context.Context, uniId uuid.UUID, session string, semester string) (academicTerm, error) {
+	if semester != SemesterFirst && semester != SemesterSecond {
+		return academicTerm{}, fmt.Errorf("%w: the semester must be %s or %s", errInvalidTerm, SemesterFirst, SemesterSecond)
+	}
+	session = strings.TrimSpace(session)
+	if session == "" {
+		current, err := tts.repo.RetrieveCurrentSession(ctx, uniId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return academicTerm{}, err
+		}
+		session = strings.TrimSpace(current.String)
+	}
+	if session == "" {
+		return academicTerm{}, fmt.Errorf("%w: give the academic session or set the current session of the university", errInvalidTerm)
+	}
+	return academicTerm{Session: session, Semester: semester}, nil
+}
+
+// the published candidate students and cohorts see. with a semester it is the one published for
+// that term, otherwise the one published last whatever its term
+func (tts *timeTableService) resolvePublishedCandidate(ctx context.Context, uniId uuid.UUID, session string, semester string) (sqlc.Candidate, error) {
+	if semester == "" && strings.TrimSpace(session) == "" {
+		return tts.repo.RetrieveLatestPublishedCandidate(ctx, uniId)
+	}
+	term, err := tts.resolveTerm(ctx, uniId, session, semester)
+	if err != nil {
+		return sqlc.Candidate{}, err
+	}
+	return tts.repo.RetrievePublishedCandidate(ctx, sqlc.GetPublishedCandidateParams{
+		UniversityID:    uniId,
+		AcademicSession: term.nullSession(),
+		Semester:        term.nullSemester(),
+	})
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+const (
+	TravelSourceManual      = "MANUAL"
+	TravelSourceCoordinates = "COORDINATES"
+)
+
+// a pair of venues is stored once, with the smaller id first
+func orderedVenuePair(a uuid.UUID, b uuid.UUID) (uuid.UUID, uuid.UUID) {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		return b, a
+	}
+	return a, b
+}
+
+// the walking time between every pair of venues that has one, set by the university or worked
+// out from the coordinates of the venues
+func (tts *timeTableService) RetrieveVenueTravelTimes(ctx context.Context, uniId uuid.UUID) (timeTableResponse, string, error) {
+	venues, err := tts.repo.RetrieveAllVenues(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving venues", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	overrides, err := tts.repo.RetrieveVenueTravelTimes(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving venue travel times", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	manual := make(map[[2]uuid.UUID]int32, len(overrides))
+	for _, override := range overrides {
+		manual[[2]uuid.UUID{override.FromVenueID, override.ToVenueID}] = override.Minutes
+	}
+	sort.Slice(venues, func(i, j int) bool {
+		return venues[i].VenueName < venues[j].VenueName
+	})
+
+	travelTimes := make([]timetableDto.VenueTravelTimeResponse, 0)
+	for i, from := range venues {
+		for _, to := range venues[i+1:] {
+			travel := timetableDto.VenueTravelTimeResponse{
+				FromVenueId:   from.VenueID,
+				FromVenueName: from.VenueName,
+				ToVenueId:     to.VenueID,
+				ToVenueName:   to.VenueName,
+			}
+			first, second := orderedVenuePair(from.VenueID, to.VenueID)
+			if minutes, ok := manual[[2]uuid.UUID{first, second}]; ok {
+				travel.Minutes = int(minutes)
+				travel.Source = TravelSourceManual
+			} else if from.VenueLatitude.Valid && from.VenueLongitude.Valid && to.VenueLatitude.Valid && to.VenueLongitude.Valid {
+				travel.Minutes = computed.WalkingMinutes(from.VenueLatitude.Float64, from.VenueLongitude.Float64, to.VenueLatitude.Float64, to.VenueLongitude.Float64)
+				travel.Source = TravelSourceCoordinates
+			} else {
+				continue
+			}
+			travelTimes = append(travelTimes, travel)
+		}
+	}
+
+	return timeTableResponse{
+		Message:           "Venue travel times retrieved successfully",
+		Data:              travelTimes,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// sets the walking time between two venues of the university, both ways
+func (tts *timeTableService) SetVenueTravelTime(ctx context.Context, body timetableDto.VenueTravelTimeDto) (timeTableResponse, string, error) {
+	if body.FromVenueId == body.ToVenueId {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("a travel time needs two different venues")
+	}
+	if body.Minutes < 0 {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("a travel time cannot be negative")
+	}
+	venues, err := tts.repo.RetrieveAllVenues(ctx, body.UniversityId)
+	if err != nil {
+		tts.logger.Error("error retrieving venues", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	found := 0
+	for _, venue := range venues {
+		if venue.VenueID == body.FromVenueId || venue.VenueID == body.ToVenueId {
+			found++
+		}
+	}
+	if found != 2 {
+		return timeTableResponse{}, status.BadRequest.Message, errors.New("both venues must belong to the university")
+	}
+
+	from, to := orderedVenuePair(body.FromVenueId, body.ToVenueId)
+	err = tts.repo.UpsertVenueTravelTime(ctx, sqlc.UpsertVenueTravelTimeParams{
+		UniversityID: body.UniversityId,
+		FromVenueID:  from,
+		ToVenueID:    to,
+		Minutes:      body.Minutes,
+	})
+	if err != nil {
+		tts.logger.Error("error setting venue travel time", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	return timeTableResponse{
+		Message:           "Venue travel time set successfully",
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// removes a time the university set, the pair goes back to the time from the coordinates
+func (tts *timeTableService) DeleteVenueTravelTime(ctx context.Context, uniId uuid.UUID, fromVenueId uuid.UUID, toVenueId uuid.UUID) (timeTableResponse, string, error) {
+	from, to := orderedVenuePair(fromVenueId, toVenueId)
+	err := tts.repo.DeleteVenueTravelTime(ctx, sqlc.DeleteVenueTravelTimeParams{
+		UniversityID: uniId,
+		FromVenueID:  from,
+		ToVenueID:    to,
+	})
+	if err != nil {
+		tts.logger.Error("error deleting venue travel time", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	return timeTableResponse{
+		Message:           "Venue travel time deleted successfully",
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+// how often the candidate placed the courses of every department outside the department's own
+// venues, only the department's when departmentId is set
+func (tts *timeTableService) RetrieveDepartmentVenueUsage(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID, departmentId uuid.UUID) (timeTableResponse, string, error) {
+	candidate, statusMsg, err := tts.retrieveCandidate(ctx, candidateId, uniId)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	rows, err := tts.repo.RetrieveDepartmentVenueUsage(ctx, candidate.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving department venue usage", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	usage := make([]timetableDto.DepartmentVenueUsageResponse, 0, len(rows))
+	for _, row := range rows {
+		if departmentId != uuid.Nil && row.DepartmentID != departmentId {
+			continue
+		}
+		department := timetableDto.DepartmentVenueUsageResponse{
+			DepartmentId:   row.DepartmentID,
+			DepartmentName: row.DepartmentName,
+			Sessions:       int(row.Sessions),
+			InOwnRooms:     int(row.OwnDepartment),
+			InFacultyRooms: int(row.OwnFaculty),
+			InSharedRooms:  int(row.Shared),
+		}
+		department.InOtherRooms = department.Sessions - department.InOwnRooms - department.InFacultyRooms - department.InSharedRooms
+		department.OutsideOwnRooms = department.Sessions - department.InOwnRooms
+		if department.Sessions > 0 {
+			department.OutsideShare = float64(department.OutsideOwnRooms) / float64(department.Sessions)
+		}
+		usage = append(usage, department)
+	}
+
+	return timeTableResponse{
+		Message:           "Department venue usage retrieved successfully",
+		Data:              usage,
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
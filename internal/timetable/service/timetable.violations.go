@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+func toViolationResponses(details []computed.ViolationDetail) []timetableDto.ViolationResponse {
+	violations := make([]timetableDto.ViolationResponse, 0, len(details))
+	for _, detail := range details {
+		severity := "SOFT"
+		if detail.Hard {
+			severity = "HARD"
+		}
+		violations = append(violations, timetableDto.ViolationResponse{
+			Type:      detail.Type,
+			Severity:  severity,
+			Penalty:   detail.Penalty,
+			Day:       detail.Day,
+			StartTime: detail.StartTime,
+			EndTime:   detail.EndTime,
+			Courses:   detail.Courses,
+			Lecturers: detail.Lecturers,
+			Venues:    detail.Venues,
+			Cohorts:   detail.Cohorts,
+			Message:   detail.Message,
+		})
+	}
+	return violations
+}
+
+// every hard and soft violation of a saved candidate, checked against the current data of the university
+func (tts *timeTableService) RetrieveCandidateViolations(ctx context.Context, candidateId uuid.UUID, uniId uuid.UUID) (timeTableResponse, string, error) {
+	candidate, err := tts.repo.RetrieveCandidate(ctx, candidateId, uniId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return timeTableResponse{}, status.NotFound.Message, errors.New("candidate not found")
+		}
+		tts.logger.Error("error retrieving candidate", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	saved, statusMsg, err := tts.loadSavedTimetable(ctx, uniId, candidate)
+	if err != nil {
+		return timeTableResponse{}, statusMsg, err
+	}
+	rows, err := tts.repo.RetrieveCandidateSessionPlacements(ctx, candidate.ID)
+	if err != nil {
+		tts.logger.Error("error retrieving candidate session placements", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	storedConflicts := 0
+	for _, row := range rows {
+		if row.Conflict {
+			storedConflicts++
+		}
+	}
+
+	cand, missing := computed.CandidateFromPlacements(saved.pre, saved.week, saved.existing, saved.coursesMap, saved.venueMap)
+	hard := append(missing, computed.FindHardViolations(saved.pre, cand)...)
+	soft := computed.FindSoftViolations(saved.pre, cand)
+	softPenalty := 0.0
+	for _, v := range soft {
+		softPenalty += v.Penalty
+	}
+
+	return timeTableResponse{
+		Message: "Candidate violations retrieved successfully",
+		Data: timetableDto.ViolationReportResponse{
+			CandidateId:     candidate.ID,
+			CandidateStatus: candidate.CandidateStatus,
+			HardCount:       len(hard),
+			SoftCount:       len(soft),
+			SoftPenalty:     softPenalty,
+			StoredConflicts: storedConflicts,
+			Hard:            toViolationResponses(computed.DescribeViolations(saved.pre, saved.week, hard)),
+			Soft:            toViolationResponses(computed.DescribeViolations(saved.pre, saved.week, soft)),
+		},
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	timetableDto "github.com/Cxons/unischedulebackend/internal/timetable/dto"
+	status "github.com/Cxons/unischedulebackend/pkg/statuscodes"
+	"github.com/google/uuid"
+)
+
+var errInvalidTeachingWeek = errors.New("invalid teaching week")
+
+// the days a university has configured and its slot length, no days means it has not configured any
+func (tts *timeTableService) loadTeachingDays(ctx context.Context, uniId uuid.UUID) ([]computed.TeachingDay, int, error) {
+	slotMinutes := 60
+	settings, err := tts.repo.GetTimetableSettings(ctx, uniId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, err
+	}
+	if err == nil {
+		slotMinutes = int(settings.SlotMinutes)
+	}
+
+	rows, err := tts.repo.GetTeachingDays(ctx, uniId)
+	if err != nil {
+		return nil, 0, err
+	}
+	days := make([]computed.TeachingDay, 0, len(rows))
+	for _, row := range rows {
+		startMinutes, err := computed.ParseMinutes(row.StartTime)
+		if err != nil {
+			return nil, 0, err
+		}
+		endMinutes, err := computed.ParseMinutes(row.EndTime)
+		if err != nil {
+			return nil, 0, err
+		}
+		days = append(days, computed.TeachingDay{Day: row.Day, StartMinutes: startMinutes, EndMinutes: endMinutes})
+	}
+	return days, slotMinutes, nil
+}
+
+// the teaching week of a university. one that has not configured its days teaches
+// monday to friday between the given start and end of day
+func (tts *timeTableService) loadTeachingWeek(ctx context.Context, uniId uuid.UUID, startOfDay time.Time, endOfDay time.Time) (computed.TeachingWeek, error) {
+	days, slotMinutes, err := tts.loadTeachingDays(ctx, uniId)
+	if err != nil {
+		return computed.TeachingWeek{}, err
+	}
+
+	if len(days) == 0 {
+		if startOfDay.IsZero() || endOfDay.IsZero() {
+			return computed.TeachingWeek{}, fmt.Errorf("%w: no teaching days are configured so the start and end of day are required", errInvalidTeachingWeek)
+		}
+		week := computed.DefaultTeachingWeek(computed.MinutesOfDay(startOfDay), computed.MinutesOfDay(endOfDay))
+		week.SlotMinutes = slotMinutes
+		if err := week.Validate(); err != nil {
+			return computed.TeachingWeek{}, fmt.Errorf("%w: %v", errInvalidTeachingWeek, err)
+		}
+		return week, nil
+	}
+
+	week, err := computed.NewTeachingWeek(days, slotMinutes)
+	if err != nil {
+		return computed.TeachingWeek{}, fmt.Errorf("%w: %v", errInvalidTeachingWeek, err)
+	}
+	return week, nil
+}
+
+// midnight utc of the date session times are stored against, today when none was given
+func weekBaseDate(startOfDay time.Time) time.Time {
+	if startOfDay.IsZero() {
+		startOfDay = time.Now()
+	}
+	y, m, d := startOfDay.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func minutesToDuration(minutes int) time.Duration {
+	return time.Duration(minutes) * time.Minute
+}
+
+func toTeachingWeekResponse(uniId uuid.UUID, days []computed.TeachingDay, slotMinutes int) timetableDto.TeachingWeekResponse {
+	week := computed.TeachingWeek{Days: days, SlotMinutes: slotMinutes}
+	resp := timetableDto.TeachingWeekResponse{
+		UniversityId: uniId,
+		Configured:   len(days) > 0,
+		SlotMinutes:  slotMinutes,
+		Days:         make([]timetableDto.TeachingDayResponse, 0, len(days)),
+	}
+	if len(days) > 0 {
+		resp.SlotsPerDay = week.SlotsPerDay()
+	}
+	for _, day := range days {
+		resp.Days = append(resp.Days, timetableDto.TeachingDayResponse{
+			Day:       day.Day,
+			StartTime: computed.FormatMinutes(day.StartMinutes),
+			EndTime:   computed.FormatMinutes(day.EndMinutes),
+		})
+	}
+	return resp
+}
+
+func (tts *timeTableService) RetrieveTeachingWeek(ctx context.Context, uniId uuid.UUID) (timeTableResponse, string, error) {
+	days, slotMinutes, err := tts.loadTeachingDays(ctx, uniId)
+	if err != nil {
+		tts.logger.Error("error retrieving teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+	week, err := computed.NewTeachingWeek(days, slotMinutes)
+	if err != nil && len(days) > 0 {
+		tts.logger.Warn("stored teaching week is invalid", "universityId", uniId, "err", err)
+	}
+
+	return timeTableResponse{
+		Message:           "Teaching week retrieved successfully",
+		Data:              toTeachingWeekResponse(uniId, week.Days, slotMinutes),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
+
+// replaces every teaching day of the university with the ones in the body
+func (tts *timeTableService) UpdateTeachingWeek(ctx context.Context, body timetableDto.TeachingWeekDto) (timeTableResponse, string, error) {
+	days := make([]computed.TeachingDay, 0, len(body.Days))
+	for _, day := range body.Days {
+		startMinutes, err := computed.ParseMinutes(day.StartTime)
+		if err != nil {
+			return timeTableResponse{}, status.BadRequest.Message, fmt.Errorf("invalid start time for %s: %v", day.Day, err)
+		}
+		endMinutes, err := computed.ParseMinutes(day.EndTime)
+		if err != nil {
+			return timeTableResponse{}, status.BadRequest.Message, fmt.Errorf("invalid end time for %s: %v", day.Day, err)
+		}
+		days = append(days, computed.TeachingDay{Day: day.Day, StartMinutes: startMinutes, EndMinutes: endMinutes})
+	}
+
+	week, err := computed.NewTeachingWeek(days, int(body.SlotMinutes))
+	if err != nil {
+		return timeTableResponse{}, status.BadRequest.Message, err
+	}
+
+	params := make([]sqlc.CreateTeachingDayParams, 0, len(week.Days))
+	for _, day := range week.Days {
+		params = append(params, sqlc.CreateTeachingDayParams{
+			UniversityID: body.UniversityId,
+			Day:          day.Day,
+			StartTime:    computed.FormatMinutes(day.StartMinutes),
+			EndTime:      computed.FormatMinutes(day.EndMinutes),
+		})
+	}
+	if err := tts.repo.ReplaceTeachingWeek(ctx, body.UniversityId, body.SlotMinutes, params); err != nil {
+		tts.logger.Error("error updating teaching week", "err", err)
+		return timeTableResponse{}, status.InternalServerError.Message, err
+	}
+
+	return timeTableResponse{
+		Message:           "Teaching week updated successfully",
+		Data:              toTeachingWeekResponse(body.UniversityId, week.Days, week.SlotMinutes),
+		StatusCode:        status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	}, status.OK.Message, nil
+}
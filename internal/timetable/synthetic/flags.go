@@ -0,0 +1,59 @@
+package synthetic
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// registers -preset and a flag for every field of Config on fs. the returned function gives the
+// preset with the flags that were set laid over it, call it once fs is parsed
+func Flags(fs *flag.FlagSet) func() (Config, error) {
+	preset := fs.String("preset", "medium", "size of the university, one of "+strings.Join(PresetNames(), ", ")+". the flags below change it")
+	var values Config
+	apply := make(map[string]func(c *Config))
+	intFlag := func(name string, usage string, field func(c *Config) *int) {
+		fs.IntVar(field(&values), name, 0, usage)
+		apply[name] = func(c *Config) { *field(c) = *field(&values) }
+	}
+	rateFlag := func(name string, usage string, field func(c *Config) *float64) {
+		fs.Float64Var(field(&values), name, 0, usage)
+		apply[name] = func(c *Config) { *field(c) = *field(&values) }
+	}
+
+	intFlag("faculties", "faculties", func(c *Config) *int { return &c.Faculties })
+	intFlag("departments", "departments per faculty", func(c *Config) *int { return &c.DepartmentsPerFaculty })
+	intFlag("levels", "levels per department, one cohort each", func(c *Config) *int { return &c.Levels })
+	intFlag("courses-per-cohort", "courses a cohort takes from its own department", func(c *Config) *int { return &c.CoursesPerCohort })
+	intFlag("lecturers", "lecturers per department", func(c *Config) *int { return &c.LecturersPerDepartment })
+	intFlag("min-cohort", "smallest cohort", func(c *Config) *int { return &c.MinCohortSize })
+	intFlag("max-cohort", "largest cohort", func(c *Config) *int { return &c.MaxCohortSize })
+	intFlag("max-sessions", "most sessions a course has a week", func(c *Config) *int { return &c.MaxSessionsPerWeek })
+	intFlag("max-hours", "longest session in hours", func(c *Config) *int { return &c.MaxCourseHours })
+	intFlag("venues", "venues", func(c *Config) *int { return &c.Venues })
+	intFlag("min-capacity", "smallest venue", func(c *Config) *int { return &c.MinVenueCapacity })
+	intFlag("max-capacity", "largest venue, the largest class always fits one", func(c *Config) *int { return &c.MaxVenueCapacity })
+	rateFlag("shared-venues", "share of venues every faculty may use", func(c *Config) *float64 { return &c.SharedVenueRate })
+	rateFlag("shared-courses", "chance a course is also taken by another department", func(c *Config) *float64 { return &c.SharedCourseRate })
+	rateFlag("team-taught", "chance a course has a second lecturer", func(c *Config) *float64 { return &c.TeamTaughtRate })
+	rateFlag("lecturer-unavailability", "chance a lecturer is unavailable in a slot", func(c *Config) *float64 { return &c.LecturerUnavailability })
+	rateFlag("venue-unavailability", "chance a venue is unavailable in a slot", func(c *Config) *float64 { return &c.VenueUnavailability })
+	intFlag("days", "teaching days from monday", func(c *Config) *int { return &c.Days })
+	intFlag("day-start", "minutes after midnight a day starts", func(c *Config) *int { return &c.DayStartMinutes })
+	intFlag("day-end", "minutes after midnight a day ends", func(c *Config) *int { return &c.DayEndMinutes })
+	intFlag("slot-minutes", "length of a slot, 30, 60 or 90", func(c *Config) *int { return &c.SlotMinutes })
+
+	return func() (Config, error) {
+		build, ok := Presets[*preset]
+		if !ok {
+			return Config{}, fmt.Errorf("unknown preset %q, use one of %s", *preset, strings.Join(PresetNames(), ", "))
+		}
+		c := build()
+		fs.Visit(func(f *flag.Flag) {
+			if set, ok := apply[f.Name]; ok {
+				set(&c)
+			}
+		})
+		return c, c.Validate()
+	}
+}
@@ -0,0 +1,115 @@
+package synthetic
+
+import (
+	"database/sql"
+
+	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	"github.com/google/uuid"
+)
+
+// the rows the repository would return once the university is seeded with WriteSQL
+func (u *University) Rows() computed.UniversityRows {
+	rows := computed.UniversityRows{
+		LecturerUnavailability: make([]sqlc.RetrieveTotalLecturerUnavailabilityRow, 0, len(u.LecturerUnavailability)),
+		VenueUnavailability:    make([]sqlc.RetrieveTotalVenueUnavailabilityRow, 0, len(u.VenueUnavailability)),
+	}
+	for _, course := range u.Courses {
+		lecturerId := uuid.NullUUID{UUID: course.LecturerIds[0], Valid: true}
+		rows.Courses = append(rows.Courses, sqlc.RetrieveCoursesForSemesterRow{
+			CourseID:         course.Id,
+			CourseCode:       course.Code,
+			CourseTitle:      course.Title,
+			CourseCreditUnit: int32(course.CreditUnit),
+			CourseDuration:   int32(course.Hours),
+			DepartmentID:     course.DepartmentId,
+			UniversityID:     u.Id,
+			LecturerID:       lecturerId,
+			SessionsPerWeek:  int32(course.SessionsPerWeek),
+			Semester:         course.Semester,
+		})
+		for _, venueId := range course.VenueIds {
+			rows.CoursesAndVenues = append(rows.CoursesAndVenues, sqlc.RetrieveAllCoursesAndTheirVenueIdsRow{
+				CourseID:         course.Id,
+				CourseCode:       course.Code,
+				CourseTitle:      course.Title,
+				CourseCreditUnit: int32(course.CreditUnit),
+				CourseDuration:   int32(course.Hours),
+				DepartmentID:     course.DepartmentId,
+				UniversityID:     u.Id,
+				LecturerID:       lecturerId,
+				SessionsPerWeek:  int32(course.SessionsPerWeek),
+				Level:            int32(course.Level),
+				Semester:         course.Semester,
+				LecturerMode:     course.LecturerMode,
+				VenueID:          uuid.NullUUID{UUID: venueId, Valid: true},
+			})
+		}
+		for _, lecturerId := range course.LecturerIds {
+			rows.CourseLecturers = append(rows.CourseLecturers, sqlc.RetrieveCourseLecturersForUniRow{CourseID: course.Id, LecturerID: lecturerId})
+		}
+		for _, cohortId := range course.CohortIds {
+			rows.CohortsForCourses = append(rows.CohortsForCourses, sqlc.RetrieveCohortsForAllCoursesRow{CohortID: cohortId, CourseID: course.Id, UniversityID: u.Id})
+		}
+	}
+	for _, lecturer := range u.Lecturers {
+		rows.Lecturers = append(rows.Lecturers, sqlc.RetrieveTotalLecturersRow{
+			LecturerID:        lecturer.Id,
+			LecturerFirstName: lecturer.FirstName,
+			LecturerLastName:  lecturer.LastName,
+			LecturerEmail:     lecturer.Email,
+		})
+	}
+	for _, venue := range u.Venues {
+		rows.Venues = append(rows.Venues, sqlc.RetrieveAllVenuesRow{VenueID: venue.Id, VenueName: venue.Name, Capacity: int32(venue.Capacity)})
+	}
+	for _, cohort := range u.Cohorts {
+		rows.Cohorts = append(rows.Cohorts, sqlc.Cohort{
+			CohortID:           cohort.Id,
+			CohortName:         cohort.Name,
+			CohortLevel:        int32(cohort.Level),
+			CohortDepartmentID: cohort.DepartmentId,
+			CohortFacultyID:    cohort.FacultyId,
+			CohortUniversityID: u.Id,
+			CohortSize:         sql.NullInt32{Int32: int32(cohort.Size), Valid: true},
+		})
+		// no students are seeded, the cohort size is used instead
+		rows.CohortStudentCounts = append(rows.CohortStudentCounts, sqlc.RetrieveCohortStudentCountsRow{CohortID: cohort.Id})
+	}
+	for _, period := range u.LecturerUnavailability {
+		rows.LecturerUnavailability = append(rows.LecturerUnavailability, sqlc.RetrieveTotalLecturerUnavailabilityRow{
+			LecturerID: period.Id,
+			Day:        period.Day,
+			StartTime:  pgTime(period.StartMinutes),
+			EndTime:    pgTime(period.EndMinutes),
+		})
+	}
+	for _, period := range u.VenueUnavailability {
+		rows.VenueUnavailability = append(rows.VenueUnavailability, sqlc.RetrieveTotalVenueUnavailabilityRow{
+			VenueID:   period.Id,
+			Day:       sql.NullString{String: period.Day, Valid: true},
+			StartTime: pgTime(period.StartMinutes),
+			EndTime:   pgTime(period.EndMinutes),
+		})
+	}
+	return rows
+}
+
+// minutes after midnight as postgres prints a TIME
+func pgTime(minutes int) string {
+	return computed.FormatMinutes(minutes) + ":00"
+}
+
+// the problem file of the university, the same POST /timetable/export gives once it is seeded
+// and has not changed its constraints
+func (u *University) Problem() (*computed.Problem, error) {
+	pre, cohortMap, venueMap, lecturerMap, coursesMap, err := computed.PreComputedFromRows(u.Rows(), u.Week)
+	if err != nil {
+		return nil, err
+	}
+	return computed.NewProblem(pre, u.Week, computed.DefaultConstraintSettings(),
+		computed.IdsByIdx(venueMap, pre.NumVenues),
+		computed.IdsByIdx(lecturerMap, pre.NumLecturers),
+		computed.IdsByIdx(cohortMap, pre.NumCohorts),
+		computed.IdsByIdx(coursesMap, pre.NumCourses))
+}
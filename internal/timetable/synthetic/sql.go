@@ -0,0 +1,169 @@
+package synthetic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	"github.com/google/uuid"
+)
+
+// no one can log in with it, it is not a bcrypt hash
+const seedPassword = "synthetic-no-login"
+
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func quoteId(id uuid.UUID) string {
+	return quote(id.String())
+}
+
+func quoteNullId(id uuid.NullUUID) string {
+	if !id.Valid {
+		return "NULL"
+	}
+	return quoteId(id.UUID)
+}
+
+func quoteTime(minutes int) string {
+	return quote(computed.FormatMinutes(minutes))
+}
+
+// writes rows into a table a few hundred at a time
+type insert struct {
+	w       *bufio.Writer
+	head    string
+	pending int
+}
+
+func newInsert(w *bufio.Writer, table string, columns ...string) *insert {
+	return &insert{w: w, head: fmt.Sprintf("INSERT INTO %s (%s) VALUES\n", table, strings.Join(columns, ", "))}
+}
+
+func (in *insert) row(values ...string) {
+	if in.pending == 0 {
+		in.w.WriteString(in.head)
+	} else {
+		in.w.WriteString(",\n")
+	}
+	in.w.WriteString("    (" + strings.Join(values, ", ") + ")")
+	in.pending++
+	if in.pending == 500 {
+		in.flush()
+	}
+}
+
+func (in *insert) flush() {
+	if in.pending > 0 {
+		in.w.WriteString(";\n\n")
+		in.pending = 0
+	}
+}
+
+// the university as sql that seeds an empty database with every migration applied, in one transaction.
+// seeding the same seed twice fails on the unique names and emails
+func (u *University) WriteSQL(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "-- %s: %d faculties, %d departments, %d lecturers, %d cohorts, %d courses, %d venues\n",
+		u.Name, len(u.Faculties), len(u.Departments), len(u.Lecturers), len(u.Cohorts), len(u.Courses), len(u.Venues))
+	bw.WriteString("BEGIN;\n\n")
+
+	in := newInsert(bw, "universities", "university_id", "university_name", "university_abbr", "email", "phone_number")
+	in.row(quoteId(u.Id), quote(u.Name), quote(u.Abbr), quote(u.Email), quote(u.PhoneNumber))
+	in.flush()
+
+	in = newInsert(bw, "faculties", "faculty_id", "faculty_name", "faculty_code", "university_id")
+	for _, faculty := range u.Faculties {
+		in.row(quoteId(faculty.Id), quote(faculty.Name), quote(faculty.Code), quoteId(u.Id))
+	}
+	in.flush()
+
+	in = newInsert(bw, "departments", "department_id", "department_name", "department_code", "faculty_id", "university_id", "number_of_levels")
+	for _, dept := range u.Departments {
+		in.row(quoteId(dept.Id), quote(dept.Name), quote(dept.Code), quoteId(dept.FacultyId), quoteId(u.Id), fmt.Sprint(dept.Levels))
+	}
+	in.flush()
+
+	in = newInsert(bw, "venues", "venue_id", "venue_name", "capacity", "university_id")
+	for _, venue := range u.Venues {
+		in.row(quoteId(venue.Id), quote(venue.Name), fmt.Sprint(venue.Capacity), quoteId(u.Id))
+	}
+	in.flush()
+
+	in = newInsert(bw, "faculty_venues", "venue_id", "faculty_id", "university_id")
+	for _, venue := range u.Venues {
+		if venue.FacultyId.Valid {
+			in.row(quoteId(venue.Id), quoteNullId(venue.FacultyId), quoteId(u.Id))
+		}
+	}
+	in.flush()
+
+	in = newInsert(bw, "lecturers", "lecturer_id", "lecturer_first_name", "lecturer_last_name", "lecturer_email", "lecturer_password", "lecturer_staff_id", "lecturer_university_id", "lecturer_faculty_id", "lecturer_department_id")
+	for _, lecturer := range u.Lecturers {
+		in.row(quoteId(lecturer.Id), quote(lecturer.FirstName), quote(lecturer.LastName), quote(lecturer.Email), quote(seedPassword),
+			quote(lecturer.StaffId), quoteId(u.Id), quoteId(lecturer.FacultyId), quoteId(lecturer.DepartmentId))
+	}
+	in.flush()
+
+	in = newInsert(bw, "cohorts", "cohort_id", "cohort_name", "cohort_level", "cohort_department_id", "cohort_faculty_id", "cohort_university_id", "cohort_size")
+	for _, cohort := range u.Cohorts {
+		in.row(quoteId(cohort.Id), quote(cohort.Name), fmt.Sprint(cohort.Level), quoteId(cohort.DepartmentId), quoteId(cohort.FacultyId), quoteId(u.Id), fmt.Sprint(cohort.Size))
+	}
+	in.flush()
+
+	in = newInsert(bw, "courses", "course_id", "course_code", "course_title", "course_credit_unit", "department_id", "university_id", "lecturer_id", "sessions_per_week", "level", "semester", "course_duration", "lecturer_mode")
+	for _, course := range u.Courses {
+		in.row(quoteId(course.Id), quote(course.Code), quote(course.Title), fmt.Sprint(course.CreditUnit), quoteId(course.DepartmentId), quoteId(u.Id),
+			quoteId(course.LecturerIds[0]), fmt.Sprint(course.SessionsPerWeek), fmt.Sprint(course.Level), quote(course.Semester), fmt.Sprint(course.Hours), quote(course.LecturerMode))
+	}
+	in.flush()
+
+	in = newInsert(bw, "courses_lecturers", "course_id", "lecturer_id")
+	for _, course := range u.Courses {
+		for _, lecturerId := range course.LecturerIds {
+			in.row(quoteId(course.Id), quoteId(lecturerId))
+		}
+	}
+	in.flush()
+
+	in = newInsert(bw, "courses_possible_venues", "course_id", "venue_id", "university_id")
+	for _, course := range u.Courses {
+		for _, venueId := range course.VenueIds {
+			in.row(quoteId(course.Id), quoteId(venueId), quoteId(u.Id))
+		}
+	}
+	in.flush()
+
+	in = newInsert(bw, "cohort_courses_offered", "cohort_id", "course_id", "university_id")
+	for _, course := range u.Courses {
+		for _, cohortId := range course.CohortIds {
+			in.row(quoteId(cohortId), quoteId(course.Id), quoteId(u.Id))
+		}
+	}
+	in.flush()
+
+	in = newInsert(bw, "lecturer_unavailability", "lecturer_id", "day", "start_time", "end_time", "reason")
+	for _, period := range u.LecturerUnavailability {
+		in.row(quoteId(period.Id), quote(period.Day), quoteTime(period.StartMinutes), quoteTime(period.EndMinutes), quote("synthetic"))
+	}
+	in.flush()
+
+	in = newInsert(bw, "venue_unavailability", "venue_id", "day", "start_time", "end_time", "reason", "university_id")
+	for _, period := range u.VenueUnavailability {
+		in.row(quoteId(period.Id), quote(period.Day), quoteTime(period.StartMinutes), quoteTime(period.EndMinutes), quote("synthetic"), quoteId(u.Id))
+	}
+	in.flush()
+
+	in = newInsert(bw, "timetable_teaching_days", "university_id", "day", "start_time", "end_time")
+	for _, day := range u.Week.Days {
+		in.row(quoteId(u.Id), quote(day.Day), quoteTime(day.StartMinutes), quoteTime(day.EndMinutes))
+	}
+	in.flush()
+
+	fmt.Fprintf(bw, "INSERT INTO timetable_settings (university_id, slot_minutes) VALUES (%s, %d);\n\n", quoteId(u.Id), u.Week.SlotMinutes)
+	bw.WriteString("COMMIT;\n")
+	return bw.Flush()
+}
@@ -0,0 +1,447 @@
+// Package synthetic generates universities to stress the scheduler with. a university is laid out
+// like a real one: faculties of departments, one cohort per level of a department taking the courses
+// of its department, some courses shared with another department, lecture rooms owned by a faculty
+// next to halls everyone uses, and lecturers and venues that are unavailable now and then. the same
+// config and seed always give the same university, ids included
+package synthetic
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/Cxons/unischedulebackend/internal/timetable/computed"
+	"github.com/google/uuid"
+)
+
+// the shape of a synthetic university
+type Config struct {
+	Faculties              int
+	DepartmentsPerFaculty  int
+	Levels                 int // levels of every department e.g 4 for 100 to 400 level, each level is one cohort
+	CoursesPerCohort       int // courses a cohort takes from its own department
+	LecturersPerDepartment int
+	MinCohortSize          int
+	MaxCohortSize          int
+	MaxSessionsPerWeek     int
+	MaxCourseHours         int // the longest a session lasts
+	Venues                 int
+	MinVenueCapacity       int
+	MaxVenueCapacity       int
+	SharedVenueRate        float64 // share of venues every faculty may use, the rest belong to one faculty
+	SharedCourseRate       float64 // chance a course is also taken by the cohort of the same level of another department
+	TeamTaughtRate         float64 // chance a course has a second lecturer
+	LecturerUnavailability float64 // chance a lecturer is unavailable in a slot of the week
+	VenueUnavailability    float64 // chance a venue is unavailable in a slot of the week
+	Days                   int     // teaching days from monday
+	DayStartMinutes        int
+	DayEndMinutes          int
+	SlotMinutes            int
+}
+
+// a mid sized university, about 400 courses
+func DefaultConfig() Config {
+	return Config{
+		Faculties:              4,
+		DepartmentsPerFaculty:  4,
+		Levels:                 4,
+		CoursesPerCohort:       6,
+		LecturersPerDepartment: 8,
+		MinCohortSize:          20,
+		MaxCohortSize:          150,
+		MaxSessionsPerWeek:     2,
+		MaxCourseHours:         2,
+		Venues:                 60,
+		MinVenueCapacity:       30,
+		MaxVenueCapacity:       500,
+		SharedVenueRate:        0.3,
+		SharedCourseRate:       0.1,
+		TeamTaughtRate:         0.15,
+		LecturerUnavailability: 0.1,
+		VenueUnavailability:    0.05,
+		Days:                   5,
+		DayStartMinutes:        8 * 60,
+		DayEndMinutes:          18 * 60,
+		SlotMinutes:            60,
+	}
+}
+
+// the sizes the tools know by name
+var Presets = map[string]func() Config{
+	"small": func() Config {
+		c := DefaultConfig()
+		c.Faculties = 2
+		c.DepartmentsPerFaculty = 2
+		c.CoursesPerCohort = 5
+		c.LecturersPerDepartment = 6
+		c.Venues = 20
+		return c
+	},
+	"medium": DefaultConfig,
+	"large": func() Config {
+		c := DefaultConfig()
+		c.Faculties = 8
+		c.DepartmentsPerFaculty = 5
+		c.Levels = 5
+		c.CoursesPerCohort = 7
+		c.LecturersPerDepartment = 10
+		c.Venues = 200
+		return c
+	},
+}
+
+// the names of the presets from the smallest up
+func PresetNames() []string {
+	return []string{"small", "medium", "large"}
+}
+
+func (c Config) Validate() error {
+	if c.Faculties < 1 || c.DepartmentsPerFaculty < 1 || c.Levels < 1 || c.CoursesPerCohort < 1 || c.LecturersPerDepartment < 1 || c.Venues < 1 {
+		return fmt.Errorf("faculties, departments, levels, courses, lecturers and venues must all be at least 1")
+	}
+	if c.Levels > 9 || c.CoursesPerCohort > 99 {
+		return fmt.Errorf("at most 9 levels and 99 courses per cohort fit in a course code")
+	}
+	if c.MinCohortSize < 1 || c.MaxCohortSize < c.MinCohortSize {
+		return fmt.Errorf("cohort sizes must be at least 1 with the max not below the min, got %d to %d", c.MinCohortSize, c.MaxCohortSize)
+	}
+	if c.MinVenueCapacity < 1 || c.MaxVenueCapacity < c.MinVenueCapacity {
+		return fmt.Errorf("venue capacities must be at least 1 with the max not below the min, got %d to %d", c.MinVenueCapacity, c.MaxVenueCapacity)
+	}
+	if c.MaxSessionsPerWeek < 1 || c.MaxCourseHours < 1 {
+		return fmt.Errorf("sessions per week and course hours must be at least 1")
+	}
+	for _, rate := range []float64{c.SharedVenueRate, c.SharedCourseRate, c.TeamTaughtRate, c.LecturerUnavailability, c.VenueUnavailability} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("rates must be between 0 and 1, got %v", rate)
+		}
+	}
+	if c.Days < 1 || c.Days > len(computed.WeekDays) {
+		return fmt.Errorf("days must be between 1 and %d, got %d", len(computed.WeekDays), c.Days)
+	}
+	if err := c.Week().Validate(); err != nil {
+		return err
+	}
+	if c.MaxCourseHours*60 > c.DayEndMinutes-c.DayStartMinutes {
+		return fmt.Errorf("a %d hour session does not fit in a day", c.MaxCourseHours)
+	}
+	return nil
+}
+
+// the teaching week of the university
+func (c Config) Week() computed.TeachingWeek {
+	days := make([]computed.TeachingDay, 0, c.Days)
+	for _, day := range computed.WeekDays[:min(max(c.Days, 0), len(computed.WeekDays))] {
+		days = append(days, computed.TeachingDay{Day: day, StartMinutes: c.DayStartMinutes, EndMinutes: c.DayEndMinutes})
+	}
+	return computed.TeachingWeek{Days: days, SlotMinutes: c.SlotMinutes}
+}
+
+type University struct {
+	Id          uuid.UUID
+	Name        string
+	Abbr        string
+	Email       string
+	PhoneNumber string
+	Week        computed.TeachingWeek
+	Faculties   []Faculty
+	Departments []Department
+	Lecturers   []Lecturer
+	Cohorts     []Cohort
+	Courses     []Course
+	Venues      []Venue
+	// when lecturers and venues cannot be used
+	LecturerUnavailability []Unavailability
+	VenueUnavailability    []Unavailability
+}
+
+type Faculty struct {
+	Id   uuid.UUID
+	Name string
+	Code string
+}
+
+type Department struct {
+	Id        uuid.UUID
+	FacultyId uuid.UUID
+	Name      string
+	Code      string
+	Levels    int
+}
+
+type Lecturer struct {
+	Id           uuid.UUID
+	FirstName    string
+	LastName     string
+	Email        string
+	StaffId      string
+	FacultyId    uuid.UUID
+	DepartmentId uuid.UUID
+}
+
+type Cohort struct {
+	Id           uuid.UUID
+	Name         string
+	Level        int // 1 for 100 level
+	FacultyId    uuid.UUID
+	DepartmentId uuid.UUID
+	Size         int
+}
+
+type Course struct {
+	Id              uuid.UUID
+	Code            string
+	Title           string
+	CreditUnit      int
+	Hours           int // how long every session is
+	SessionsPerWeek int
+	Level           int
+	Semester        string
+	DepartmentId    uuid.UUID
+	LecturerIds     []uuid.UUID // the first is the lecturer of the course
+	LecturerMode    string
+	CohortIds       []uuid.UUID // the cohort of its own department first
+	VenueIds        []uuid.UUID // the venues it may be taught in, all large enough
+}
+
+type Venue struct {
+	Id        uuid.UUID
+	Name      string
+	Capacity  int
+	FacultyId uuid.NullUUID // the faculty that owns it, none when everyone may use it
+}
+
+// a period of a day something cannot be used, times are minutes after midnight
+type Unavailability struct {
+	Id           uuid.UUID // the lecturer or venue
+	Day          string
+	StartMinutes int
+	EndMinutes   int
+}
+
+var facultyNames = []string{"Science", "Engineering", "Arts", "Social Sciences", "Management Sciences", "Law", "Medicine", "Education", "Agriculture", "Environmental Sciences"}
+
+var departmentCodes = []string{
+	"CSC", "MTH", "PHY", "CHM", "BIO", "STA", "GEO", "MCB", "BCH", "ECO", "ACC", "BUS", "POL", "SOC", "PSY", "HIS",
+	"ENG", "LIN", "PHL", "MUS", "CVE", "EEE", "MEE", "CHE", "ARC", "EDU", "AGR", "ANA", "PHS", "LAW",
+}
+
+var firstNames = []string{"Ada", "Chinedu", "Ngozi", "Emeka", "Funmi", "Tunde", "Amaka", "Bola", "Ifeoma", "Segun", "Zainab", "Musa", "Kemi", "Obinna", "Halima", "Yusuf", "Chioma", "Femi", "Aisha", "Uche"}
+
+var lastNames = []string{"Okafor", "Adeyemi", "Bello", "Eze", "Ogunleye", "Ibrahim", "Nwosu", "Balogun", "Okeke", "Abubakar", "Olawale", "Obi", "Danjuma", "Onyeka", "Adebayo", "Umar", "Chukwu", "Lawal", "Nnamdi", "Salami"}
+
+// ids come from r so the same seed gives the same ids
+func newId(r *rand.Rand) uuid.UUID {
+	return uuid.Must(uuid.NewRandomFromReader(r))
+}
+
+func between(r *rand.Rand, lo int, hi int) int {
+	return lo + r.Intn(hi-lo+1)
+}
+
+// builds the university of the config from seed
+func Generate(c Config, seed int64) (*University, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(seed))
+	u := &University{
+		Id:          newId(r),
+		Name:        fmt.Sprintf("Synthetic University %d", seed),
+		Abbr:        fmt.Sprintf("SYN%d", seed),
+		Email:       fmt.Sprintf("registry@syn%d.edu", seed),
+		PhoneNumber: fmt.Sprintf("+%014d", uint64(seed)%1e14),
+		Week:        c.Week(),
+	}
+
+	for f := 0; f < c.Faculties; f++ {
+		name := fmt.Sprintf("Faculty %d", f+1)
+		if f < len(facultyNames) {
+			name = facultyNames[f]
+		}
+		u.Faculties = append(u.Faculties, Faculty{Id: newId(r), Name: name, Code: fmt.Sprintf("F%02d", f+1)})
+	}
+	for f, faculty := range u.Faculties {
+		for d := 0; d < c.DepartmentsPerFaculty; d++ {
+			n := f*c.DepartmentsPerFaculty + d
+			code := departmentCodes[n%len(departmentCodes)]
+			if n >= len(departmentCodes) {
+				code = fmt.Sprintf("%s%d", code, n/len(departmentCodes)+1)
+			}
+			u.Departments = append(u.Departments, Department{
+				Id:        newId(r),
+				FacultyId: faculty.Id,
+				Name:      "Department of " + code,
+				Code:      code,
+				Levels:    c.Levels,
+			})
+		}
+	}
+
+	// the lecturers and the cohort of every level of each department
+	lecturersOf := make(map[uuid.UUID][]uuid.UUID)
+	cohortOf := make(map[uuid.UUID][]int) // department to cohort idx by level
+	for _, dept := range u.Departments {
+		for l := 0; l < c.LecturersPerDepartment; l++ {
+			n := len(u.Lecturers)
+			first := firstNames[r.Intn(len(firstNames))]
+			last := lastNames[r.Intn(len(lastNames))]
+			lecturer := Lecturer{
+				Id:           newId(r),
+				FirstName:    first,
+				LastName:     last,
+				Email:        fmt.Sprintf("lecturer%d@syn%d.edu", n+1, seed),
+				StaffId:      fmt.Sprintf("SYN%d-%05d", seed, n+1),
+				FacultyId:    dept.FacultyId,
+				DepartmentId: dept.Id,
+			}
+			u.Lecturers = append(u.Lecturers, lecturer)
+			lecturersOf[dept.Id] = append(lecturersOf[dept.Id], lecturer.Id)
+		}
+		for level := 1; level <= c.Levels; level++ {
+			// classes shrink a little every year
+			size := between(r, c.MinCohortSize, c.MaxCohortSize)
+			size = max(c.MinCohortSize, size-size*(level-1)/10)
+			cohortOf[dept.Id] = append(cohortOf[dept.Id], len(u.Cohorts))
+			u.Cohorts = append(u.Cohorts, Cohort{
+				Id:           newId(r),
+				Name:         fmt.Sprintf("%s %d00 level", dept.Code, level),
+				Level:        level,
+				FacultyId:    dept.FacultyId,
+				DepartmentId: dept.Id,
+				Size:         size,
+			})
+		}
+	}
+
+	headcounts := make([]int, 0)
+	for d, dept := range u.Departments {
+		for level := 1; level <= c.Levels; level++ {
+			home := u.Cohorts[cohortOf[dept.Id][level-1]]
+			for n := 1; n <= c.CoursesPerCohort; n++ {
+				course := Course{
+					Id:              newId(r),
+					Code:            fmt.Sprintf("%s%d%02d", dept.Code, level, n),
+					Title:           fmt.Sprintf("%s %d00 level course %d", dept.Code, level, n),
+					CreditUnit:      between(r, 1, 3),
+					Hours:           between(r, 1, c.MaxCourseHours),
+					SessionsPerWeek: between(r, 1, c.MaxSessionsPerWeek),
+					Level:           level,
+					Semester:        "First",
+					DepartmentId:    dept.Id,
+					LecturerMode:    computed.LecturerModeTeam,
+					CohortIds:       []uuid.UUID{home.Id},
+				}
+				lecturers := lecturersOf[dept.Id]
+				first := r.Intn(len(lecturers))
+				course.LecturerIds = []uuid.UUID{lecturers[first]}
+				if len(lecturers) > 1 && r.Float64() < c.TeamTaughtRate {
+					second := (first + 1 + r.Intn(len(lecturers)-1)) % len(lecturers)
+					course.LecturerIds = append(course.LecturerIds, lecturers[second])
+					if r.Intn(2) == 0 {
+						course.LecturerMode = computed.LecturerModeRotate
+					}
+				}
+				headcount := home.Size
+				if len(u.Departments) > 1 && r.Float64() < c.SharedCourseRate {
+					other := u.Departments[(d+1+r.Intn(len(u.Departments)-1))%len(u.Departments)]
+					guest := u.Cohorts[cohortOf[other.Id][level-1]]
+					course.CohortIds = append(course.CohortIds, guest.Id)
+					headcount += guest.Size
+				}
+				u.Courses = append(u.Courses, course)
+				headcounts = append(headcounts, headcount)
+			}
+		}
+	}
+
+	// mostly classrooms with a few large halls, the largest holds the largest class
+	largestClass := 0
+	for _, headcount := range headcounts {
+		largestClass = max(largestClass, headcount)
+	}
+	for v := 0; v < c.Venues; v++ {
+		spread := r.Float64() * r.Float64()
+		venue := Venue{
+			Id:       newId(r),
+			Capacity: c.MinVenueCapacity + int(spread*float64(c.MaxVenueCapacity-c.MinVenueCapacity)),
+		}
+		if v == 0 {
+			venue.Capacity = max(venue.Capacity, largestClass)
+		}
+		if r.Float64() >= c.SharedVenueRate {
+			faculty := u.Faculties[r.Intn(len(u.Faculties))]
+			venue.FacultyId = uuid.NullUUID{UUID: faculty.Id, Valid: true}
+			venue.Name = fmt.Sprintf("%s Room %d", faculty.Code, v+1)
+		} else {
+			venue.Name = fmt.Sprintf("Hall %d", v+1)
+		}
+		u.Venues = append(u.Venues, venue)
+	}
+
+	facultyOf := make(map[uuid.UUID]uuid.UUID)
+	for _, dept := range u.Departments {
+		facultyOf[dept.Id] = dept.FacultyId
+	}
+	bySize := make([]int, len(u.Venues))
+	for v := range bySize {
+		bySize[v] = v
+	}
+	sort.SliceStable(bySize, func(i, j int) bool {
+		return u.Venues[bySize[i]].Capacity < u.Venues[bySize[j]].Capacity
+	})
+	for i := range u.Courses {
+		course := &u.Courses[i]
+		faculty := facultyOf[course.DepartmentId]
+		for _, v := range bySize {
+			venue := u.Venues[v]
+			if venue.Capacity < headcounts[i] {
+				continue
+			}
+			if !venue.FacultyId.Valid || venue.FacultyId.UUID == faculty {
+				course.VenueIds = append(course.VenueIds, venue.Id)
+			}
+		}
+		// a class too large for its own rooms borrows the smallest room that holds it
+		if len(course.VenueIds) == 0 {
+			for _, v := range bySize {
+				if u.Venues[v].Capacity >= headcounts[i] {
+					course.VenueIds = append(course.VenueIds, u.Venues[v].Id)
+					break
+				}
+			}
+		}
+	}
+
+	for _, lecturer := range u.Lecturers {
+		u.LecturerUnavailability = append(u.LecturerUnavailability, unavailability(r, lecturer.Id, u.Week, c.LecturerUnavailability)...)
+	}
+	for _, venue := range u.Venues {
+		u.VenueUnavailability = append(u.VenueUnavailability, unavailability(r, venue.Id, u.Week, c.VenueUnavailability)...)
+	}
+	return u, nil
+}
+
+// marks every slot of the week unavailable with the given chance, neighbouring slots become one period
+func unavailability(r *rand.Rand, id uuid.UUID, week computed.TeachingWeek, density float64) []Unavailability {
+	periods := make([]Unavailability, 0)
+	if density <= 0 {
+		return periods
+	}
+	for _, day := range week.Days {
+		open := false
+		for start := day.StartMinutes; start+week.SlotMinutes <= day.EndMinutes; start += week.SlotMinutes {
+			if r.Float64() >= density {
+				open = false
+				continue
+			}
+			if open {
+				periods[len(periods)-1].EndMinutes = start + week.SlotMinutes
+				continue
+			}
+			periods = append(periods, Unavailability{Id: id, Day: day.Day, StartMinutes: start, EndMinutes: start + week.SlotMinutes})
+			open = true
+		}
+	}
+	return periods
+}
@@ -15,4 +15,8 @@ type CustomSessionPlacement struct {
 	Day string
 	SessionTime time.Time
 	UniversityId uuid.UUID
+	Conflict bool // takes part in a hard violation
+	SessionType string
+	GroupIdx int32 // the group of the course's students the session is for
+	GroupCount int32 // 1 when the whole class attends
 }
\ No newline at end of file
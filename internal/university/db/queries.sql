@@ -105,9 +105,10 @@ INSERT INTO venues(
     location,
     venue_image,
     capacity,
-    university_id
+    university_id,
+    venue_kind
 )VALUES(
-    $1,$2,$3,$4,$5,$6,$7
+    $1,$2,$3,$4,$5,$6,$7,$8
 )
 RETURNING *;
 
@@ -172,11 +173,19 @@ SELECT
     cohort_level,
     cohort_department_id,
     cohort_faculty_id,
-    cohort_university_id
+    cohort_university_id,
+    cohort_size
 FROM cohorts
 WHERE 
     cohort_department_id = $1;
 
+-- name: SetCohortSize :one
+UPDATE cohorts
+SET cohort_size = $1,
+    updated_at = NOW()
+WHERE cohort_id = $2
+RETURNING *;
+
 
 
 -- name: CreateVenueUnavailablity :exec
@@ -196,4 +205,29 @@ SELECT
 FROM departments
 WHERE university_id = $1;
 
-
+-- name: SetVenueKind :exec
+UPDATE venues
+SET venue_kind = $1,
+    updated_at = NOW()
+WHERE venue_id = $2 AND university_id = $3;
+
+-- name: ClearVenueFeatures :exec
+DELETE FROM venue_features
+WHERE venue_id = $1 AND university_id = $2;
+
+-- name: AddVenueFeature :exec
+INSERT INTO venue_features(
+    venue_id,feature,university_id
+)VALUES($1,$2,$3);
+
+-- name: FetchVenueFeatures :many
+SELECT feature
+FROM venue_features
+WHERE venue_id = $1
+ORDER BY feature;
+
+-- name: SetVenueRestricted :exec
+UPDATE venues
+SET is_restricted = $1,
+    updated_at = NOW()
+WHERE venue_id = $2 AND university_id = $3;
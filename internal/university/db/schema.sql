@@ -43,7 +43,8 @@ CREATE TABLE cohorts(
     cohort_faculty_id UUID NOT NULL REFERENCES faculties(faculty_id) ON DELETE CASCADE,
     cohort_university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
     created_at TIMESTAMPTZ DEFAULT NOW(),
-    updated_at TIMESTAMPTZ DEFAULT NOW()  
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    cohort_size INT DEFAULT NULL CHECK (cohort_size IS NULL OR cohort_size >= 0)
 );
 
 CREATE TABLE venues(
@@ -57,10 +58,20 @@ CREATE TABLE venues(
     university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
     is_active BOOLEAN DEFAULT TRUE,
     created_at TIMESTAMPTZ DEFAULT NOW(),
-    updated_at TIMESTAMPTZ DEFAULT NOW()
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    venue_kind TEXT DEFAULT NULL, -- e.g HALL, LAB or CLASSROOM, null for a general room
+    is_restricted BOOLEAN NOT NULL DEFAULT FALSE -- only its owning departments and faculties may use it
 );
 
 
+CREATE TABLE venue_features(
+    venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    feature TEXT NOT NULL, -- e.g PROJECTOR, COMPUTER_LAB, WHEELCHAIR_ACCESS or EXAM_SEATING
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY(venue_id,feature)
+);
+
 CREATE TABLE faculty_venues(
     venue_id UUID REFERENCES venues(venue_id) ON DELETE CASCADE,
     faculty_id UUID REFERENCES faculties(faculty_id) ON DELETE CASCADE,
@@ -60,6 +60,8 @@ type CreateVenueDto struct {
 	VenueImage string `json:"venueImage" validate:"omitempty"`
 	Capacity int32 `json:"capacity" validate:"required"`
 	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	VenueKind string `json:"venueKind" validate:"omitempty"` // e.g HALL, LAB or CLASSROOM, the course components that need one are placed only there
+	Features []string `json:"features" validate:"omitempty"` // e.g PROJECTOR, COMPUTER_LAB or WHEELCHAIR_ACCESS
 	VenueType string `json:"venueType" validate:"required"`
 	TypeId uuid.UUID `json:"typeId" validate:"required"`
 	UnavailabilityDay string `json:"unavailabilityDay" validate:"required"`
@@ -74,3 +76,29 @@ type VenueUnavailability struct {
 	StartTime time.Time
 	EndTime time.Time
 }
+
+type SetCohortSizeDto struct {
+	CohortId uuid.UUID `json:"cohortId" validate:"required"`
+	CohortSize *int32 `json:"cohortSize" validate:"omitempty,min=0"`
+}
+
+// an empty kind makes the venue a general room again
+type SetVenueKindDto struct {
+	VenueId uuid.UUID `json:"venueId" validate:"required"`
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	VenueKind string `json:"venueKind" validate:"omitempty"`
+}
+
+// a restricted venue is only used for the courses of the departments and faculties that own it
+type SetVenueRestrictedDto struct {
+	VenueId uuid.UUID `json:"venueId" validate:"required"`
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	IsRestricted bool `json:"isRestricted"`
+}
+
+// replaces the features of the venue, an empty list removes them all
+type SetVenueFeaturesDto struct {
+	VenueId uuid.UUID `json:"venueId" validate:"required"`
+	UniversityId uuid.UUID `json:"universityId" validate:"required"`
+	Features []string `json:"features" validate:"omitempty"`
+}
@@ -118,4 +118,39 @@ func (uh *UniversityHandler) FetchAllDepartmentsForAUni(res http.ResponseWriter,
 	uniId := queryParams.Get("uniId")
 	resp,errMsg,err := uh.service.FetchAllDepartmentsForAUni(ctx,utils.StringToUUID(uniId))
 	utils.HandleAuthResponse(resp,err,errMsg,res)
-}
\ No newline at end of file
+}
+
+func (uh *UniversityHandler) SetCohortSize(res http.ResponseWriter, req *http.Request){
+	var body dto.SetCohortSizeDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := uh.service.SetCohortSize(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (uh *UniversityHandler) SetVenueKind(res http.ResponseWriter, req *http.Request){
+	var body dto.SetVenueKindDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := uh.service.SetVenueKind(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (uh *UniversityHandler) SetVenueRestricted(res http.ResponseWriter, req *http.Request){
+	var body dto.SetVenueRestrictedDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := uh.service.SetVenueRestricted(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (uh *UniversityHandler) SetVenueFeatures(res http.ResponseWriter, req *http.Request){
+	var body dto.SetVenueFeaturesDto
+	utils.HandleBodyParsing(req,res,&body)
+	resp,errMsg,err := uh.service.SetVenueFeatures(ctx,body)
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
+
+func (uh *UniversityHandler) FetchVenueFeatures(res http.ResponseWriter, req *http.Request){
+	queryParams := req.URL.Query()
+	venueId := queryParams.Get("venueId")
+	resp,errMsg,err := uh.service.FetchVenueFeatures(ctx,utils.StringToUUID(venueId))
+	utils.HandleAuthResponse(resp,err,errMsg,res)
+}
@@ -19,10 +19,15 @@ type UniRepository interface{
 	RetrieveAllFaculties(ctx context.Context,uniId uuid.UUID)([]sqlc.Faculty,error)
 	RetrieveAllDepartments(ctx context.Context,deptParams sqlc.RetrieveDeptsForAFacultyParams)([]sqlc.Department,error)
 	FetchApprovedLecturersInDepartment(ctx context.Context, deptId uuid.UUID)([]sqlc.FetchApprovedLecturersInDepartmentRow,error)
-	CreateVenue(ctx context.Context,venueInfo sqlc.CreateVenueParams,venueType string, id uuid.UUID,unavailabilityData dto.VenueUnavailability)error
+	CreateVenue(ctx context.Context,venueInfo sqlc.CreateVenueParams,venueType string, id uuid.UUID,unavailabilityData dto.VenueUnavailability,features []string)error
 	RetrieveAllVenues(ctx context.Context, uniId uuid.UUID)([]sqlc.RetrieveAllVenuesRow,error)
 	FetchCohortsForADepartment(ctx context.Context,deptId uuid.UUID)([]sqlc.FetchCohortsForADepartmentRow,error)
 	FetchAllDepartmentsForAUni(ctx context.Context,uniId uuid.UUID)([]sqlc.FetchAllDepartmentsForAUniRow,error)
+	SetCohortSize(ctx context.Context,params sqlc.SetCohortSizeParams)(sqlc.Cohort,error)
+	SetVenueKind(ctx context.Context,params sqlc.SetVenueKindParams)error
+	SetVenueRestricted(ctx context.Context,params sqlc.SetVenueRestrictedParams)error
+	SetVenueFeatures(ctx context.Context,venueId uuid.UUID,uniId uuid.UUID,features []string)error
+	FetchVenueFeatures(ctx context.Context,venueId uuid.UUID)([]string,error)
 }
 
 
@@ -96,7 +101,7 @@ func (unp *uniRepository) RetrieveAllVenues(ctx context.Context, uniId uuid.UUID
 	return unp.vq.RetrieveAllVenues(ctx,uniId)
 }
 
-func (unp *uniRepository) CreateVenue(ctx context.Context,venueInfo sqlc.CreateVenueParams,venueType string, id uuid.UUID,unavailabilityData dto.VenueUnavailability)error{
+func (unp *uniRepository) CreateVenue(ctx context.Context,venueInfo sqlc.CreateVenueParams,venueType string, id uuid.UUID,unavailabilityData dto.VenueUnavailability,features []string)error{
 	return unp.store.ExecTx(ctx,func(q *sqlc.Queries) error {
 		venue,err := q.CreateVenue(ctx,venueInfo)
 		if err != nil{
@@ -137,6 +142,17 @@ func (unp *uniRepository) CreateVenue(ctx context.Context,venueInfo sqlc.CreateV
 		if unavailabilityErr != nil{
 			return unavailabilityErr
 		}
+		for _,feature := range features{
+			err := q.AddVenueFeature(ctx,sqlc.AddVenueFeatureParams{
+				VenueID: venue.VenueID,
+				Feature: feature,
+				UniversityID: venue.UniversityID,
+			})
+			if err != nil{
+				slog.Error("error setting venue feature","err:",err)
+				return err
+			}
+		}
 		return nil
 	})
 }
@@ -144,4 +160,44 @@ func (unp *uniRepository) CreateVenue(ctx context.Context,venueInfo sqlc.CreateV
 
 func (unp *uniRepository) FetchCohortsForADepartment(ctx context.Context,deptId uuid.UUID)([]sqlc.FetchCohortsForADepartmentRow,error){
 	return unp.cohq.FetchCohortsForADepartment(ctx,deptId)
-}
\ No newline at end of file
+}
+
+func (unp *uniRepository) SetCohortSize(ctx context.Context,params sqlc.SetCohortSizeParams)(sqlc.Cohort,error){
+	return unp.cohq.SetCohortSize(ctx,params)
+}
+
+func (unp *uniRepository) SetVenueKind(ctx context.Context,params sqlc.SetVenueKindParams)error{
+	return unp.vq.SetVenueKind(ctx,params)
+}
+
+func (unp *uniRepository) SetVenueRestricted(ctx context.Context,params sqlc.SetVenueRestrictedParams)error{
+	return unp.vq.SetVenueRestricted(ctx,params)
+}
+
+// replaces the features of the venue
+func (unp *uniRepository) SetVenueFeatures(ctx context.Context,venueId uuid.UUID,uniId uuid.UUID,features []string)error{
+	return unp.store.ExecTx(ctx,func(q *sqlc.Queries)error{
+		err := q.ClearVenueFeatures(ctx,sqlc.ClearVenueFeaturesParams{
+			VenueID: venueId,
+			UniversityID: uniId,
+		})
+		if err != nil{
+			return err
+		}
+		for _,feature := range features{
+			err = q.AddVenueFeature(ctx,sqlc.AddVenueFeatureParams{
+				VenueID: venueId,
+				Feature: feature,
+				UniversityID: uniId,
+			})
+			if err != nil{
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (unp *uniRepository) FetchVenueFeatures(ctx context.Context,venueId uuid.UUID)([]string,error){
+	return unp.vq.FetchVenueFeatures(ctx,venueId)
+}
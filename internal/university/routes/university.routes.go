@@ -18,7 +18,12 @@ func Routes (uniHandler uniHandler.UniversityHandler)chi.Router{
 	r.Get("/all/departments",uniHandler.FetchAllDepartmentsForAUni)
 	r.Get("/department/lecturers",uniHandler.RetrieveDepartmentLecturers)
 	r.Post("/venue",uniHandler.CreateVenue)
+	r.Post("/venue/kind",uniHandler.SetVenueKind)
+	r.Post("/venue/restricted",uniHandler.SetVenueRestricted)
+	r.Post("/venue/features",uniHandler.SetVenueFeatures)
+	r.Get("/venue/features",uniHandler.FetchVenueFeatures)
 	r.Get("/department/cohorts",uniHandler.FetchCohortsForADepartment)
+	r.Post("/cohort/size",uniHandler.SetCohortSize)
 	r.Get("/venues",uniHandler.RetrieveAllVenues)
 	return r
 }
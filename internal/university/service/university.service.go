@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 
 	sqlc "github.com/Cxons/unischedulebackend/internal/shared/db"
@@ -26,6 +28,11 @@ type UniService interface{
 	 RetrieveAllVenues(ctx context.Context,uniId uuid.UUID)(uniResponse,string,error)
 	 FetchCohortsForADepartment(ctx context.Context,deptId uuid.UUID)(uniResponse,string,error)
 	FetchAllDepartmentsForAUni(ctx context.Context, uniId uuid.UUID)(uniResponse,string,error)
+	SetCohortSize(ctx context.Context, body dto.SetCohortSizeDto)(uniResponse,string,error)
+	SetVenueKind(ctx context.Context, body dto.SetVenueKindDto)(uniResponse,string,error)
+	SetVenueRestricted(ctx context.Context, body dto.SetVenueRestrictedDto)(uniResponse,string,error)
+	SetVenueFeatures(ctx context.Context, body dto.SetVenueFeaturesDto)(uniResponse,string,error)
+	FetchVenueFeatures(ctx context.Context, venueId uuid.UUID)(uniResponse,string,error)
 }
 
 type uniService struct{
@@ -164,6 +171,7 @@ func (uns *uniService) CreateVenue(ctx context.Context, venueData dto.CreateVenu
 		VenueImage: utils.StringToNullString(venueData.VenueImage),
 		Capacity: venueData.Capacity,
 		UniversityID: venueData.UniversityId,
+		VenueKind: utils.StringToNullString(venueData.VenueKind),
 	}
 	venueUnavailability := dto.VenueUnavailability{
 		Reason: venueData.UnavailabilityReason,
@@ -171,7 +179,7 @@ func (uns *uniService) CreateVenue(ctx context.Context, venueData dto.CreateVenu
 		StartTime: venueData.UnavailabilityStartTime,
 		EndTime: venueData.UnavailabilityEndTime,
 	}
-	err := uns.repo.CreateVenue(ctx,actualVenueData,venueData.VenueType,venueData.TypeId,venueUnavailability)
+	err := uns.repo.CreateVenue(ctx,actualVenueData,venueData.VenueType,venueData.TypeId,venueUnavailability,utils.NormalizeTags(venueData.Features))
 	if err != nil{
 		uns.logger.Error("error creating venue","err:",err)
 		return uniResponse{},status.InternalServerError.Message,err
@@ -223,4 +231,90 @@ func (uns *uniService) FetchAllDepartmentsForAUni(ctx context.Context, uniId uui
 		StatusCode: status.OK.Code,
 		StatusCodeMessage: status.OK.Message,
 	},status.OK.Message,nil
-}
\ No newline at end of file
+}
+
+// a nil size clears it so the timetable falls back to counting the students of the cohort
+func (uns *uniService) SetCohortSize(ctx context.Context, body dto.SetCohortSizeDto)(uniResponse,string,error){
+	cohortSize := sql.NullInt32{}
+	if body.CohortSize != nil{
+		cohortSize = sql.NullInt32{Int32: *body.CohortSize,Valid: true}
+	}
+	data,err := uns.repo.SetCohortSize(ctx,sqlc.SetCohortSizeParams{
+		CohortSize: cohortSize,
+		CohortID: body.CohortId,
+	})
+	if err != nil{
+		if errors.Is(err,sql.ErrNoRows){
+			return uniResponse{},status.NotFound.Message,errors.New("cohort not found")
+		}
+		uns.logger.Error("error setting cohort size","err:",err)
+		return uniResponse{},status.InternalServerError.Message,err
+	}
+	return uniResponse{
+		Message: "Cohort size updated",
+		Data: data,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+func (uns *uniService) SetVenueKind(ctx context.Context, body dto.SetVenueKindDto)(uniResponse,string,error){
+	err := uns.repo.SetVenueKind(ctx,sqlc.SetVenueKindParams{
+		VenueKind: utils.StringToNullString(body.VenueKind),
+		VenueID: body.VenueId,
+		UniversityID: body.UniversityId,
+	})
+	if err != nil{
+		uns.logger.Error("error setting venue kind","err:",err)
+		return uniResponse{},status.InternalServerError.Message,err
+	}
+	return uniResponse{
+		Message: "Venue kind updated",
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+func (uns *uniService) SetVenueRestricted(ctx context.Context, body dto.SetVenueRestrictedDto)(uniResponse,string,error){
+	err := uns.repo.SetVenueRestricted(ctx,sqlc.SetVenueRestrictedParams{
+		IsRestricted: body.IsRestricted,
+		VenueID: body.VenueId,
+		UniversityID: body.UniversityId,
+	})
+	if err != nil{
+		uns.logger.Error("error setting venue restriction","err:",err)
+		return uniResponse{},status.InternalServerError.Message,err
+	}
+	return uniResponse{
+		Message: "Venue restriction updated",
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+func (uns *uniService) SetVenueFeatures(ctx context.Context, body dto.SetVenueFeaturesDto)(uniResponse,string,error){
+	err := uns.repo.SetVenueFeatures(ctx,body.VenueId,body.UniversityId,utils.NormalizeTags(body.Features))
+	if err != nil{
+		uns.logger.Error("error setting venue features","err:",err)
+		return uniResponse{},status.InternalServerError.Message,err
+	}
+	return uniResponse{
+		Message: "Venue features updated",
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
+
+func (uns *uniService) FetchVenueFeatures(ctx context.Context, venueId uuid.UUID)(uniResponse,string,error){
+	data,err := uns.repo.FetchVenueFeatures(ctx,venueId)
+	if err != nil{
+		uns.logger.Error("error fetching venue features","err:",err)
+		return uniResponse{},status.InternalServerError.Message,err
+	}
+	return uniResponse{
+		Message: "The venue features",
+		Data: data,
+		StatusCode: status.OK.Code,
+		StatusCodeMessage: status.OK.Message,
+	},status.OK.Message,nil
+}
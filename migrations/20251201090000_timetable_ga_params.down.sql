@@ -0,0 +1,9 @@
+ALTER TABLE candidates
+DROP COLUMN seed,
+DROP COLUMN population_size,
+DROP COLUMN generations,
+DROP COLUMN mutation_rate,
+DROP COLUMN tournament_size,
+DROP COLUMN elitism_fraction;
+
+DROP TABLE timetable_settings;
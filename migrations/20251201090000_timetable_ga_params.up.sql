@@ -0,0 +1,19 @@
+CREATE TABLE timetable_settings(
+    university_id UUID PRIMARY KEY REFERENCES universities(university_id) ON DELETE CASCADE,
+    population_size INT NOT NULL DEFAULT 100,
+    generations INT NOT NULL DEFAULT 100,
+    mutation_rate DOUBLE PRECISION NOT NULL DEFAULT 0.05,
+    tournament_size INT NOT NULL DEFAULT 10,
+    elitism_fraction DOUBLE PRECISION NOT NULL DEFAULT 0.1,
+    seed BIGINT,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+ALTER TABLE candidates
+ADD COLUMN seed BIGINT,
+ADD COLUMN population_size INT,
+ADD COLUMN generations INT,
+ADD COLUMN mutation_rate DOUBLE PRECISION,
+ADD COLUMN tournament_size INT,
+ADD COLUMN elitism_fraction DOUBLE PRECISION;
@@ -0,0 +1 @@
+DROP TABLE timetable_constraints;
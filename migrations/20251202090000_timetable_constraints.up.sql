@@ -0,0 +1,10 @@
+CREATE TABLE timetable_constraints(
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    constraint_name TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    weight DOUBLE PRECISION NOT NULL CHECK (weight >= 0),
+    limit_value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (university_id, constraint_name)
+);
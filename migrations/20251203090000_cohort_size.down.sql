@@ -0,0 +1,2 @@
+ALTER TABLE cohorts
+DROP COLUMN cohort_size;
@@ -0,0 +1,2 @@
+ALTER TABLE cohorts
+ADD COLUMN cohort_size INT DEFAULT NULL CHECK (cohort_size IS NULL OR cohort_size >= 0);
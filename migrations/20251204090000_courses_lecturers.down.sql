@@ -0,0 +1,4 @@
+ALTER TABLE courses
+DROP COLUMN lecturer_mode;
+
+DROP TABLE courses_lecturers;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS courses_lecturers(
+    course_id UUID REFERENCES courses(course_id) ON DELETE CASCADE,
+    lecturer_id UUID REFERENCES lecturers(lecturer_id) ON DELETE CASCADE,
+    PRIMARY KEY(course_id,lecturer_id),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- the lecturer already on a course becomes its first course lecturer
+INSERT INTO courses_lecturers(course_id,lecturer_id)
+SELECT course_id,lecturer_id FROM courses
+WHERE lecturer_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE courses
+ADD COLUMN lecturer_mode TEXT NOT NULL DEFAULT 'TEAM' CHECK (lecturer_mode IN ('TEAM','ROTATE'));
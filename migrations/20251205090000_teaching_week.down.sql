@@ -0,0 +1,12 @@
+ALTER TABLE lecturer_unavailability
+DROP CONSTRAINT lecturer_unavailability_day_check,
+ADD CONSTRAINT lecturer_unavailability_day_check CHECK (day IN ('Monday','Tuesday','Wednesday','Thursday','Friday','Saturday'));
+
+ALTER TABLE session_placements
+DROP CONSTRAINT session_placements_day_check,
+ADD CONSTRAINT session_placements_day_check CHECK (day IN ('Monday','Tuesday','Wednesday','Thursday','Friday'));
+
+ALTER TABLE timetable_settings
+DROP COLUMN slot_minutes;
+
+DROP TABLE timetable_teaching_days;
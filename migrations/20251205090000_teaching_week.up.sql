@@ -0,0 +1,21 @@
+CREATE TABLE timetable_teaching_days(
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    day TEXT NOT NULL CHECK (day IN ('Monday','Tuesday','Wednesday','Thursday','Friday','Saturday','Sunday')),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (university_id, day),
+    CHECK (start_time < end_time)
+);
+
+ALTER TABLE timetable_settings
+ADD COLUMN slot_minutes INT NOT NULL DEFAULT 60 CHECK (slot_minutes IN (30,60,90));
+
+ALTER TABLE session_placements
+DROP CONSTRAINT session_placements_day_check,
+ADD CONSTRAINT session_placements_day_check CHECK (day IN ('Monday','Tuesday','Wednesday','Thursday','Friday','Saturday','Sunday'));
+
+ALTER TABLE lecturer_unavailability
+DROP CONSTRAINT lecturer_unavailability_day_check,
+ADD CONSTRAINT lecturer_unavailability_day_check CHECK (day IN ('Monday','Tuesday','Wednesday','Thursday','Friday','Saturday','Sunday'));
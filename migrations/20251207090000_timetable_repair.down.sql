@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS candidate_moved_sessions;
+
+ALTER TABLE candidates
+DROP COLUMN IF EXISTS repaired_from;
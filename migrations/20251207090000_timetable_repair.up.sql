@@ -0,0 +1,17 @@
+ALTER TABLE candidates
+ADD COLUMN repaired_from UUID REFERENCES candidates(id) ON DELETE SET NULL;
+
+CREATE TABLE candidate_moved_sessions(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    session_idx INT NOT NULL,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    from_venue_id UUID REFERENCES venues(venue_id) ON DELETE SET NULL,
+    from_day TEXT,
+    from_session_time TIMESTAMPTZ,
+    to_venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    to_day TEXT NOT NULL,
+    to_session_time TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
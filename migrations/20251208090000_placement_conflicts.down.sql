@@ -0,0 +1,2 @@
+ALTER TABLE session_placements
+DROP COLUMN IF EXISTS conflict;
@@ -0,0 +1,2 @@
+ALTER TABLE session_placements
+ADD COLUMN conflict BOOLEAN NOT NULL DEFAULT FALSE;
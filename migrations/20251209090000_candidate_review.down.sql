@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS candidate_reviews;
+
+ALTER TABLE candidates
+DROP CONSTRAINT IF EXISTS candidates_candidate_status_check,
+DROP COLUMN IF EXISTS published_at,
+DROP COLUMN IF EXISTS published_by;
+
+UPDATE candidates SET candidate_status = 'CURRENT' WHERE candidate_status = 'PUBLISHED';
+UPDATE candidates SET candidate_status = 'DEPRECATED' WHERE candidate_status <> 'CURRENT';
+
+ALTER TABLE candidates
+ADD CONSTRAINT candidates_candidate_status_check CHECK (candidate_status IN ('CURRENT','DEPRECATED'));
@@ -0,0 +1,26 @@
+ALTER TABLE candidates
+DROP CONSTRAINT IF EXISTS candidates_candidate_status_check;
+
+UPDATE candidates SET candidate_status = 'PUBLISHED' WHERE candidate_status = 'CURRENT';
+UPDATE candidates SET candidate_status = 'ARCHIVED' WHERE candidate_status = 'DEPRECATED';
+
+ALTER TABLE candidates
+ADD CONSTRAINT candidates_candidate_status_check CHECK (candidate_status IN ('DRAFT','UNDER_REVIEW','PUBLISHED','ARCHIVED')),
+ADD COLUMN published_at TIMESTAMPTZ,
+ADD COLUMN published_by UUID REFERENCES university_admin(admin_id) ON DELETE SET NULL;
+
+-- deans review a candidate for their faculty and HODs for their department
+CREATE TABLE candidate_reviews(
+    review_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    reviewer_id UUID NOT NULL REFERENCES lecturers(lecturer_id) ON DELETE CASCADE,
+    reviewer_role TEXT NOT NULL CHECK (reviewer_role IN ('DEAN','HOD')),
+    faculty_id UUID REFERENCES faculties(faculty_id) ON DELETE CASCADE,
+    department_id UUID REFERENCES departments(department_id) ON DELETE CASCADE,
+    decision TEXT NOT NULL CHECK (decision IN ('APPROVED','CHANGES_REQUESTED')),
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE(candidate_id,reviewer_id,reviewer_role)
+);
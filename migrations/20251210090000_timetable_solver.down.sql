@@ -0,0 +1,5 @@
+ALTER TABLE candidates
+DROP COLUMN IF EXISTS solver;
+
+ALTER TABLE timetable_settings
+DROP COLUMN IF EXISTS solver;
@@ -0,0 +1,6 @@
+ALTER TABLE timetable_settings
+ADD COLUMN solver TEXT NOT NULL DEFAULT 'GENETIC' CHECK (solver IN ('GENETIC','ANNEALING','GENETIC_TABU'));
+
+-- the solver that produced a candidate, NULL for repaired ones
+ALTER TABLE candidates
+ADD COLUMN solver TEXT;
@@ -0,0 +1,3 @@
+-- a genetic run is only repeatable with the same seed and number of workers
+ALTER TABLE candidates
+ADD COLUMN workers INT;
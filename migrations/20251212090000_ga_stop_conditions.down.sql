@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS candidate_fitness_history;
+
+ALTER TABLE candidates
+DROP COLUMN IF EXISTS stop_reason,
+DROP COLUMN IF EXISTS generations_run;
+
+ALTER TABLE timetable_settings
+DROP COLUMN IF EXISTS stop_when_feasible,
+DROP COLUMN IF EXISTS time_budget_seconds,
+DROP COLUMN IF EXISTS stall_generations;
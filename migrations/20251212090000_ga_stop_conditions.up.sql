@@ -0,0 +1,20 @@
+-- when a genetic run may stop before every generation is built, 0 and FALSE never stop it early
+ALTER TABLE timetable_settings
+ADD COLUMN stall_generations INT NOT NULL DEFAULT 0 CHECK (stall_generations >= 0),
+ADD COLUMN time_budget_seconds INT NOT NULL DEFAULT 0 CHECK (time_budget_seconds >= 0),
+ADD COLUMN stop_when_feasible BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- how many generations the run that produced a candidate built and why it stopped
+ALTER TABLE candidates
+ADD COLUMN generations_run INT,
+ADD COLUMN stop_reason TEXT;
+
+-- the best and mean fitness of the population after every generation of a run, generation 0 is
+-- the initial population
+CREATE TABLE candidate_fitness_history(
+    candidate_id UUID NOT NULL REFERENCES candidates(id) ON DELETE CASCADE,
+    generation INT NOT NULL,
+    best_fitness DOUBLE PRECISION NOT NULL,
+    mean_fitness DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY(candidate_id,generation)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS unique_published_candidate_per_term;
+
+ALTER TABLE candidates
+DROP COLUMN IF EXISTS semester,
+DROP COLUMN IF EXISTS academic_session;
@@ -0,0 +1,10 @@
+-- the academic session and semester a candidate is the timetable of, both are empty on candidates
+-- generated before timetables had a term and those were built from every course
+ALTER TABLE candidates
+ADD COLUMN academic_session TEXT,
+ADD COLUMN semester TEXT CHECK (semester IN ('First','Second'));
+
+-- every term has its own published timetable, publishing one leaves the other terms alone
+CREATE UNIQUE INDEX unique_published_candidate_per_term
+ON candidates(university_id,academic_session,semester)
+WHERE candidate_status = 'PUBLISHED';
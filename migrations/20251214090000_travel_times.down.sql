@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS venue_travel_times;
+
+ALTER TABLE timetable_constraints
+DROP COLUMN IF EXISTS hard;
@@ -0,0 +1,16 @@
+-- a hard constraint is never traded for a better score, its breaches count like clashes
+ALTER TABLE timetable_constraints
+ADD COLUMN hard BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- minutes it takes to walk between two venues where the distance between their coordinates is
+-- wrong e.g a river in the way, it takes as long both ways so a pair is stored once
+CREATE TABLE venue_travel_times(
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    from_venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    to_venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    minutes INT NOT NULL CHECK (minutes >= 0),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (from_venue_id, to_venue_id),
+    CHECK (from_venue_id < to_venue_id)
+);
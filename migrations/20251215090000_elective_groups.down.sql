@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS elective_group_courses;
+DROP TABLE IF EXISTS elective_groups;
@@ -0,0 +1,19 @@
+-- a cohort takes exactly one course of every elective group it has, the courses of a group may
+-- run at the same time for the cohort. a course of the cohort in no group is taken by all of it
+CREATE TABLE elective_groups(
+    elective_group_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    cohort_id UUID NOT NULL REFERENCES cohorts(cohort_id) ON DELETE CASCADE,
+    group_name TEXT NOT NULL,
+    UNIQUE(cohort_id,group_name),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE elective_group_courses(
+    elective_group_id UUID NOT NULL REFERENCES elective_groups(elective_group_id) ON DELETE CASCADE,
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    PRIMARY KEY(elective_group_id,course_id),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
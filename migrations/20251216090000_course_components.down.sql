@@ -0,0 +1,7 @@
+ALTER TABLE session_placements
+DROP COLUMN IF EXISTS session_type;
+
+DROP TABLE IF EXISTS course_components;
+
+ALTER TABLE venues
+DROP COLUMN IF EXISTS venue_kind;
@@ -0,0 +1,21 @@
+-- the kind of room a venue is e.g HALL, LAB or CLASSROOM, null for a general room
+ALTER TABLE venues
+ADD COLUMN venue_kind TEXT DEFAULT NULL;
+
+-- the kinds of session a course has every week. a course without components has one LECTURE
+-- component made of its course_duration and sessions_per_week
+CREATE TABLE course_components(
+    component_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    session_type TEXT NOT NULL CHECK (session_type IN ('LECTURE','LAB','TUTORIAL')),
+    duration INT NOT NULL CHECK (duration > 0),
+    sessions_per_week INT NOT NULL CHECK (sessions_per_week > 0),
+    venue_kind TEXT DEFAULT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (course_id, session_type)
+);
+
+ALTER TABLE session_placements
+ADD COLUMN session_type TEXT NOT NULL DEFAULT 'LECTURE';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS course_required_features;
+DROP TABLE IF EXISTS venue_features;
@@ -0,0 +1,22 @@
+-- what a venue has e.g PROJECTOR, COMPUTER_LAB, CHEMISTRY_LAB, WHEELCHAIR_ACCESS or EXAM_SEATING
+CREATE TABLE venue_features(
+    venue_id UUID NOT NULL REFERENCES venues(venue_id) ON DELETE CASCADE,
+    feature TEXT NOT NULL,
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY(venue_id,feature)
+);
+
+-- the features every venue of a course needs, or only the venues of one of its session types
+-- when session_type is set
+CREATE TABLE course_required_features(
+    requirement_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    course_id UUID NOT NULL REFERENCES courses(course_id) ON DELETE CASCADE,
+    university_id UUID NOT NULL REFERENCES universities(university_id) ON DELETE CASCADE,
+    session_type TEXT DEFAULT NULL CHECK (session_type IN ('LECTURE','LAB','TUTORIAL')),
+    feature TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX course_required_features_unique
+ON course_required_features(course_id,COALESCE(session_type,''),feature);
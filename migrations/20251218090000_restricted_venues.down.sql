@@ -0,0 +1,2 @@
+ALTER TABLE venues
+DROP COLUMN IF EXISTS is_restricted;
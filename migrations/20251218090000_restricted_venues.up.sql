@@ -0,0 +1,4 @@
+-- a restricted venue is only for the departments and faculties that own it in dept_venues and
+-- faculty_venues, the courses of other departments are never placed in it
+ALTER TABLE venues
+ADD COLUMN is_restricted BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,9 @@
+ALTER TABLE session_placements
+DROP COLUMN IF EXISTS group_count,
+DROP COLUMN IF EXISTS group_idx;
+
+ALTER TABLE course_components
+DROP COLUMN IF EXISTS max_group_size;
+
+ALTER TABLE courses
+DROP COLUMN IF EXISTS max_group_size;
@@ -0,0 +1,13 @@
+-- the most students one session of the course may hold, a bigger class is split into parallel
+-- groups of at most this many. null keeps every cohort of the course in one session
+ALTER TABLE courses
+ADD COLUMN max_group_size INT DEFAULT NULL CHECK (max_group_size IS NULL OR max_group_size > 0);
+
+-- overrides the course's max_group_size for the sessions of the component
+ALTER TABLE course_components
+ADD COLUMN max_group_size INT DEFAULT NULL CHECK (max_group_size IS NULL OR max_group_size > 0);
+
+-- the group of the course's students the session is for, group_count 1 for the whole class
+ALTER TABLE session_placements
+ADD COLUMN group_idx INT NOT NULL DEFAULT 0,
+ADD COLUMN group_count INT NOT NULL DEFAULT 1;